@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var aplCmd = &cobra.Command{
+	Use:   "apl",
+	Short: "convert APL rotations between json and text",
+	Long:  "convert APL rotations between the protojson format of .apl.json files and the human-readable APL text format",
+}
+
+var aplFmtCmd = &cobra.Command{
+	Use:   "fmt [file]",
+	Short: "print an APL rotation as formatted text",
+	Long:  "print an APL rotation in APL text or protojson format as formatted APL text, reads stdin if no file is given",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rot, err := readAPLRotation(args)
+		if err != nil {
+			return err
+		}
+		return writeAPLOutput([]byte(core.APLRotationToText(rot)))
+	},
+}
+
+var aplParseCmd = &cobra.Command{
+	Use:   "parse [file]",
+	Short: "convert an APL rotation to protojson",
+	Long:  "convert an APL rotation in APL text format to protojson, reads stdin if no file is given",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rot, err := readAPLRotation(args)
+		if err != nil {
+			return err
+		}
+		output, err := protojson.MarshalOptions{Multiline: true, Indent: "\t"}.Marshal(rot)
+		if err != nil {
+			return fmt.Errorf("failed to marshal rotation: %w", err)
+		}
+		return writeAPLOutput(append(output, '\n'))
+	},
+}
+
+func init() {
+	aplCmd.PersistentFlags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	aplCmd.AddCommand(aplFmtCmd)
+	aplCmd.AddCommand(aplParseCmd)
+}
+
+// Reads a rotation from the file in args or stdin, either in protojson or APL text format.
+func readAPLRotation(args []string) (*proto.APLRotation, error) {
+	name := "stdin"
+	var data []byte
+	var err error
+	if len(args) > 0 {
+		name = args[0]
+		data, err = os.ReadFile(name)
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		rot := &proto.APLRotation{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, rot); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return rot, nil
+	}
+
+	rot, err := core.APLRotationFromText(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return rot, nil
+}
+
+func writeAPLOutput(output []byte) error {
+	if outfile == "" {
+		_, err := os.Stdout.Write(output)
+		return err
+	}
+	if err := os.WriteFile(outfile, output, 0666); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
@@ -17,10 +17,17 @@ var simCmd = &cobra.Command{
 	Run:   simMain,
 }
 
+var (
+	combatLogFile   string
+	combatLogFormat string
+)
+
 func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	simCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	simCmd.Flags().StringVar(&combatLogFile, "combatlog", "", "location of combat log output file for the first iteration, disabled if empty")
+	simCmd.Flags().StringVar(&combatLogFormat, "combatlog-format", "jsonl", "format of the combat log file, either jsonl or csv")
 	simCmd.MarkFlagRequired("infile")
 }
 
@@ -35,6 +42,16 @@ func simMain(cmd *cobra.Command, args []string) {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
 
+	if combatLogFile != "" {
+		if combatLogFormat != "jsonl" && combatLogFormat != "csv" {
+			log.Fatalf("invalid combat log format %q, expected jsonl or csv", combatLogFormat)
+		}
+		if input.SimOptions == nil {
+			input.SimOptions = &proto.SimOptions{}
+		}
+		input.SimOptions.CombatLog = true
+	}
+
 	var output []byte
 	reporter := make(chan *proto.ProgressMetrics, 10)
 	core.RunRaidSimConcurrentAsync(input, reporter, "cmd-raid-sim")
@@ -50,6 +67,15 @@ func simMain(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	if combatLogFile != "" && finalResult.Error == nil {
+		if err := writeCombatLog(combatLogFile, combatLogFormat, finalResult.CombatLog); err != nil {
+			log.Fatalf("failed to write combat log: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote combat log: `%s` successfully.\n", combatLogFile)
+		}
+	}
+
 	output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
 	if err != nil {
 		log.Fatalf("failed to marshal final results: %s", err)
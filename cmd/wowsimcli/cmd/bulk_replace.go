@@ -65,9 +65,10 @@ func bulkSimMain(cmd *cobra.Command, args []string) {
 }
 
 type ItemReplacementInput struct {
-	Combinations bool              `json:"combinations"`
-	FastMode     bool              `json:"fast_mode"`
-	Items        []*proto.ItemSpec // spec for replacement
+	Combinations bool                        `json:"combinations"`
+	FastMode     bool                        `json:"fast_mode"`
+	Items        []*proto.ItemSpec           // spec for replacement
+	TalentSearch *proto.TalentSearchSettings `json:"talent_search"`
 }
 
 type ReplaceIter struct {
@@ -94,6 +95,7 @@ func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) stri
 			Items:              replaceInput.Items,
 			IterationsPerCombo: input.SimOptions.Iterations,
 			FastMode:           replaceInput.FastMode,
+			TalentSearch:       replaceInput.TalentSearch,
 		},
 	}
 	progress := make(chan *proto.ProgressMetrics, 100)
@@ -155,7 +157,7 @@ func printCombos(results *proto.BulkSimResult) string {
 	result := ""
 	foundBase := false
 	for i := 0; i < len(results.Results); i++ {
-		if len(results.Results[i].ItemsAdded) == 0 {
+		if len(results.Results[i].ItemsAdded) == 0 && results.Results[i].TalentLoadout == nil {
 			foundBase = true
 		}
 		result += printCombo(results.Results[i])
@@ -168,7 +170,7 @@ func printCombos(results *proto.BulkSimResult) string {
 
 func printCombo(combo *proto.BulkComboResult) string {
 	itemtext := "["
-	if len(combo.ItemsAdded) == 0 {
+	if len(combo.ItemsAdded) == 0 && combo.TalentLoadout == nil {
 		itemtext += "BASE RESULT"
 	}
 	for j, item := range combo.ItemsAdded {
@@ -178,5 +180,8 @@ func printCombo(combo *proto.BulkComboResult) string {
 		itemtext += fmt.Sprintf("%s@%s", core.ItemsByID[item.Item.Id].Name, item.Slot.String())
 	}
 	itemtext += "]"
+	if combo.TalentLoadout != nil {
+		itemtext += fmt.Sprintf("[%s]", combo.TalentLoadout.Name)
+	}
 	return fmt.Sprintf("%s,%0.1f\n", itemtext, combo.UnitMetrics.Dps.Avg)
 }
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var combatLogCSVHeader = []string{
+	"timestamp",
+	"type",
+	"source",
+	"target",
+	"spell_id",
+	"item_id",
+	"other_id",
+	"tag",
+	"spell_school",
+	"amount",
+	"outcome",
+	"periodic",
+	"threat",
+	"resource_type",
+	"value_before",
+	"value_after",
+	"duration",
+}
+
+func writeCombatLog(path string, format string, events []*proto.CombatLogEvent) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	switch format {
+	case "jsonl":
+		err = writeCombatLogJSONL(writer, events)
+	case "csv":
+		err = writeCombatLogCSV(writer, events)
+	default:
+		err = fmt.Errorf("unknown combat log format %q", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+func writeCombatLogJSONL(writer *bufio.Writer, events []*proto.CombatLogEvent) error {
+	marshaler := protojson.MarshalOptions{UseProtoNames: true}
+	for _, event := range events {
+		line, err := marshaler.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write(line); err != nil {
+			return err
+		}
+		if err := writer.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCombatLogCSV(writer *bufio.Writer, events []*proto.CombatLogEvent) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(combatLogCSVHeader); err != nil {
+		return err
+	}
+
+	formatFloat := func(val float64) string {
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+
+	for _, event := range events {
+		id := event.GetId()
+		record := []string{
+			formatFloat(event.Timestamp),
+			event.Type.String(),
+			event.Source,
+			event.Target,
+			strconv.Itoa(int(id.GetSpellId())),
+			strconv.Itoa(int(id.GetItemId())),
+			strconv.Itoa(int(id.GetOtherId())),
+			strconv.Itoa(int(id.GetTag())),
+			strconv.Itoa(int(event.SpellSchool)),
+			formatFloat(event.Amount),
+			event.Outcome,
+			strconv.FormatBool(event.Periodic),
+			formatFloat(event.Threat),
+			event.ResourceType.String(),
+			formatFloat(event.ValueBefore),
+			formatFloat(event.ValueAfter),
+			formatFloat(event.Duration),
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
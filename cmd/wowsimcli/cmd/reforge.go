@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var reforgeCmd = &cobra.Command{
+	Use:   "reforge",
+	Short: "optimize reforges and gems",
+	Long:  "optimize reforges and gems for the EP weights and stat breakpoints of an individual sim",
+	Run:   reforgeMain,
+}
+
+var (
+	reforgeConfigFile    string
+	reforgeGems          []int32
+	reforgeSimCandidates int32
+	reforgeSimIterations int32
+)
+
+func init() {
+	reforgeCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (IndividualSimSettings in protojson format)")
+	reforgeCmd.Flags().StringVar(&reforgeConfigFile, "config", "", "location of optimizer config file (ReforgeOptimizerRequest in protojson format without settings), defaults to the EP weights and stat caps of the input")
+	reforgeCmd.Flags().Int32SliceVar(&reforgeGems, "gems", nil, "candidate gem ids for all sockets, gems are left untouched if empty")
+	reforgeCmd.Flags().Int32Var(&reforgeSimCandidates, "sim-candidates", 0, "number of top candidates to verify by running full sims")
+	reforgeCmd.Flags().Int32Var(&reforgeSimIterations, "sim-iterations", 0, "iterations per verification sim, defaults to the iterations of the input")
+	reforgeCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	reforgeCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	reforgeCmd.MarkFlagRequired("infile")
+}
+
+func reforgeMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	settings := &proto.IndividualSimSettings{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, settings); err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	request := &proto.ReforgeOptimizerRequest{}
+	if reforgeConfigFile != "" {
+		configData, err := os.ReadFile(reforgeConfigFile)
+		if err != nil {
+			log.Fatalf("failed to load config json file %q: %v", reforgeConfigFile, err)
+		}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(configData, request); err != nil {
+			log.Fatalf("failed to load config json file: %s", err)
+		}
+	}
+	request.Settings = settings
+	if len(reforgeGems) > 0 {
+		request.GemOptions = reforgeGems
+	}
+	if cmd.Flags().Changed("sim-candidates") {
+		request.SimCandidates = reforgeSimCandidates
+	}
+	if cmd.Flags().Changed("sim-iterations") {
+		request.SimIterations = reforgeSimIterations
+	}
+
+	if verbose {
+		fmt.Printf("Optimizing reforges for %s...\n", settings.GetPlayer().GetName())
+	}
+	result := core.OptimizeReforges(request)
+	if result.Error != nil {
+		log.Fatalf("failed to optimize reforges: %s", result.Error.Message)
+	}
+	if !result.Optimal {
+		log.Printf("warning: reforge optimizer hit its node limit, results may not be optimal")
+	}
+
+	output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+	if err != nil {
+		log.Fatalf("failed to marshal final results: %s", err)
+	}
+
+	if outfile == "" {
+		fmt.Print(string(output))
+	} else {
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
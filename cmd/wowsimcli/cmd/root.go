@@ -17,7 +17,9 @@ func Execute(version string) {
 	rootCmd.AddCommand(newVersionCommand(version))
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(bulkCmd)
+	rootCmd.AddCommand(reforgeCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
+	rootCmd.AddCommand(aplCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
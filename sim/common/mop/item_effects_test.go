@@ -0,0 +1,126 @@
+package mop
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func init() {
+	core.RegisterAgentFactory(
+		proto.Player_ElementalShaman{},
+		proto.Spec_SpecElementalShaman,
+		newItemEffectTestAgent,
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_ElementalShaman)
+			if !ok {
+				panic("Invalid spec value for Elemental Shaman!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+// Deals a fixed amount of direct damage, so proc counts and copied amounts are easy to check,
+// and has a mana cost heal for cost modifiers.
+type itemEffectTestAgent struct {
+	core.Character
+	spell     *core.Spell
+	healSpell *core.Spell
+}
+
+func newItemEffectTestAgent(character *core.Character, _ *proto.Player) core.Agent {
+	agent := &itemEffectTestAgent{Character: *character}
+	agent.EnableManaBar()
+	return agent
+}
+
+func (agent *itemEffectTestAgent) GetCharacter() *core.Character {
+	return &agent.Character
+}
+
+func (agent *itemEffectTestAgent) Initialize() {
+	agent.spell = agent.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 42},
+		SpellSchool: core.SpellSchoolShadow,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagIgnoreArmor,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealDamage(sim, target, 1000, spell.OutcomeAlwaysHit)
+		},
+	})
+
+	agent.healSpell = agent.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 43},
+		SpellSchool: core.SpellSchoolNature,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagHelpful,
+
+		ManaCost: core.ManaCostOptions{
+			FlatCost: 1000,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, 1000, spell.OutcomeHealing)
+		},
+	})
+}
+
+func (agent *itemEffectTestAgent) ApplyTalents()                       {}
+func (agent *itemEffectTestAgent) Reset(_ *core.Simulation)            {}
+func (agent *itemEffectTestAgent) OnGCDReady(_ *core.Simulation)       {}
+func (agent *itemEffectTestAgent) OnEncounterStart(_ *core.Simulation) {}
+
+func newTestEquipment() *proto.EquipmentSpec {
+	equipment := &proto.EquipmentSpec{Items: make([]*proto.ItemSpec, proto.ItemSlot_ItemSlotTrinket1+1)}
+	for i := range equipment.Items {
+		equipment.Items[i] = &proto.ItemSpec{}
+	}
+	return equipment
+}
+
+func setupItemEffectTestSim(equipment *proto.EquipmentSpec, database *proto.SimDatabase) (*core.Simulation, *itemEffectTestAgent) {
+	sim := core.NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: equipment,
+							Database:  database,
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 93, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+		},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	return sim, sim.Raid.Parties[0].Players[0].(*itemEffectTestAgent)
+}
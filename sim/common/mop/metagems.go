@@ -1,6 +1,8 @@
 package mop
 
 import (
+	"time"
+
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
@@ -22,4 +24,149 @@ func init() {
 		character := agent.GetCharacter()
 		character.ApplyEquipScaling(stats.Armor, 1.02)
 	})
+
+	// Indomitable Primal Diamond
+	// Melee attacks which damage you have a chance to grant Fortitude, reducing all damage taken by 20%
+	// for 7 sec. (Approximately 2.57 procs per minute)
+	core.NewItemEffect(95344, func(agent core.Agent, _ proto.ItemLevelState) {
+		character := agent.GetCharacter()
+
+		fortitudeAura := character.RegisterAura(core.Aura{
+			Label:    "Fortitude",
+			ActionID: core.ActionID{SpellID: 137593},
+			Duration: time.Second * 7,
+		}).AttachMultiplicativePseudoStatBuff(&character.PseudoStats.DamageTakenMultiplier, 0.8)
+
+		core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
+			Name:     "Indomitable Primal Diamond",
+			ActionID: core.ActionID{ItemID: 95344},
+			DPM: character.NewStaticRPPMProcManager(core.ProcMaskMelee, core.RPPMConfig{
+				PPM: 2.56999993324,
+			}),
+			Outcome:  core.OutcomeLanded,
+			Callback: core.CallbackOnSpellHitTaken,
+			Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+				if result.Damage > 0 {
+					fortitudeAura.Activate(sim)
+				}
+			},
+		})
+	})
+
+	// Sinister Primal Diamond
+	// Your spells have a chance to grant Tempus Repit, increasing your spell haste by 30% for 10 sec.
+	// (Approximately [1.35 + Haste] procs per minute)
+	core.NewItemEffect(95345, func(agent core.Agent, _ proto.ItemLevelState) {
+		character := agent.GetCharacter()
+
+		tempusRepitAura := character.RegisterAura(core.Aura{
+			Label:    "Tempus Repit",
+			ActionID: core.ActionID{SpellID: 137590},
+			Duration: time.Second * 10,
+		}).AttachMultiplyCastSpeed(1.3)
+
+		core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
+			Name:     "Sinister Primal Diamond",
+			ActionID: core.ActionID{ItemID: 95345},
+			Harmful:  true,
+			DPM: character.NewStaticRPPMProcManager(core.ProcMaskSpellOrSpellProc, core.RPPMConfig{
+				PPM: 1.35000002384,
+			}.WithHasteMod().
+				WithSpecMod(-0.5, proto.Spec_SpecArcaneMage).
+				WithSpecMod(-0.34999999404, proto.Spec_SpecBalanceDruid).
+				WithClassMod(-0.40000000596, int(1<<proto.Class_ClassWarlock)),
+			),
+			Outcome:  core.OutcomeLanded,
+			Callback: core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt,
+			Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+				tempusRepitAura.Activate(sim)
+			},
+		})
+	})
+
+	// Capacitive Primal Diamond
+	// Your melee and ranged attacks have a chance to grant Capacitance. At 5 charges, your next
+	// attack strikes the target with lightning, dealing Nature damage. (Approximately 19.27 procs per minute)
+	core.NewItemEffect(95346, func(agent core.Agent, _ proto.ItemLevelState) {
+		character := agent.GetCharacter()
+
+		lightningStrike := character.RegisterSpell(core.SpellConfig{
+			ActionID:    core.ActionID{SpellID: 137597},
+			SpellSchool: core.SpellSchoolNature,
+			ProcMask:    core.ProcMaskEmpty,
+			Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+
+			DamageMultiplier: 1,
+			CritMultiplier:   character.DefaultCritMultiplier(),
+			ThreatMultiplier: 1,
+
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				baseDamage := character.CalcScalingSpellDmg(12.0) + max(spell.MeleeAttackPower(), spell.RangedAttackPower())*0.75
+				spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMeleeSpecialCritOnly)
+			},
+		})
+
+		capacitanceAura := character.RegisterAura(core.Aura{
+			Label:     "Capacitance",
+			ActionID:  core.ActionID{SpellID: 137596},
+			Duration:  time.Minute,
+			MaxStacks: 5,
+		})
+
+		core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
+			Name:     "Capacitive Primal Diamond",
+			ActionID: core.ActionID{ItemID: 95346},
+			Harmful:  true,
+			DPM: character.NewStaticRPPMProcManager(core.ProcMaskMeleeOrMeleeProc|core.ProcMaskRangedOrRangedProc, core.RPPMConfig{
+				PPM: 19.27000045776,
+			}.WithSpecMod(-0.5, proto.Spec_SpecArmsWarrior).
+				WithSpecMod(-0.5, proto.Spec_SpecFrostDeathKnight).
+				WithSpecMod(-0.5, proto.Spec_SpecUnholyDeathKnight).
+				WithSpecMod(-0.5, proto.Spec_SpecRetributionPaladin),
+			),
+			Outcome:  core.OutcomeLanded,
+			Callback: core.CallbackOnSpellHitDealt,
+			Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+				capacitanceAura.Activate(sim)
+				capacitanceAura.AddStack(sim)
+
+				if capacitanceAura.GetStacks() == capacitanceAura.MaxStacks {
+					capacitanceAura.Deactivate(sim)
+					lightningStrike.Cast(sim, result.Target)
+				}
+			},
+		})
+	})
+
+	// Courageous Primal Diamond
+	// Your healing spells have a chance to grant Lucidity, allowing all of your spells to be cast without
+	// mana for 4 sec. (Approximately [1.61 + Haste] procs per minute)
+	core.NewItemEffect(95347, func(agent core.Agent, _ proto.ItemLevelState) {
+		character := agent.GetCharacter()
+
+		lucidityAura := character.RegisterAura(core.Aura{
+			Label:    "Lucidity",
+			ActionID: core.ActionID{SpellID: 137288},
+			Duration: time.Second * 4,
+		}).AttachSpellMod(core.SpellModConfig{
+			Kind:         core.SpellMod_PowerCost_Pct,
+			ResourceType: proto.ResourceType_ResourceTypeMana,
+			FloatValue:   -100,
+		})
+
+		core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
+			Name:     "Courageous Primal Diamond",
+			ActionID: core.ActionID{ItemID: 95347},
+			DPM: character.NewStaticRPPMProcManager(core.ProcMaskSpellHealing, core.RPPMConfig{
+				PPM: 1.61000001431,
+			}.WithHasteMod().
+				WithSpecMod(-0.3, proto.Spec_SpecRestorationDruid).
+				WithSpecMod(-0.3, proto.Spec_SpecMistweaverMonk),
+			),
+			Callback: core.CallbackOnHealDealt,
+			Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+				lucidityAura.Activate(sim)
+			},
+		})
+	})
 }
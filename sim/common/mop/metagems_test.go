@@ -0,0 +1,66 @@
+package mop
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func setupMetaGemTestSim(gemID int32) (*core.Simulation, *itemEffectTestAgent) {
+	const headID = 990201
+
+	equipment := newTestEquipment()
+	equipment.Items[proto.ItemSlot_ItemSlotHead] = &proto.ItemSpec{Id: headID, Gems: []int32{gemID}}
+
+	return setupItemEffectTestSim(equipment, &proto.SimDatabase{
+		Items: []*proto.SimItem{{
+			Id:         headID,
+			Type:       proto.ItemType_ItemTypeHead,
+			GemSockets: []proto.GemColor{proto.GemColor_GemColorMeta},
+			ScalingOptions: map[int32]*proto.ScalingItemProperties{
+				int32(proto.ItemLevelState_Base): {Ilvl: 553},
+			},
+		}},
+		Gems: []*proto.SimGem{{
+			Id:    gemID,
+			Color: proto.GemColor_GemColorMeta,
+		}},
+	})
+}
+
+func TestIndomitablePrimalDiamond(t *testing.T) {
+	sim, agent := setupMetaGemTestSim(95344)
+
+	multiplierBefore := agent.PseudoStats.DamageTakenMultiplier
+	fortitude := agent.GetAuraByID(core.ActionID{SpellID: 137593})
+	fortitude.Activate(sim)
+	if multiplier := agent.PseudoStats.DamageTakenMultiplier / multiplierBefore; !core.WithinToleranceFloat64(0.8, multiplier, 0.0001) {
+		t.Fatalf("Expected Fortitude to reduce damage taken by 20%% but found a multiplier of %0.3f", multiplier)
+	}
+
+	fortitude.Deactivate(sim)
+	if multiplier := agent.PseudoStats.DamageTakenMultiplier; multiplier != multiplierBefore {
+		t.Fatalf("Expected damage taken multiplier %0.3f after Fortitude fades but found %0.3f", multiplierBefore, multiplier)
+	}
+}
+
+func TestCourageousPrimalDiamond(t *testing.T) {
+	sim, agent := setupMetaGemTestSim(95347)
+
+	costBefore := agent.healSpell.Cost.GetCurrentCost()
+	if costBefore <= 0 {
+		t.Fatalf("Expected the test heal to cost mana")
+	}
+
+	lucidity := agent.GetAuraByID(core.ActionID{SpellID: 137288})
+	lucidity.Activate(sim)
+	if cost := agent.healSpell.Cost.GetCurrentCost(); cost != 0 {
+		t.Fatalf("Expected spells to cost no mana during Lucidity but found %0.0f", cost)
+	}
+
+	lucidity.Deactivate(sim)
+	if cost := agent.healSpell.Cost.GetCurrentCost(); cost != costBefore {
+		t.Fatalf("Expected a cost of %0.0f after Lucidity fades but found %0.0f", costBefore, cost)
+	}
+}
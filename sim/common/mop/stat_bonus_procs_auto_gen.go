@@ -9,239 +9,6 @@ func RegisterAllProcs() {
 
 	// Procs
 	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Your attacks have a chance to grant 1 Intellect for 20s. ( 15% chance, 115 sec cooldown)
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnSpellHitDealt,
-	//	ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskRangedAuto | core.ProcMaskRangedSpecial | core.ProcMaskSpellDamage | core.ProcMaskMeleeProc | core.ProcMaskRangedProc | core.ProcMaskSpellDamageProc,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102293, ItemName: "Purified Bindings of Immerseus (N)"},
-	//	{ItemID: 104426, ItemName: "Purified Bindings of Immerseus (H)"},
-	//	{ItemID: 104675, ItemName: "Purified Bindings of Immerseus (Flexible)"},
-	//	{ItemID: 104924, ItemName: "Purified Bindings of Immerseus (LFR) (Celestial)"},
-	//	{ItemID: 105173, ItemName: "Purified Bindings of Immerseus (Warforged)"},
-	//	{ItemID: 105422, ItemName: "Purified Bindings of Immerseus (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Your heals have a 0.1% chance to trigger Multistrike, which causes instant additional healing to your
-	// target equal to 33% of the original healing done.
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnHealDealt | core.CallbackOnPeriodicHealDealt,
-	//	ProcMask: core.ProcMaskSpellHealing,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102294, ItemName: "Nazgrim's Burnished Insignia (N)"},
-	//	{ItemID: 104553, ItemName: "Nazgrim's Burnished Insignia (H)"},
-	//	{ItemID: 104802, ItemName: "Nazgrim's Burnished Insignia (Flexible)"},
-	//	{ItemID: 105051, ItemName: "Nazgrim's Burnished Insignia (LFR) (Celestial)"},
-	//	{ItemID: 105300, ItemName: "Nazgrim's Burnished Insignia (Warforged)"},
-	//	{ItemID: 105549, ItemName: "Nazgrim's Burnished Insignia (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Your attacks have a 0.01% chance to Cleave, dealing the same damage to up to 5 other nearby targets.
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt,
-	//	ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskRangedAuto | core.ProcMaskRangedSpecial | core.ProcMaskSpellDamage | core.ProcMaskMeleeProc | core.ProcMaskRangedProc | core.ProcMaskSpellDamageProc,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102295, ItemName: "Fusion-Fire Core (N)"},
-	//	{ItemID: 104463, ItemName: "Fusion-Fire Core (H)"},
-	//	{ItemID: 104712, ItemName: "Fusion-Fire Core (Flexible)"},
-	//	{ItemID: 104961, ItemName: "Fusion-Fire Core (LFR) (Celestial)"},
-	//	{ItemID: 105210, ItemName: "Fusion-Fire Core (Warforged)"},
-	//	{ItemID: 105459, ItemName: "Fusion-Fire Core (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Amplifies your Critical Strike damage and healing, Haste, Mastery, and Spirit by 1%.
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnHealDealt | core.CallbackOnPeriodicHealDealt,
-	//	ProcMask: core.ProcMaskSpellHealing,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102299, ItemName: "Prismatic Prison of Pride (N)"},
-	//	{ItemID: 104478, ItemName: "Prismatic Prison of Pride (H)"},
-	//	{ItemID: 104727, ItemName: "Prismatic Prison of Pride (Flexible)"},
-	//	{ItemID: 104976, ItemName: "Prismatic Prison of Pride (LFR) (Celestial)"},
-	//	{ItemID: 105225, ItemName: "Prismatic Prison of Pride (Warforged)"},
-	//	{ItemID: 105474, ItemName: "Prismatic Prison of Pride (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Your attacks have a 0.1% chance to trigger Multistrike, which deals instant additional damage to your
-	// target equal to 33% of the original damage dealt.
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt,
-	//	ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskRangedAuto | core.ProcMaskRangedSpecial | core.ProcMaskSpellDamage | core.ProcMaskMeleeProc | core.ProcMaskRangedProc | core.ProcMaskSpellDamageProc,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102300, ItemName: "Kardris' Toxic Totem (N)"},
-	//	{ItemID: 104544, ItemName: "Kardris' Toxic Totem (H)"},
-	//	{ItemID: 104793, ItemName: "Kardris' Toxic Totem (Flexible)"},
-	//	{ItemID: 105042, ItemName: "Kardris' Toxic Totem (LFR) (Celestial)"},
-	//	{ItemID: 105291, ItemName: "Kardris' Toxic Totem (Warforged)"},
-	//	{ItemID: 105540, ItemName: "Kardris' Toxic Totem (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Your attacks have a 0.1% chance to trigger Multistrike, which deals instant additional damage to your
-	// target equal to 33% of the original damage dealt.
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt,
-	//	ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskRangedAuto | core.ProcMaskRangedSpecial | core.ProcMaskSpellDamage | core.ProcMaskMeleeProc | core.ProcMaskRangedProc | core.ProcMaskSpellDamageProc,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102301, ItemName: "Haromm's Talisman (N)"},
-	//	{ItemID: 104531, ItemName: "Haromm's Talisman (H)"},
-	//	{ItemID: 104780, ItemName: "Haromm's Talisman (Flexible)"},
-	//	{ItemID: 105029, ItemName: "Haromm's Talisman (LFR) (Celestial)"},
-	//	{ItemID: 105278, ItemName: "Haromm's Talisman (Warforged)"},
-	//	{ItemID: 105527, ItemName: "Haromm's Talisman (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Your attacks have a 0.01% chance to Cleave, dealing the same damage to up to 5 other nearby targets.
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt,
-	//	ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskRangedAuto | core.ProcMaskRangedSpecial | core.ProcMaskSpellDamage | core.ProcMaskMeleeProc | core.ProcMaskRangedProc | core.ProcMaskSpellDamageProc,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102302, ItemName: "Sigil of Rampage (N)"},
-	//	{ItemID: 104584, ItemName: "Sigil of Rampage (H)"},
-	//	{ItemID: 104833, ItemName: "Sigil of Rampage (Flexible)"},
-	//	{ItemID: 105082, ItemName: "Sigil of Rampage (LFR) (Celestial)"},
-	//	{ItemID: 105331, ItemName: "Sigil of Rampage (Warforged)"},
-	//	{ItemID: 105580, ItemName: "Sigil of Rampage (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Your attacks have a 0.01% chance to Cleave, dealing the same damage to up to 5 other nearby targets.
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt,
-	//	ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskRangedAuto | core.ProcMaskRangedSpecial | core.ProcMaskSpellDamage | core.ProcMaskMeleeProc | core.ProcMaskRangedProc | core.ProcMaskSpellDamageProc,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102303, ItemName: "Frenzied Crystal of Rage (N)"},
-	//	{ItemID: 104576, ItemName: "Frenzied Crystal of Rage (H)"},
-	//	{ItemID: 104825, ItemName: "Frenzied Crystal of Rage (Flexible)"},
-	//	{ItemID: 105074, ItemName: "Frenzied Crystal of Rage (LFR) (Celestial)"},
-	//	{ItemID: 105323, ItemName: "Frenzied Crystal of Rage (Warforged)"},
-	//	{ItemID: 105572, ItemName: "Frenzied Crystal of Rage (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Your heals have a 0.01% chance to Cleave, dealing the same healing to up to 5 other nearby targets.
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnHealDealt | core.CallbackOnPeriodicHealDealt,
-	//	ProcMask: core.ProcMaskSpellHealing,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102304, ItemName: "Thok's Acid-Grooved Tooth (N)"},
-	//	{ItemID: 104611, ItemName: "Thok's Acid-Grooved Tooth (H)"},
-	//	{ItemID: 104860, ItemName: "Thok's Acid-Grooved Tooth (Flexible)"},
-	//	{ItemID: 105109, ItemName: "Thok's Acid-Grooved Tooth (LFR) (Celestial)"},
-	//	{ItemID: 105358, ItemName: "Thok's Acid-Grooved Tooth (Warforged)"},
-	//	{ItemID: 105607, ItemName: "Thok's Acid-Grooved Tooth (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Amplifies your Critical Strike damage and healing, Haste, Mastery, and Spirit by 1%.
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnSpellHitDealt,
-	//	ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskRangedSpecial | core.ProcMaskSpellDamage | core.ProcMaskMeleeProc | core.ProcMaskRangedProc | core.ProcMaskSpellDamageProc,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102305, ItemName: "Thok's Tail Tip (N)"},
-	//	{ItemID: 104613, ItemName: "Thok's Tail Tip (H)"},
-	//	{ItemID: 104862, ItemName: "Thok's Tail Tip (Flexible)"},
-	//	{ItemID: 105111, ItemName: "Thok's Tail Tip (LFR) (Celestial)"},
-	//	{ItemID: 105360, ItemName: "Thok's Tail Tip (Warforged)"},
-	//	{ItemID: 105609, ItemName: "Thok's Tail Tip (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Your heals have a chance to grant you 19260 Spirit for 10s. Every 0.5 sec, this effect is reduced by 963
-	// Spirit. (Approximately 0.92 procs per minute)
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnHealDealt | core.CallbackOnPeriodicHealDealt,
-	//	ProcMask: core.ProcMaskSpellHealing,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102309, ItemName: "Dysmorphic Samophlange of Discontinuity (N)"},
-	//	{ItemID: 104619, ItemName: "Dysmorphic Samophlange of Discontinuity (H)"},
-	//	{ItemID: 104868, ItemName: "Dysmorphic Samophlange of Discontinuity (Flexible)"},
-	//	{ItemID: 105117, ItemName: "Dysmorphic Samophlange of Discontinuity (LFR) (Celestial)"},
-	//	{ItemID: 105366, ItemName: "Dysmorphic Samophlange of Discontinuity (Warforged)"},
-	//	{ItemID: 105615, ItemName: "Dysmorphic Samophlange of Discontinuity (Heroic Warforged)"},
-	// })
-	
-	// TODO: Manual implementation required
-	//       This can be ignored if the effect has already been implemented.
-	//       With next db run the item will be removed if implemented.
-	//
-	// Your melee and ranged attacks have a chance to grant you 19260 Agility for 10s. Every 0.5 sec this effect
-	// decrements by 963 Agility. (Approximately 1.00 procs per minute)
-	// shared.NewProcStatBonusEffectWithVariants(shared.ProcStatBonusEffect{
-	//	Callback: core.CallbackOnSpellHitDealt,
-	//	ProcMask: core.ProcMaskMeleeMHAuto | core.ProcMaskMeleeOHAuto | core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeOHSpecial | core.ProcMaskRangedAuto | core.ProcMaskRangedSpecial | core.ProcMaskSpellDamage,
-	//	Outcome:  core.OutcomeLanded,
-	//	Harmful:  true,
-	// }, []shared.ItemVariant{
-	//	{ItemID: 102311, ItemName: "Ticking Ebon Detonator (N)"},
-	//	{ItemID: 104616, ItemName: "Ticking Ebon Detonator (H)"},
-	//	{ItemID: 104865, ItemName: "Ticking Ebon Detonator (Flexible)"},
-	//	{ItemID: 105114, ItemName: "Ticking Ebon Detonator (LFR) (Celestial)"},
-	//	{ItemID: 105363, ItemName: "Ticking Ebon Detonator (Warforged)"},
-	//	{ItemID: 105612, ItemName: "Ticking Ebon Detonator (Heroic Warforged)"},
-	// })
-	
 	// TODO: Manual implementation required
 	//       This can be ignored if the effect has already been implemented.
 	//       With next db run the item will be removed if implemented.
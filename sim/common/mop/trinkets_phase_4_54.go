@@ -21,6 +21,153 @@ type readinessTrinketConfig struct {
 	cdrAuraIDs       map[proto.Spec]int32
 }
 
+// 5.4 special effects scale with the item level of the trinket.
+// Each coefficient yields a percentage: the amplification amount or the proc chance.
+// Tooltip values in this file are for the Normal versions (item level 553).
+const (
+	amplificationCoeff = 0.00129999998
+	multistrikeCoeff   = 0.00310000009
+	cleaveCoeff        = 0.00124999997
+)
+
+type specialEffectFactory func(character *core.Character, itemID int32, state proto.ItemLevelState, versionLabel string, isHealing bool) *core.Aura
+
+type specialEffectStatProcConfig struct {
+	auraLabel  string
+	auraID     int32
+	stat       stats.Stat
+	coeff      float64
+	duration   time.Duration
+	ppm        float64
+	procChance float64
+	icd        time.Duration
+}
+
+type specialEffectTrinketConfig struct {
+	itemVersionMap shared.ItemVersionMap
+	trinketLabel   string
+	isHealing      bool
+	specialEffect  specialEffectFactory
+	statProc       *specialEffectStatProcConfig
+}
+
+// Amplifies your Critical Strike damage and healing, Haste, Mastery, and Spirit by X%.
+func applyAmplification(character *core.Character, itemID int32, state proto.ItemLevelState, versionLabel string, _ bool) *core.Aura {
+	multiplier := 1 + core.GetItemEffectRandomPropPointsForItem(itemID, state)*amplificationCoeff/100
+
+	return core.MakePermanent(character.RegisterAura(core.Aura{
+		Label:    fmt.Sprintf("Amplification %d %s", itemID, versionLabel),
+		ActionID: core.ActionID{SpellID: 146051, Tag: itemID},
+	})).AttachMultiplicativePseudoStatBuff(
+		&character.PseudoStats.CritDamageMultiplier, multiplier,
+	).AttachStatDependency(
+		character.NewDynamicMultiplyStat(stats.HasteRating, multiplier),
+	).AttachStatDependency(
+		character.NewDynamicMultiplyStat(stats.MasteryRating, multiplier),
+	).AttachStatDependency(
+		character.NewDynamicMultiplyStat(stats.Spirit, multiplier),
+	)
+}
+
+// Your attacks (or heals) have a X% chance to trigger Multistrike, which causes instant additional
+// damage (or healing) to your target equal to 33% of the original amount.
+func applyMultistrike(character *core.Character, itemID int32, state proto.ItemLevelState, versionLabel string, isHealing bool) *core.Aura {
+	procChance := core.GetItemEffectRandomPropPointsForItem(itemID, state) * multistrikeCoeff / 100
+
+	var copyAmount float64
+	multistrikeSpell := character.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: core.Ternary[int32](isHealing, 146177, 146061), Tag: itemID},
+		SpellSchool: core.SpellSchoolPhysical,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagIgnoreModifiers | core.SpellFlagNoSpellMods | core.SpellFlagPassiveSpell | core.SpellFlagNoOnCastComplete | core.Ternary(isHealing, core.SpellFlagHelpful, 0),
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if isHealing {
+				spell.CalcAndDealHealing(sim, target, copyAmount, spell.OutcomeHealing)
+			} else {
+				spell.CalcAndDealDamage(sim, target, copyAmount, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	return core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
+		Name:       fmt.Sprintf("Multistrike %d %s", itemID, versionLabel),
+		Harmful:    !isHealing,
+		ProcChance: procChance,
+		ProcMask:   core.Ternary(isHealing, core.ProcMaskSpellHealing, core.ProcMaskDirect|core.ProcMaskProc),
+		Callback:   core.Ternary(isHealing, core.CallbackOnHealDealt|core.CallbackOnPeriodicHealDealt, core.CallbackOnSpellHitDealt|core.CallbackOnPeriodicDamageDealt),
+		Outcome:    core.OutcomeLanded,
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			if result.Damage <= 0 {
+				return
+			}
+			copyAmount = result.Damage * 0.33
+			multistrikeSpell.Cast(sim, result.Target)
+		},
+	})
+}
+
+// Your attacks (or heals) have a X% chance to Cleave, dealing the same damage (or healing) to up to 5
+// other nearby targets.
+func applyCleave(character *core.Character, itemID int32, state proto.ItemLevelState, versionLabel string, isHealing bool) *core.Aura {
+	procChance := core.GetItemEffectRandomPropPointsForItem(itemID, state) * cleaveCoeff / 100
+	maxTargets := 5
+
+	var copyAmount float64
+	cleaveSpell := character.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: core.Ternary[int32](isHealing, 146159, 146137), Tag: itemID},
+		SpellSchool: core.SpellSchoolPhysical,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagIgnoreModifiers | core.SpellFlagNoSpellMods | core.SpellFlagPassiveSpell | core.SpellFlagNoOnCastComplete | core.Ternary(isHealing, core.SpellFlagHelpful, 0),
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if isHealing {
+				numHealed := 0
+				for _, unit := range sim.Raid.AllPlayerUnits {
+					if numHealed >= maxTargets {
+						break
+					}
+					if unit == target || !unit.IsEnabled() {
+						continue
+					}
+					spell.CalcAndDealHealing(sim, unit, copyAmount, spell.OutcomeHealing)
+					numHealed++
+				}
+				return
+			}
+
+			// FIXME: This ignores target modifiers and assumes they are the same for the original target and the cleaved targets
+			numHits := min(int32(maxTargets), sim.Environment.ActiveTargetCount()-1)
+			for range numHits {
+				target = sim.Environment.NextActiveTargetUnit(target)
+				spell.CalcAndDealDamage(sim, target, copyAmount, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	return core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
+		Name:       fmt.Sprintf("Cleave %d %s", itemID, versionLabel),
+		Harmful:    !isHealing,
+		ProcChance: procChance,
+		ProcMask:   core.Ternary(isHealing, core.ProcMaskSpellHealing, core.ProcMaskDirect|core.ProcMaskProc),
+		Callback:   core.Ternary(isHealing, core.CallbackOnHealDealt|core.CallbackOnPeriodicHealDealt, core.CallbackOnSpellHitDealt|core.CallbackOnPeriodicDamageDealt),
+		Outcome:    core.OutcomeLanded,
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			if result.Damage <= 0 || (!isHealing && sim.Environment.ActiveTargetCount() < 2) {
+				return
+			}
+			copyAmount = result.Damage
+			cleaveSpell.Cast(sim, result.Target)
+		},
+	})
+}
+
 func init() {
 	newReadinessTrinket := func(config *readinessTrinketConfig) {
 		config.itemVersionMap.RegisterAll(func(version shared.ItemVersion, itemID int32, versionLabel string) {
@@ -76,10 +223,10 @@ func init() {
 	}
 
 	// Assurance of Consequence
-	// Increases the cooldown recovery rate of six of your major abilities by 47%.
+	// Increases the cooldown recovery rate of six of your major abilities by 39%.
 	// Effective for Agility-based damage roles only.
 	//
-	// Your attacks have a chance to grant you 14039 Agility for 20 sec.
+	// Your attacks have a chance to grant you 3829 Agility for 20 sec.
 	// (15% chance, 115 sec cooldown) (Proc chance: 15%, 1.917m cooldown)
 	newReadinessTrinket(&readinessTrinketConfig{
 		itemVersionMap: shared.ItemVersionMap{
@@ -128,10 +275,10 @@ func init() {
 	})
 
 	// Evil Eye of Galakras
-	// Increases the cooldown recovery rate of six of your major abilities by 1%. Effective for Strength-based
+	// Increases the cooldown recovery rate of six of your major abilities by 39%. Effective for Strength-based
 	// damage roles only.
 	//
-	// Your attacks have a chance to grant you 11761 Strength for 10 sec.
+	// Your attacks have a chance to grant you 3829 Strength for 10 sec.
 	// (15% chance, 55 sec cooldown) (Proc chance: 15%, 55s cooldown)
 	newReadinessTrinket(&readinessTrinketConfig{
 		itemVersionMap: shared.ItemVersionMap{
@@ -165,4 +312,466 @@ func init() {
 			proto.Spec_SpecProtectionWarrior: 145992,
 		},
 	})
+
+	newSpecialEffectTrinket := func(config *specialEffectTrinketConfig) {
+		config.itemVersionMap.RegisterAll(func(version shared.ItemVersion, itemID int32, versionLabel string) {
+			core.NewItemEffect(itemID, func(agent core.Agent, state proto.ItemLevelState) {
+				character := agent.GetCharacter()
+				eligibleSlots := character.ItemSwap.EligibleSlotsForItem(itemID)
+
+				specialAura := config.specialEffect(character, itemID, state, versionLabel, config.isHealing)
+				character.ItemSwap.RegisterProcWithSlots(itemID, specialAura, eligibleSlots)
+
+				if config.statProc == nil {
+					return
+				}
+
+				statProc := config.statProc
+				stats := stats.Stats{}
+				stats[statProc.stat] = core.GetItemEffectScaling(itemID, statProc.coeff, state)
+
+				aura := character.NewTemporaryStatsAura(
+					fmt.Sprintf("%s %s", statProc.auraLabel, versionLabel),
+					core.ActionID{SpellID: statProc.auraID},
+					stats,
+					statProc.duration,
+				)
+
+				procMask := core.Ternary(config.isHealing, core.ProcMaskSpellHealing, core.ProcMaskDirect|core.ProcMaskProc)
+				var dpm *core.DynamicProcManager
+				if statProc.ppm > 0 {
+					dpm = character.NewRPPMProcManager(itemID, false, procMask, core.RPPMConfig{
+						PPM: statProc.ppm,
+					})
+				}
+
+				triggerAura := core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
+					Name:       fmt.Sprintf("%s %s", config.trinketLabel, versionLabel),
+					Harmful:    !config.isHealing,
+					DPM:        dpm,
+					ProcChance: statProc.procChance,
+					ICD:        statProc.icd,
+					ProcMask:   core.Ternary(dpm == nil, procMask, 0),
+					Outcome:    core.OutcomeLanded,
+					Callback:   core.Ternary(config.isHealing, core.CallbackOnHealDealt|core.CallbackOnPeriodicHealDealt, core.CallbackOnSpellHitDealt),
+					Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+						aura.Activate(sim)
+					},
+				})
+
+				if statProc.icd > 0 {
+					aura.Icd = triggerAura.Icd
+				}
+				character.AddStatProcBuff(itemID, aura, false, eligibleSlots)
+				character.ItemSwap.RegisterProcWithSlots(itemID, triggerAura, eligibleSlots)
+			})
+		})
+	}
+
+	// Purified Bindings of Immerseus
+	// Amplifies your Critical Strike damage and healing, Haste, Mastery, and Spirit by 5.14%.
+	//
+	// Your attacks have a chance to grant 3829 Intellect for 20s. (15% chance, 115 sec cooldown)
+	newSpecialEffectTrinket(&specialEffectTrinketConfig{
+		itemVersionMap: shared.ItemVersionMap{
+			shared.ItemVersionLFR:             104924,
+			shared.ItemVersionNormal:          102293,
+			shared.ItemVersionHeroic:          104426,
+			shared.ItemVersionWarforged:       105173,
+			shared.ItemVersionHeroicWarforged: 105422,
+			shared.ItemVersionFlexible:        104675,
+		},
+		trinketLabel:  "Purified Bindings of Immerseus",
+		specialEffect: applyAmplification,
+		statProc: &specialEffectStatProcConfig{
+			auraLabel:  "Expanded Mind",
+			auraID:     146046,
+			stat:       stats.Intellect,
+			coeff:      0.96799999475,
+			duration:   time.Second * 20,
+			procChance: 0.15,
+			icd:        time.Second * 115,
+		},
+	})
+
+	// Thok's Tail Tip
+	// Amplifies your Critical Strike damage and healing, Haste, Mastery, and Spirit by 5.14%.
+	//
+	// Your attacks have a chance to grant 3829 Strength for 20s. (15% chance, 115 sec cooldown)
+	newSpecialEffectTrinket(&specialEffectTrinketConfig{
+		itemVersionMap: shared.ItemVersionMap{
+			shared.ItemVersionLFR:             105111,
+			shared.ItemVersionNormal:          102305,
+			shared.ItemVersionHeroic:          104613,
+			shared.ItemVersionWarforged:       105360,
+			shared.ItemVersionHeroicWarforged: 105609,
+			shared.ItemVersionFlexible:        104862,
+		},
+		trinketLabel:  "Thok's Tail Tip",
+		specialEffect: applyAmplification,
+		statProc: &specialEffectStatProcConfig{
+			auraLabel:  "Determination",
+			auraID:     146250,
+			stat:       stats.Strength,
+			coeff:      0.96799999475,
+			duration:   time.Second * 20,
+			procChance: 0.15,
+			icd:        time.Second * 115,
+		},
+	})
+
+	// Prismatic Prison of Pride
+	// Amplifies your Critical Strike damage and healing, Haste, Mastery, and Spirit by 5.14%.
+	//
+	// Your heals have a chance to grant 3829 Intellect for 20s. (15% chance, 115 sec cooldown)
+	newSpecialEffectTrinket(&specialEffectTrinketConfig{
+		itemVersionMap: shared.ItemVersionMap{
+			shared.ItemVersionLFR:             104976,
+			shared.ItemVersionNormal:          102299,
+			shared.ItemVersionHeroic:          104478,
+			shared.ItemVersionWarforged:       105225,
+			shared.ItemVersionHeroicWarforged: 105474,
+			shared.ItemVersionFlexible:        104727,
+		},
+		trinketLabel:  "Prismatic Prison of Pride",
+		isHealing:     true,
+		specialEffect: applyAmplification,
+		statProc: &specialEffectStatProcConfig{
+			auraLabel:  "Expanded Mind (Prismatic Prison of Pride)",
+			auraID:     146314,
+			stat:       stats.Intellect,
+			coeff:      0.96799999475,
+			duration:   time.Second * 20,
+			procChance: 0.15,
+			icd:        time.Second * 115,
+		},
+	})
+
+	// Haromm's Talisman
+	// Your attacks have a 12.26% chance to trigger Multistrike, which deals instant additional damage to your
+	// target equal to 33% of the original damage dealt.
+	//
+	// Your attacks have a chance to grant you 3829 Agility for 10s. (Approximately 0.92 procs per minute)
+	newSpecialEffectTrinket(&specialEffectTrinketConfig{
+		itemVersionMap: shared.ItemVersionMap{
+			shared.ItemVersionLFR:             105029,
+			shared.ItemVersionNormal:          102301,
+			shared.ItemVersionHeroic:          104531,
+			shared.ItemVersionWarforged:       105278,
+			shared.ItemVersionHeroicWarforged: 105527,
+			shared.ItemVersionFlexible:        104780,
+		},
+		trinketLabel:  "Haromm's Talisman",
+		specialEffect: applyMultistrike,
+		statProc: &specialEffectStatProcConfig{
+			auraLabel: "Vicious",
+			auraID:    148903,
+			stat:      stats.Agility,
+			coeff:     0.96799999475,
+			duration:  time.Second * 10,
+			ppm:       0.92000001669,
+		},
+	})
+
+	// Kardris' Toxic Totem
+	// Your attacks have a 12.26% chance to trigger Multistrike, which deals instant additional damage to your
+	// target equal to 33% of the original damage dealt.
+	//
+	// Your harmful spells have a chance to grant you 3829 Intellect for 10s. (Approximately 0.92 procs per minute)
+	newSpecialEffectTrinket(&specialEffectTrinketConfig{
+		itemVersionMap: shared.ItemVersionMap{
+			shared.ItemVersionLFR:             105042,
+			shared.ItemVersionNormal:          102300,
+			shared.ItemVersionHeroic:          104544,
+			shared.ItemVersionWarforged:       105291,
+			shared.ItemVersionHeroicWarforged: 105540,
+			shared.ItemVersionFlexible:        104793,
+		},
+		trinketLabel:  "Kardris' Toxic Totem",
+		specialEffect: applyMultistrike,
+		statProc: &specialEffectStatProcConfig{
+			auraLabel: "Toxic Power",
+			auraID:    148906,
+			stat:      stats.Intellect,
+			coeff:     0.96799999475,
+			duration:  time.Second * 10,
+			ppm:       0.92000001669,
+		},
+	})
+
+	// Nazgrim's Burnished Insignia
+	// Your heals have a 12.26% chance to trigger Multistrike, which causes instant additional healing to your
+	// target equal to 33% of the original healing done.
+	//
+	// Your heals have a chance to grant you 3829 Intellect for 10s. (Approximately 0.92 procs per minute)
+	newSpecialEffectTrinket(&specialEffectTrinketConfig{
+		itemVersionMap: shared.ItemVersionMap{
+			shared.ItemVersionLFR:             105051,
+			shared.ItemVersionNormal:          102294,
+			shared.ItemVersionHeroic:          104553,
+			shared.ItemVersionWarforged:       105300,
+			shared.ItemVersionHeroicWarforged: 105549,
+			shared.ItemVersionFlexible:        104802,
+		},
+		trinketLabel:  "Nazgrim's Burnished Insignia",
+		isHealing:     true,
+		specialEffect: applyMultistrike,
+		statProc: &specialEffectStatProcConfig{
+			auraLabel: "Mark of Salvation",
+			auraID:    148908,
+			stat:      stats.Intellect,
+			coeff:     0.96799999475,
+			duration:  time.Second * 10,
+			ppm:       0.92000001669,
+		},
+	})
+
+	// Sigil of Rampage
+	// Your attacks have a 4.95% chance to Cleave, dealing the same damage to up to 5 other nearby targets.
+	//
+	// Your attacks have a chance to grant you 3829 Agility for 10s. (Approximately 0.92 procs per minute)
+	newSpecialEffectTrinket(&specialEffectTrinketConfig{
+		itemVersionMap: shared.ItemVersionMap{
+			shared.ItemVersionLFR:             105082,
+			shared.ItemVersionNormal:          102302,
+			shared.ItemVersionHeroic:          104584,
+			shared.ItemVersionWarforged:       105331,
+			shared.ItemVersionHeroicWarforged: 105580,
+			shared.ItemVersionFlexible:        104833,
+		},
+		trinketLabel:  "Sigil of Rampage",
+		specialEffect: applyCleave,
+		statProc: &specialEffectStatProcConfig{
+			auraLabel: "Ferocity",
+			auraID:    148896,
+			stat:      stats.Agility,
+			coeff:     0.96799999475,
+			duration:  time.Second * 10,
+			ppm:       0.92000001669,
+		},
+	})
+
+	// Fusion-Fire Core
+	// Your attacks have a 4.95% chance to Cleave, dealing the same damage to up to 5 other nearby targets.
+	//
+	// Your attacks have a chance to grant you 3829 Strength for 10s. (Approximately 0.92 procs per minute)
+	newSpecialEffectTrinket(&specialEffectTrinketConfig{
+		itemVersionMap: shared.ItemVersionMap{
+			shared.ItemVersionLFR:             104961,
+			shared.ItemVersionNormal:          102295,
+			shared.ItemVersionHeroic:          104463,
+			shared.ItemVersionWarforged:       105210,
+			shared.ItemVersionHeroicWarforged: 105459,
+			shared.ItemVersionFlexible:        104712,
+		},
+		trinketLabel:  "Fusion-Fire Core",
+		specialEffect: applyCleave,
+		statProc: &specialEffectStatProcConfig{
+			auraLabel: "Tenacious",
+			auraID:    148899,
+			stat:      stats.Strength,
+			coeff:     0.96799999475,
+			duration:  time.Second * 10,
+			ppm:       0.92000001669,
+		},
+	})
+
+	// Frenzied Crystal of Rage
+	// Your attacks have a 4.95% chance to Cleave, dealing the same damage to up to 5 other nearby targets.
+	//
+	// Your harmful spells have a chance to grant you 3829 Intellect for 10s. (Approximately 0.92 procs per minute)
+	newSpecialEffectTrinket(&specialEffectTrinketConfig{
+		itemVersionMap: shared.ItemVersionMap{
+			shared.ItemVersionLFR:             105074,
+			shared.ItemVersionNormal:          102303,
+			shared.ItemVersionHeroic:          104576,
+			shared.ItemVersionWarforged:       105323,
+			shared.ItemVersionHeroicWarforged: 105572,
+			shared.ItemVersionFlexible:        104825,
+		},
+		trinketLabel:  "Frenzied Crystal of Rage",
+		specialEffect: applyCleave,
+		statProc: &specialEffectStatProcConfig{
+			auraLabel: "Extravagant Visions",
+			auraID:    148897,
+			stat:      stats.Intellect,
+			coeff:     0.96799999475,
+			duration:  time.Second * 10,
+			ppm:       0.92000001669,
+		},
+	})
+
+	// Thok's Acid-Grooved Tooth
+	// Your heals have a 4.95% chance to Cleave, dealing the same healing to up to 5 other nearby targets.
+	//
+	// Your heals have a chance to grant you 3829 Intellect for 10s. (Approximately 0.92 procs per minute)
+	newSpecialEffectTrinket(&specialEffectTrinketConfig{
+		itemVersionMap: shared.ItemVersionMap{
+			shared.ItemVersionLFR:             105109,
+			shared.ItemVersionNormal:          102304,
+			shared.ItemVersionHeroic:          104611,
+			shared.ItemVersionWarforged:       105358,
+			shared.ItemVersionHeroicWarforged: 105607,
+			shared.ItemVersionFlexible:        104860,
+		},
+		trinketLabel:  "Thok's Acid-Grooved Tooth",
+		isHealing:     true,
+		specialEffect: applyCleave,
+		statProc: &specialEffectStatProcConfig{
+			auraLabel: "Soothing Power",
+			auraID:    148911,
+			stat:      stats.Intellect,
+			coeff:     0.96799999475,
+			duration:  time.Second * 10,
+			ppm:       0.92000001669,
+		},
+	})
+
+	newDecayingStatTrinket := func(itemVersionMap shared.ItemVersionMap, label string, auraLabel string, auraID int32, stat stats.Stat, isHealing bool, ppm float64) {
+		itemVersionMap.RegisterAll(func(version shared.ItemVersion, itemID int32, versionLabel string) {
+			core.NewItemEffect(itemID, func(agent core.Agent, state proto.ItemLevelState) {
+				character := agent.GetCharacter()
+				bonusPerStack := stats.Stats{}
+				bonusPerStack[stat] = core.GetItemEffectScaling(itemID, 0.5189999938, state)
+
+				var decayAction *core.PendingAction
+				aura := core.MakeStackingAura(character, core.StackingStatAura{
+					Aura: core.Aura{
+						Label:     fmt.Sprintf("%s %s", auraLabel, versionLabel),
+						ActionID:  core.ActionID{SpellID: auraID},
+						Duration:  time.Second * 10,
+						MaxStacks: 20,
+						OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+							if decayAction != nil {
+								decayAction.Cancel(sim)
+								decayAction = nil
+							}
+						},
+					},
+					BonusPerStack: bonusPerStack,
+				})
+
+				procMask := core.Ternary(isHealing, core.ProcMaskSpellHealing, core.ProcMaskMeleeOrMeleeProc|core.ProcMaskRangedOrRangedProc)
+				triggerAura := core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
+					Name:    fmt.Sprintf("%s %s", label, versionLabel),
+					Harmful: !isHealing,
+					DPM: character.NewRPPMProcManager(itemID, false, procMask, core.RPPMConfig{
+						PPM: ppm,
+					}),
+					Outcome:  core.OutcomeLanded,
+					Callback: core.Ternary(isHealing, core.CallbackOnHealDealt|core.CallbackOnPeriodicHealDealt, core.CallbackOnSpellHitDealt),
+					Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+						if decayAction != nil {
+							decayAction.Cancel(sim)
+						}
+						aura.Activate(sim)
+						aura.SetStacks(sim, aura.MaxStacks)
+
+						// Every 0.5 sec the effect loses one stack.
+						decayAction = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+							Period:   time.Millisecond * 500,
+							NumTicks: int(aura.MaxStacks) - 1,
+							OnAction: func(sim *core.Simulation) {
+								if aura.IsActive() {
+									aura.RemoveStack(sim)
+								}
+							},
+						})
+					},
+				})
+
+				eligibleSlots := character.ItemSwap.EligibleSlotsForItem(itemID)
+				character.AddStatProcBuff(itemID, aura, false, eligibleSlots)
+				character.ItemSwap.RegisterProcWithSlots(itemID, triggerAura, eligibleSlots)
+			})
+		})
+	}
+
+	// Ticking Ebon Detonator
+	// Your melee and ranged attacks have a chance to grant you 41060 Agility for 10s. Every 0.5 sec this effect
+	// decrements by 2053 Agility. (Approximately 1.00 procs per minute)
+	newDecayingStatTrinket(shared.ItemVersionMap{
+		shared.ItemVersionLFR:             105114,
+		shared.ItemVersionNormal:          102311,
+		shared.ItemVersionHeroic:          104616,
+		shared.ItemVersionWarforged:       105363,
+		shared.ItemVersionHeroicWarforged: 105612,
+		shared.ItemVersionFlexible:        104865,
+	}, "Ticking Ebon Detonator", "Restless Agility", 146310, stats.Agility, false, 1.0)
+
+	// Dysmorphic Samophlange of Discontinuity
+	// Your heals have a chance to grant you 41060 Spirit for 10s. Every 0.5 sec, this effect is reduced by 2053
+	// Spirit. (Approximately 0.92 procs per minute)
+	newDecayingStatTrinket(shared.ItemVersionMap{
+		shared.ItemVersionLFR:             105117,
+		shared.ItemVersionNormal:          102309,
+		shared.ItemVersionHeroic:          104619,
+		shared.ItemVersionWarforged:       105366,
+		shared.ItemVersionHeroicWarforged: 105615,
+		shared.ItemVersionFlexible:        104868,
+	}, "Dysmorphic Samophlange of Discontinuity", "Restless Spirit", 146317, stats.Spirit, true, 0.92000001669)
+
+	newRampingStatTrinket := func(itemVersionMap shared.ItemVersionMap, label string, auraLabel string, auraID int32, stackingAuraID int32, stat stats.Stat, procMask core.ProcMask, ppm float64) {
+		itemVersionMap.RegisterAll(func(version shared.ItemVersion, itemID int32, versionLabel string) {
+			core.NewItemEffect(itemID, func(agent core.Agent, state proto.ItemLevelState) {
+				character := agent.GetCharacter()
+				bonusPerStack := stats.Stats{}
+				bonusPerStack[stat] = core.GetItemEffectScaling(itemID, 0.44999998808, state)
+
+				statBuffAura, aura := character.NewTemporaryStatBuffWithStacks(core.TemporaryStatBuffWithStacksConfig{
+					AuraLabel:            fmt.Sprintf("%s %s", auraLabel, versionLabel),
+					ActionID:             core.ActionID{SpellID: auraID},
+					Duration:             time.Second * 10,
+					MaxStacks:            10,
+					TimePerStack:         time.Second * 1,
+					BonusPerStack:        bonusPerStack,
+					StackingAuraActionID: core.ActionID{SpellID: stackingAuraID},
+					StackingAuraLabel:    fmt.Sprintf("%s Stacks %s", auraLabel, versionLabel),
+					TickImmediately:      true,
+				})
+
+				triggerAura := core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
+					Name:    fmt.Sprintf("%s %s", label, versionLabel),
+					Harmful: true,
+					ICD:     time.Second * 10,
+					DPM: character.NewRPPMProcManager(itemID, false, procMask, core.RPPMConfig{
+						PPM: ppm,
+					}),
+					Outcome:  core.OutcomeLanded,
+					Callback: core.CallbackOnSpellHitDealt,
+					Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+						aura.Activate(sim)
+					},
+				})
+
+				eligibleSlots := character.ItemSwap.EligibleSlotsForItem(itemID)
+				character.AddStatProcBuff(itemID, statBuffAura, false, eligibleSlots)
+				character.ItemSwap.RegisterProcWithSlots(itemID, triggerAura, eligibleSlots)
+			})
+		})
+	}
+
+	// Black Blood of Y'Shaarj
+	// Your harmful spells have a chance to grant you Wrath of Unchained, granting 1780 Intellect every 1 sec for
+	// 10 sec. (Approximately 1.10 procs per minute)
+	newRampingStatTrinket(shared.ItemVersionMap{
+		shared.ItemVersionLFR:             105150,
+		shared.ItemVersionNormal:          102310,
+		shared.ItemVersionHeroic:          104652,
+		shared.ItemVersionWarforged:       105399,
+		shared.ItemVersionHeroicWarforged: 105648,
+		shared.ItemVersionFlexible:        104901,
+	}, "Black Blood of Y'Shaarj", "Wrath of Unchained", 146184, 146183, stats.Intellect, core.ProcMaskSpellOrSpellProc, 1.10000002384)
+
+	// Skeer's Bloodsoaked Talisman
+	// Your attacks have a chance to grant you Cruelty, granting 1780 Critical Strike every 1 sec for 10 sec.
+	// (Approximately 1.10 procs per minute)
+	newRampingStatTrinket(shared.ItemVersionMap{
+		shared.ItemVersionLFR:             105134,
+		shared.ItemVersionNormal:          102308,
+		shared.ItemVersionHeroic:          104636,
+		shared.ItemVersionWarforged:       105383,
+		shared.ItemVersionHeroicWarforged: 105632,
+		shared.ItemVersionFlexible:        104885,
+	}, "Skeer's Bloodsoaked Talisman", "Cruelty", 146285, 146286, stats.CritRating, core.ProcMaskMeleeOrMeleeProc|core.ProcMaskRangedOrRangedProc, 1.10000002384)
 }
@@ -0,0 +1,93 @@
+package mop
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+// Item level and random property points of the Normal Siege of Orgrimmar trinkets.
+const (
+	testTrinketIlvl           = 553
+	testTrinketRandPropPoints = 3956
+)
+
+func setupTrinketTestSim(itemID int32) (*core.Simulation, *itemEffectTestAgent) {
+	equipment := newTestEquipment()
+	equipment.Items[proto.ItemSlot_ItemSlotTrinket1] = &proto.ItemSpec{Id: itemID}
+
+	return setupItemEffectTestSim(equipment, &proto.SimDatabase{
+		Items: []*proto.SimItem{{
+			Id:   itemID,
+			Type: proto.ItemType_ItemTypeTrinket,
+			ScalingOptions: map[int32]*proto.ScalingItemProperties{
+				int32(proto.ItemLevelState_Base): {Ilvl: testTrinketIlvl},
+			},
+		}},
+		ItemEffectRandPropPoints: []*proto.ItemEffectRandPropPoints{
+			{Ilvl: testTrinketIlvl, RandPropPoints: testTrinketRandPropPoints},
+		},
+	})
+}
+
+func TestAmplificationScalesWithItemLevel(t *testing.T) {
+	// Purified Bindings of Immerseus (Normal)
+	_, agent := setupTrinketTestSim(102293)
+
+	expected := 1 + testTrinketRandPropPoints*amplificationCoeff/100
+	if multiplier := agent.PseudoStats.CritDamageMultiplier; !core.WithinToleranceFloat64(expected, multiplier, 0.0001) {
+		t.Fatalf("Expected a crit damage multiplier of %0.4f but found %0.4f", expected, multiplier)
+	}
+}
+
+func TestStatProcUsesItemEffectScaling(t *testing.T) {
+	// Purified Bindings of Immerseus (Normal)
+	sim, agent := setupTrinketTestSim(102293)
+
+	aura := agent.GetAuraByID(core.ActionID{SpellID: 146046})
+	intellectBefore := agent.GetStat(stats.Intellect)
+	aura.Activate(sim)
+	if gained := agent.GetStat(stats.Intellect) - intellectBefore; gained != 3829 {
+		t.Fatalf("Expected Expanded Mind to grant 3829 Intellect but found %0.0f", gained)
+	}
+}
+
+func TestMultistrikeProcChance(t *testing.T) {
+	// Kardris' Toxic Totem (Normal)
+	sim, agent := setupTrinketTestSim(102300)
+	target := sim.Encounter.AllTargetUnits[0]
+
+	const numCasts = 20000
+	for range numCasts {
+		agent.spell.Cast(sim, target)
+	}
+
+	multistrike := agent.GetSpell(core.ActionID{SpellID: 146061, Tag: 102300})
+	metrics := multistrike.SpellMetrics[target.UnitIndex]
+	expectedChance := testTrinketRandPropPoints * multistrikeCoeff / 100
+	if chance := float64(metrics.Casts) / numCasts; !core.WithinToleranceFloat64(expectedChance, chance, 0.01) {
+		t.Fatalf("Expected a multistrike chance of %0.4f but found %0.4f", expectedChance, chance)
+	}
+	if perHit := metrics.TotalDamage / float64(metrics.Casts); !core.WithinToleranceFloat64(330, perHit, 0.001) {
+		t.Fatalf("Expected each multistrike to copy 33%% of the damage but found %0.3f", perHit)
+	}
+}
+
+func TestDecayingStatTrinketStacks(t *testing.T) {
+	// Ticking Ebon Detonator (Normal)
+	sim, agent := setupTrinketTestSim(102311)
+
+	aura := agent.GetAuraByID(core.ActionID{SpellID: 146310})
+	agilityBefore := agent.GetStat(stats.Agility)
+	aura.Activate(sim)
+	aura.SetStacks(sim, aura.MaxStacks)
+	if gained := agent.GetStat(stats.Agility) - agilityBefore; gained != 20*2053 {
+		t.Fatalf("Expected Restless Agility to grant %d Agility at full stacks but found %0.0f", 20*2053, gained)
+	}
+	aura.RemoveStack(sim)
+	if gained := agent.GetStat(stats.Agility) - agilityBefore; gained != 19*2053 {
+		t.Fatalf("Expected one decay to leave %d Agility but found %0.0f", 19*2053, gained)
+	}
+}
@@ -27,10 +27,15 @@ func ComputeStats(csr *proto.ComputeStatsRequest) *proto.ComputeStatsResult {
  * Returns stat weights and EP values, with standard deviations, for all stats.
  */
 func StatWeights(request *proto.StatWeightsRequest) *proto.StatWeightsResult {
-	return runStatWeights(request, nil, simsignals.CreateSignals())
+	return runStatWeights(request, nil, simsignals.CreateSignals(), nil)
 }
 
 func StatWeightsAsync(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	StatWeightsAsyncWithRunner(request, progress, requestId, nil)
+}
+
+// Like StatWeightsAsync, but runs each of the stat weight sims with the given runner.
+func StatWeightsAsyncWithRunner(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, requestId string, runner RaidSimRunner) {
 	signals, err := simsignals.RegisterWithId(requestId)
 	if err != nil {
 		progress <- &proto.ProgressMetrics{
@@ -44,7 +49,7 @@ func StatWeightsAsync(request *proto.StatWeightsRequest, progress chan *proto.Pr
 	}
 	go func() {
 		defer simsignals.UnregisterId(requestId)
-		result := runStatWeights(request, progress, signals)
+		result := runStatWeights(request, progress, signals, runner)
 		progress <- &proto.ProgressMetrics{
 			FinalWeightResult: result,
 		}
@@ -60,6 +65,10 @@ func StatWeightCompute(request *proto.StatWeightsCalcRequest) *proto.StatWeights
 	return computeStatWeights(request)
 }
 
+// Runs a single raid sim like RunSim, e.g. on other machines. Progress is reported like RunSim,
+// closing the progress channel when done.
+type RaidSimRunner func(*proto.RaidSimRequest, chan *proto.ProgressMetrics, simsignals.Signals) *proto.RaidSimResult
+
 /**
  * Runs multiple iterations of the sim with a full raid.
  */
@@ -114,6 +123,12 @@ func RunBulkSim(request *proto.BulkSimRequest) *proto.BulkSimResult {
 }
 
 func RunBulkSimAsync(request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	RunBulkSimAsyncWithRunner(request, progress, requestId, nil, 0)
+}
+
+// Like RunBulkSimAsync, but runs each combo with the given runner, with up to concurrency combos at
+// once.
+func RunBulkSimAsyncWithRunner(request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics, requestId string, runner RaidSimRunner, concurrency int) {
 	signals, err := simsignals.RegisterWithId(requestId)
 	if err != nil {
 		progress <- &proto.ProgressMetrics{
@@ -127,7 +142,17 @@ func RunBulkSimAsync(request *proto.BulkSimRequest, progress chan *proto.Progres
 	}
 	go func() {
 		defer simsignals.UnregisterId(requestId)
-		BulkSim(signals, request, progress)
+		bulk := &bulkSimRunner{
+			SingleRaidSimRunner: runSim,
+			Request:             request,
+			Concurrency:         concurrency,
+		}
+		if runner != nil {
+			bulk.SingleRaidSimRunner = func(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, _ bool, signals simsignals.Signals) *proto.RaidSimResult {
+				return runner(rsr, progress, signals)
+			}
+		}
+		bulk.runWithProgress(signals, progress)
 	}()
 }
 
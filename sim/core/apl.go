@@ -13,6 +13,23 @@ type APLRotation struct {
 	prepullActions []*APLAction
 	priorityList   []*APLAction
 
+	variables         []*APLVariable
+	variablesByName   map[string]*APLVariable
+	actionLists       []*APLActionList
+	actionListsByName map[string]*APLActionList
+
+	// Incremented for every choice of the next action, variables are evaluated once per decision.
+	decisionIdx int
+
+	// List entries which chose the current action, from innermost to outermost.
+	decisionEntries []*APLAction
+
+	// Number of iterations in the execution profiles of the entries.
+	profileIterations int64
+
+	// True for the built-in rotation of units without an APL, which is left out of stats and results.
+	isCustomRotation bool
+
 	// Action currently controlling this rotation (only used for certain actions, such as StrictSequence).
 	controllingActions []APLActionImpl
 
@@ -74,8 +91,17 @@ func (rot *APLRotation) doAndRecordWarnings(warningsList *[]*proto.APLValidation
 	rot.parsingPrepull = false
 }
 
+// Like doAndRecordWarnings, but can be invoked while recording the warnings of another item, e.g. when
+// a variable is parsed on its first reference.
+func (rot *APLRotation) doAndRecordNestedWarnings(warningsList *[]*proto.APLValidation, fn func()) {
+	curValidations, parsingPrepull := rot.curValidations, rot.parsingPrepull
+	rot.curValidations = nil
+	rot.doAndRecordWarnings(warningsList, false, fn)
+	rot.curValidations, rot.parsingPrepull = curValidations, parsingPrepull
+}
+
 func (unit *Unit) newCustomRotation() *APLRotation {
-	return unit.newAPLRotation(&proto.APLRotation{
+	rotation := unit.newAPLRotation(&proto.APLRotation{
 		Type: proto.APLRotation_TypeAPL,
 		PriorityList: []*proto.APLListItem{
 			{
@@ -85,6 +111,8 @@ func (unit *Unit) newCustomRotation() *APLRotation {
 			},
 		},
 	})
+	rotation.isCustomRotation = true
+	return rotation
 }
 
 func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
@@ -97,6 +125,67 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 		prepullValidations:      make([][]*proto.APLValidation, len(config.PrepullActions)),
 		priorityListValidations: make([][]*proto.APLValidation, len(config.PriorityList)),
 		uuidValidations:         make(map[*proto.UUID][]*proto.APLValidation),
+		variablesByName:         make(map[string]*APLVariable),
+		actionListsByName:       make(map[string]*APLActionList),
+	}
+
+	// Variables and action lists are parsed on their first reference, so that they can be
+	// declared in any order.
+	for _, variableConfig := range config.Variables {
+		variable := &APLVariable{
+			rot:            rotation,
+			name:           variableConfig.Name,
+			config:         variableConfig.Value,
+			cachedDecision: -1,
+		}
+		rotation.variables = append(rotation.variables, variable)
+		rotation.doAndRecordWarnings(&variable.validations, false, func() {
+			if variable.name == "" {
+				rotation.ValidationMessage(proto.LogLevel_Warning, "Variable must have a name")
+			} else if _, ok := rotation.variablesByName[variable.name]; ok {
+				rotation.ValidationMessage(proto.LogLevel_Warning, "Duplicate variable name: '%s'", variable.name)
+			} else {
+				rotation.variablesByName[variable.name] = variable
+			}
+		})
+	}
+	for _, listConfig := range config.ActionLists {
+		list := &APLActionList{
+			rot:         rotation,
+			name:        listConfig.Name,
+			config:      listConfig,
+			validations: make([][]*proto.APLValidation, len(listConfig.PriorityList)),
+		}
+		rotation.actionLists = append(rotation.actionLists, list)
+
+		// Warnings about whole lists are shown on their first item.
+		var listWarnings *[]*proto.APLValidation
+		if len(list.validations) > 0 {
+			listWarnings = &list.validations[0]
+		}
+		rotation.doAndRecordWarnings(listWarnings, false, func() {
+			if list.name == "" {
+				rotation.ValidationMessage(proto.LogLevel_Warning, "Action list must have a name")
+			} else if _, ok := rotation.actionListsByName[list.name]; ok {
+				rotation.ValidationMessage(proto.LogLevel_Warning, "Duplicate action list name: '%s'", list.name)
+			} else {
+				rotation.actionListsByName[list.name] = list
+			}
+		})
+	}
+	for _, variable := range rotation.variables {
+		if rotation.variablesByName[variable.name] == variable {
+			rotation.doAndRecordWarnings(&variable.validations, false, func() {
+				rotation.getVariable(variable.name)
+			})
+		}
+	}
+	for _, list := range rotation.actionLists {
+		if rotation.actionListsByName[list.name] == list {
+			rotation.doAndRecordWarnings(nil, false, func() {
+				rotation.getActionList(list.name)
+			})
+		}
 	}
 
 	// Parse prepull actions
@@ -118,6 +207,8 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 								// Warnings for prepull cast failure are detected by running a fake prepull,
 								// so this action.Execute needs to record warnings.
 								rotation.doAndRecordWarnings(&rotation.prepullValidations[prepullIdx], true, func() {
+									action.profile.recordCondition(true)
+									action.profile.recordExecution(sim)
 									action.Execute(sim)
 								})
 							})
@@ -142,6 +233,22 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 	}
 
 	// Finalize
+	for _, variable := range rotation.variables {
+		if variable.value != nil {
+			rotation.doAndRecordWarnings(&variable.validations, false, func() {
+				for _, value := range flattenAPLValues([]APLValue{variable.value}) {
+					value.Finalize(rotation)
+				}
+			})
+		}
+	}
+	for _, list := range rotation.actionLists {
+		for i, action := range list.actions {
+			rotation.doAndRecordWarnings(&list.validations[list.idxMap[i]], false, func() {
+				action.Finalize(rotation)
+			})
+		}
+	}
 	for i, action := range rotation.prepullActions {
 		rotation.doAndRecordWarnings(&rotation.prepullValidations[rotation.prepullIdxMap[i]], true, func() {
 			action.Finalize(rotation)
@@ -219,6 +326,13 @@ func (rot *APLRotation) getStats() *proto.APLStats {
 			action.impl.PostFinalize(rot)
 		})
 	}
+	for _, list := range rot.actionLists {
+		for i, action := range list.actions {
+			rot.doAndRecordWarnings(&list.validations[list.idxMap[i]], false, func() {
+				action.impl.PostFinalize(rot)
+			})
+		}
+	}
 
 	uuidValidationsArr := make([]*proto.UUIDValidations, len(rot.uuidValidations))
 	i := 0
@@ -238,15 +352,33 @@ func (rot *APLRotation) getStats() *proto.APLStats {
 			return &proto.APLActionStats{Validations: validations}
 		}),
 		UuidValidations: uuidValidationsArr,
+		Variables: MapSlice(rot.variables, func(variable *APLVariable) *proto.APLActionStats {
+			return &proto.APLActionStats{Validations: variable.validations}
+		}),
+		ActionLists: MapSlice(rot.actionLists, func(list *APLActionList) *proto.APLActionListStats {
+			return &proto.APLActionListStats{
+				PriorityList: MapSlice(list.validations, func(validations []*proto.APLValidation) *proto.APLActionStats {
+					return &proto.APLActionStats{Validations: validations}
+				}),
+			}
+		}),
 	}
 }
 
 func (rot *APLRotation) allAPLActions() []*APLAction {
-	if rot == nil || rot.priorityList == nil {
+	if rot == nil {
+		return []*APLAction{}
+	}
+
+	actions := rot.priorityList
+	for _, list := range rot.actionLists {
+		actions = append(actions[:len(actions):len(actions)], list.actions...)
+	}
+	if actions == nil {
 		return []*APLAction{}
 	}
 
-	return Flatten(MapSlice(rot.priorityList, func(action *APLAction) []*APLAction {
+	return Flatten(MapSlice(actions, func(action *APLAction) []*APLAction {
 		// Check if action is nil before calling GetAllActions
 		if action == nil {
 			return []*APLAction{}
@@ -265,6 +397,15 @@ func (rot *APLRotation) reset(sim *Simulation) {
 	rot.inLoop = false
 	rot.interruptChannelIf = nil
 	rot.allowChannelRecastOnInterrupt = false
+	for _, variable := range rot.variables {
+		variable.reset()
+	}
+	for _, list := range rot.actionLists {
+		list.inLoop = false
+	}
+	for _, action := range rot.profiledActions() {
+		action.profile.reset()
+	}
 	for _, action := range rot.allAPLActions() {
 		action.impl.Reset(sim)
 	}
@@ -299,6 +440,10 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 			panic(fmt.Sprintf("[USER_ERROR] Infinite loop detected, current action:\n%s", nextAction))
 		}
 
+		for _, entry := range apl.decisionEntries {
+			entry.profile.recordExecution(sim)
+		}
+
 		nextAction.Execute(sim)
 	}
 	apl.inLoop = false
@@ -320,17 +465,45 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 }
 
 func (apl *APLRotation) getNextAction(sim *Simulation) *APLAction {
+	apl.decisionIdx++
+	apl.decisionEntries = apl.decisionEntries[:0]
+
 	if len(apl.controllingActions) != 0 {
 		return apl.controllingActions[len(apl.controllingActions)-1].GetNextAction(sim)
 	}
 
-	for _, action := range apl.priorityList {
-		if action.IsReady(sim) {
-			return action
+	nextAction, _ := apl.getNextListAction(sim, apl.priorityList)
+	return nextAction
+}
+
+// Returns the first ready action of the list, descending into the lists of Call List and Run List
+// actions. The bool is true if a Run List ended the search, even if it found no ready action.
+func (apl *APLRotation) getNextListAction(sim *Simulation, actions []*APLAction) (*APLAction, bool) {
+	for _, action := range actions {
+		if !action.evaluateCondition(sim) {
+			continue
 		}
-	}
 
-	return nil
+		if invocation, ok := action.impl.(aplActionListInvocation); ok {
+			nextAction, done := invocation.getNextListAction(sim)
+			if nextAction != nil {
+				apl.decisionEntries = append(apl.decisionEntries, action)
+			}
+			if nextAction != nil || done {
+				return nextAction, done
+			}
+		} else {
+			// Actions checked by IsReady, e.g. lists within sequences, are not part of the decision.
+			numEntries := len(apl.decisionEntries)
+			isReady := action.impl.IsReady(sim)
+			apl.decisionEntries = apl.decisionEntries[:numEntries]
+			if isReady {
+				apl.decisionEntries = append(apl.decisionEntries, action)
+				return action, false
+			}
+		}
+	}
+	return nil, false
 }
 
 func (apl *APLRotation) pushControllingAction(ca APLActionImpl) {
@@ -9,6 +9,9 @@ import (
 type APLAction struct {
 	condition APLValue
 	impl      APLActionImpl
+
+	// Only recorded for prepull actions and list entries.
+	profile aplActionProfile
 }
 
 func (action *APLAction) Finalize(rot *APLRotation) {
@@ -22,6 +25,13 @@ func (action *APLAction) IsReady(sim *Simulation) bool {
 	return (action.condition == nil || action.condition.GetBool(sim)) && action.impl.IsReady(sim)
 }
 
+// Evaluates the condition of a list entry, recording the result in its profile.
+func (action *APLAction) evaluateCondition(sim *Simulation) bool {
+	result := action.condition == nil || action.condition.GetBool(sim)
+	action.profile.recordCondition(result)
+	return result
+}
+
 func (action *APLAction) Execute(sim *Simulation) {
 	action.impl.Execute(sim)
 }
@@ -41,15 +51,23 @@ func (action *APLAction) GetAllAPLValues() []APLValue {
 		if a.condition != nil {
 			unprocessed = append(unprocessed, a.condition)
 		}
+		values = append(values, flattenAPLValues(unprocessed)...)
+	}
+	return FilterSlice(values, func(val APLValue) bool { return val != nil })
+}
 
-		for len(unprocessed) > 0 {
-			next := unprocessed[len(unprocessed)-1]
-			unprocessed = unprocessed[:len(unprocessed)-1]
-			values = append(values, next)
+// Returns the values along with all of their inner values.
+func flattenAPLValues(unprocessed []APLValue) []APLValue {
+	var values []APLValue
+	for len(unprocessed) > 0 {
+		next := unprocessed[len(unprocessed)-1]
+		unprocessed = unprocessed[:len(unprocessed)-1]
+		values = append(values, next)
+		if next != nil {
 			unprocessed = append(unprocessed, next.GetInnerValues()...)
 		}
 	}
-	return FilterSlice(values, func(val APLValue) bool { return val != nil })
+	return values
 }
 
 func (action *APLAction) GetAllSpells() []*Spell {
@@ -176,6 +194,12 @@ func (rot *APLRotation) newAPLActionImpl(config *proto.APLAction) APLActionImpl
 	case *proto.APLAction_StrictSequence:
 		return rot.newActionStrictSequence(config.GetStrictSequence())
 
+	// Action lists
+	case *proto.APLAction_CallList:
+		return rot.newActionCallList(config.GetCallList())
+	case *proto.APLAction_RunList:
+		return rot.newActionRunList(config.GetRunList())
+
 	// Misc
 	case *proto.APLAction_ChangeTarget:
 		return rot.newActionChangeTarget(config.GetChangeTarget())
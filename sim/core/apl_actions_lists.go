@@ -0,0 +1,153 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Named sub-list of a rotation, invoked with the Call List and Run List actions.
+type APLActionList struct {
+	rot     *APLRotation
+	name    string
+	config  *proto.APLActionList
+	actions []*APLAction
+
+	validations [][]*proto.APLValidation
+	// Maps indices in actions to indices in config.
+	idxMap []int
+
+	// Used to detect lists which call themselves while parsing.
+	parsing bool
+	parsed  bool
+
+	// Used to avoid recursive list evaluations.
+	inLoop bool
+}
+
+// Returns the action list with the given name, parsing its actions first if needed.
+func (rot *APLRotation) getActionList(name string) *APLActionList {
+	list, ok := rot.actionListsByName[name]
+	if !ok {
+		rot.ValidationMessage(proto.LogLevel_Warning, "No action list with name: '%s'", name)
+		return nil
+	}
+
+	if list.parsing {
+		rot.ValidationMessage(proto.LogLevel_Warning, "Action list '%s' calls itself", name)
+		return nil
+	}
+	if !list.parsed {
+		list.parsing = true
+		for i, aplItem := range list.config.PriorityList {
+			rot.doAndRecordNestedWarnings(&list.validations[i], func() {
+				if !aplItem.Hide {
+					action := rot.newAPLAction(aplItem.Action)
+					if action != nil {
+						list.actions = append(list.actions, action)
+						list.idxMap = append(list.idxMap, i)
+					}
+				}
+			})
+		}
+		list.parsing = false
+		list.parsed = true
+	}
+	return list
+}
+
+// Returns the first ready action of the list, see APLRotation.getNextListAction.
+func (list *APLActionList) getNextAction(sim *Simulation) (*APLAction, bool) {
+	if list.inLoop {
+		return nil, false
+	}
+
+	list.inLoop = true
+	nextAction, done := list.rot.getNextListAction(sim, list.actions)
+	list.inLoop = false
+	return nextAction, done
+}
+
+// Actions which are replaced by an action of another list when choosing the next action.
+type aplActionListInvocation interface {
+	getNextListAction(sim *Simulation) (*APLAction, bool)
+}
+
+// Invocation of a list by an action, which remembers the action of the list chosen in IsReady so
+// that Execute runs it without evaluating the list again.
+type aplListInvocation struct {
+	list *APLActionList
+
+	nextAction   *APLAction
+	nextActionAt time.Duration
+}
+
+func (invocation *aplListInvocation) reset() {
+	invocation.nextAction = nil
+}
+func (invocation *aplListInvocation) IsReady(sim *Simulation) bool {
+	invocation.nextAction, _ = invocation.list.getNextAction(sim)
+	invocation.nextActionAt = sim.CurrentTime
+	return invocation.nextAction != nil
+}
+func (invocation *aplListInvocation) Execute(sim *Simulation) {
+	nextAction := invocation.nextAction
+	if nextAction == nil || invocation.nextActionAt != sim.CurrentTime {
+		nextAction, _ = invocation.list.getNextAction(sim)
+	}
+	invocation.nextAction = nil
+	if nextAction != nil {
+		nextAction.Execute(sim)
+	}
+}
+
+type APLActionCallList struct {
+	defaultAPLActionImpl
+	aplListInvocation
+}
+
+func (rot *APLRotation) newActionCallList(config *proto.APLActionCallList) APLActionImpl {
+	list := rot.getActionList(config.Name)
+	if list == nil {
+		return nil
+	}
+	return &APLActionCallList{
+		aplListInvocation: aplListInvocation{list: list},
+	}
+}
+func (action *APLActionCallList) getNextListAction(sim *Simulation) (*APLAction, bool) {
+	return action.list.getNextAction(sim)
+}
+func (action *APLActionCallList) Reset(*Simulation) {
+	action.reset()
+}
+func (action *APLActionCallList) String() string {
+	return fmt.Sprintf("Call List(%s)", action.list.name)
+}
+
+// Outside of priority lists, e.g. within sequences, Run List behaves like Call List.
+type APLActionRunList struct {
+	defaultAPLActionImpl
+	aplListInvocation
+}
+
+func (rot *APLRotation) newActionRunList(config *proto.APLActionRunList) APLActionImpl {
+	list := rot.getActionList(config.Name)
+	if list == nil {
+		return nil
+	}
+	return &APLActionRunList{
+		aplListInvocation: aplListInvocation{list: list},
+	}
+}
+func (action *APLActionRunList) getNextListAction(sim *Simulation) (*APLAction, bool) {
+	nextAction, _ := action.list.getNextAction(sim)
+	return nextAction, true
+}
+func (action *APLActionRunList) Reset(*Simulation) {
+	action.reset()
+}
+func (action *APLActionRunList) String() string {
+	return fmt.Sprintf("Run List(%s)", action.list.name)
+}
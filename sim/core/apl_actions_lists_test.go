@@ -0,0 +1,137 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func aplListsTestRotation(t *testing.T, text string) (*Simulation, *APLRotation) {
+	config, err := APLRotationFromText(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsr := raidDamageTestRequest()
+	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
+	sim := newSimWithEnv(env, rsr.SimOptions, simsignals.CreateSignals())
+	dummy := env.Raid.AllPlayerUnits[0]
+	dummy.Rotation = dummy.newAPLRotation(config)
+	rot := dummy.Rotation
+	sim.reset()
+	return sim, rot
+}
+
+func aplListsTestValidations(stats *proto.APLStats) []string {
+	var messages []string
+	addAll := func(validations []*proto.APLValidation) {
+		for _, validation := range validations {
+			messages = append(messages, validation.Validation)
+		}
+	}
+	for _, item := range stats.PriorityList {
+		addAll(item.Validations)
+	}
+	for _, variable := range stats.Variables {
+		addAll(variable.Validations)
+	}
+	for _, list := range stats.ActionLists {
+		for _, item := range list.PriorityList {
+			addAll(item.Validations)
+		}
+	}
+	return messages
+}
+
+func TestAPLCallListFallsThrough(t *testing.T) {
+	sim, rot := aplListsTestRotation(t, `
+call_list("empty")
+call_list("waits") if false
+call_list("waits")
+wait(1s)
+
+list empty: wait(0s)
+list waits: wait(2s) if false
+list waits: wait(3s)
+`)
+
+	if action := rot.getNextAction(sim); action == nil || action.impl.String() != "Wait(3s)" {
+		t.Fatalf("Expected Wait(3s) from the called list, got %v", action)
+	}
+}
+
+func TestAPLRunListStops(t *testing.T) {
+	sim, rot := aplListsTestRotation(t, `
+run_list("empty")
+wait(1s)
+
+list empty: wait(0s)
+`)
+
+	if action := rot.getNextAction(sim); action != nil {
+		t.Fatalf("Expected no action after running an empty list, got %v", action)
+	}
+}
+
+func TestAPLCallListExecutesActionChosenInIsReady(t *testing.T) {
+	sim, rot := aplListsTestRotation(t, `
+call_list("waits")
+run_list("waits")
+
+list waits: wait(1s)
+`)
+
+	listAction := rot.actionListsByName["waits"].actions[0]
+	for _, action := range rot.priorityList {
+		listAction.profile.reset()
+		if !action.impl.IsReady(sim) {
+			t.Fatalf("Expected %s to be ready", action.impl)
+		}
+		action.impl.Execute(sim)
+		if evaluations := listAction.profile.current.conditionEvaluations; evaluations != 1 {
+			t.Fatalf("Expected %s to evaluate its list once, got %d evaluations", action.impl, evaluations)
+		}
+	}
+}
+
+func TestAPLVariables(t *testing.T) {
+	sim, rot := aplListsTestRotation(t, `
+variable long = $short * 3
+variable short = 2s
+wait($long) if $long > $short
+`)
+
+	action := rot.getNextAction(sim)
+	if action == nil {
+		t.Fatalf("Expected a wait action")
+	}
+	if duration := action.impl.(*APLActionWait).duration.GetDuration(sim); duration != time.Second*6 {
+		t.Fatalf("Expected a wait of 6s, got %s", duration)
+	}
+}
+
+func TestAPLListAndVariableCycles(t *testing.T) {
+	_, rot := aplListsTestRotation(t, `
+variable a = $b
+variable b = $a + 1
+wait(1s) if $missing
+call_list("first")
+
+list first: call_list("second")
+list second: call_list("first")
+`)
+
+	messages := strings.Join(aplListsTestValidations(rot.getStats()), "\n")
+	for _, expected := range []string{
+		"Variable 'a' depends on itself",
+		"No variable with name: 'missing'",
+		"Action list 'first' calls itself",
+	} {
+		if !strings.Contains(messages, expected) {
+			t.Fatalf("Expected a validation warning %q, got:\n%s", expected, messages)
+		}
+	}
+}
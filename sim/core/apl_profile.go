@@ -0,0 +1,135 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type aplProfileCounts struct {
+	conditionEvaluations int64
+	conditionTrue        int64
+	executions           int64
+
+	// Time between consecutive executions within an iteration.
+	executionIntervals   int64
+	executionIntervalSum time.Duration
+}
+
+func (counts *aplProfileCounts) add(other *aplProfileCounts) {
+	counts.conditionEvaluations += other.conditionEvaluations
+	counts.conditionTrue += other.conditionTrue
+	counts.executions += other.executions
+	counts.executionIntervals += other.executionIntervals
+	counts.executionIntervalSum += other.executionIntervalSum
+}
+
+// Records how often a prepull or list entry is considered and executed, for finding entries which never fire.
+type aplActionProfile struct {
+	// Counts for the current iteration.
+	current         aplProfileCounts
+	lastExecutionAt time.Duration
+
+	// Aggregate values. These are updated after each iteration.
+	total aplProfileCounts
+}
+
+func (profile *aplActionProfile) reset() {
+	profile.current = aplProfileCounts{}
+}
+
+func (profile *aplActionProfile) recordCondition(result bool) {
+	profile.current.conditionEvaluations++
+	if result {
+		profile.current.conditionTrue++
+	}
+}
+
+func (profile *aplActionProfile) recordExecution(sim *Simulation) {
+	if profile.current.executions > 0 {
+		profile.current.executionIntervals++
+		profile.current.executionIntervalSum += sim.CurrentTime - profile.lastExecutionAt
+	}
+	profile.current.executions++
+	profile.lastExecutionAt = sim.CurrentTime
+}
+
+func (profile *aplActionProfile) doneIteration() {
+	profile.total.add(&profile.current)
+}
+
+func (profile *aplActionProfile) fillStats(stats *proto.APLActionStats, iterations int64) {
+	stats.Profiled = true
+	stats.ConditionEvaluations = profile.total.conditionEvaluations
+	stats.ConditionTrue = profile.total.conditionTrue
+	stats.Executions = profile.total.executions
+	stats.ExecutionIntervals = profile.total.executionIntervals
+	stats.ExecutionIntervalSeconds = profile.total.executionIntervalSum.Seconds()
+	finalizeAPLActionStats(stats, iterations)
+}
+
+// Computes the averages of a profiled entry and warns about entries which never executed.
+func finalizeAPLActionStats(stats *proto.APLActionStats, iterations int64) {
+	if !stats.Profiled {
+		return
+	}
+
+	if stats.ExecutionIntervals > 0 {
+		stats.AvgSecondsBetweenExecutions = stats.ExecutionIntervalSeconds / float64(stats.ExecutionIntervals)
+	}
+	if stats.Executions == 0 {
+		stats.Validations = append(stats.Validations, &proto.APLValidation{
+			LogLevel:   proto.LogLevel_Warning,
+			Validation: fmt.Sprintf("Never executed in %d iterations", iterations),
+		})
+	}
+}
+
+// Returns the stats of all prepull actions and list entries, in a fixed order for the same rotation.
+func allAPLActionStats(stats *proto.APLStats) []*proto.APLActionStats {
+	all := append(stats.PrepullActions[:len(stats.PrepullActions):len(stats.PrepullActions)], stats.PriorityList...)
+	for _, list := range stats.ActionLists {
+		all = append(all, list.PriorityList...)
+	}
+	return all
+}
+
+func (rot *APLRotation) doneIteration() {
+	rot.profileIterations++
+	for _, action := range rot.profiledActions() {
+		action.profile.doneIteration()
+	}
+}
+
+func (rot *APLRotation) profiledActions() []*APLAction {
+	actions := append(rot.prepullActions[:len(rot.prepullActions):len(rot.prepullActions)], rot.priorityList...)
+	for _, list := range rot.actionLists {
+		actions = append(actions, list.actions...)
+	}
+	return actions
+}
+
+// Returns the execution profile of all entries, for simulation results.
+func (rot *APLRotation) getProfileStats() *proto.APLStats {
+	newStats := func(numItems int, actions []*APLAction, idxMap []int) []*proto.APLActionStats {
+		stats := make([]*proto.APLActionStats, numItems)
+		for i := range stats {
+			stats[i] = &proto.APLActionStats{}
+		}
+		for i, action := range actions {
+			action.profile.fillStats(stats[idxMap[i]], rot.profileIterations)
+		}
+		return stats
+	}
+
+	return &proto.APLStats{
+		PrepullActions: newStats(len(rot.prepullValidations), rot.prepullActions, rot.prepullIdxMap),
+		PriorityList:   newStats(len(rot.priorityListValidations), rot.priorityList, rot.priorityListIdxMap),
+		ActionLists: MapSlice(rot.actionLists, func(list *APLActionList) *proto.APLActionListStats {
+			return &proto.APLActionListStats{
+				PriorityList: newStats(len(list.validations), list.actions, list.idxMap),
+			}
+		}),
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestAPLProfile(t *testing.T) {
+	sim, rot := aplListsTestRotation(t, `
+call_list("waits")
+wait(1s) if false
+wait(2s)
+
+list waits: wait(0s)
+`)
+
+	for i := 0; i < 2; i++ {
+		sim.reset()
+		rot.DoNextAction(sim)
+		rot.doneIteration()
+	}
+
+	stats := rot.getProfileStats()
+	for i, expected := range []*proto.APLActionStats{
+		{ConditionEvaluations: 2, ConditionTrue: 2},
+		{ConditionEvaluations: 2},
+		{ConditionEvaluations: 2, ConditionTrue: 2, Executions: 2},
+	} {
+		actual := stats.PriorityList[i]
+		if actual.ConditionEvaluations != expected.ConditionEvaluations || actual.ConditionTrue != expected.ConditionTrue || actual.Executions != expected.Executions {
+			t.Fatalf("Entry %d: expected %v, got %v", i, expected, actual)
+		}
+		if dead := len(actual.Validations) > 0; dead != (expected.Executions == 0) {
+			t.Fatalf("Entry %d: unexpected dead entry warnings %v", i, actual.Validations)
+		}
+	}
+	if listItem := stats.ActionLists[0].PriorityList[0]; listItem.ConditionEvaluations != 2 || listItem.Executions != 0 {
+		t.Fatalf("Unexpected action list item stats %v", listItem)
+	}
+
+	// Combining the results of concurrent sims keeps the totals and rechecks for dead entries.
+	rsrc := &raidSimResultCombiner{}
+	combined := rsrc.newAPLStats(stats)
+	rsrc.combineAPLStats(combined, stats, false, 4)
+	rsrc.combineAPLStats(combined, stats, true, 4)
+	if executions := combined.PriorityList[2].Executions; executions != 4 {
+		t.Fatalf("Expected 4 combined executions, got %d", executions)
+	}
+	if validations := combined.PriorityList[1].Validations; len(validations) != 1 || validations[0].Validation != "Never executed in 4 iterations" {
+		t.Fatalf("Expected a single dead entry warning, got %v", validations)
+	}
+}
@@ -0,0 +1,403 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Human-readable text format for APL rotations, loosely based on SimC action lists:
+//
+//	variable execute = is_execute_phase(E20) && current_rage > 30
+//	prepull -1.5s: cast_spell(other:Potion)
+//	## Notes of the next item.
+//	cast_spell(spell:12294) if aura_remaining_time(spell:60503) < 2s && current_rage > 30
+//	hide cast_spell(spell:1464)
+//	call_list("aoe") if number_targets > 2
+//	list aoe: cast_spell(spell:1680) if $execute
+//
+// Actions and values are written as calls named after their proto field, e.g. cast_spell for
+// APLAction.cast_spell. Calls without arguments can leave out the parentheses. Arguments are
+// passed by name, e.g. source_unit=pet, except the action ID of spell and aura values and the only
+// field of single-field messages, which are positional. Operators, constants and the and/or/not
+// values use infix syntax instead, and variables are referenced as $name. Value UUIDs are not part of
+// the text format.
+
+// Matches constants which are written without quotes, the sign is handled by the unary minus.
+var aplTextNumberRegex = regexp.MustCompile(`^(\d+\.?\d*|\.\d+)(ns|us|ms|s|m|h|%)?$`)
+
+const (
+	aplTextPrecOr = iota + 1
+	aplTextPrecAnd
+	aplTextPrecCmp
+	aplTextPrecAdd
+	aplTextPrecMul
+	aplTextPrecUnary
+	aplTextPrecPrimary
+)
+
+var aplTextCmpOps = map[proto.APLValueCompare_ComparisonOperator]string{
+	proto.APLValueCompare_OpEq: "==",
+	proto.APLValueCompare_OpNe: "!=",
+	proto.APLValueCompare_OpLt: "<",
+	proto.APLValueCompare_OpLe: "<=",
+	proto.APLValueCompare_OpGt: ">",
+	proto.APLValueCompare_OpGe: ">=",
+}
+
+var aplTextMathOps = map[proto.APLValueMath_MathOperator]string{
+	proto.APLValueMath_OpAdd: "+",
+	proto.APLValueMath_OpSub: "-",
+	proto.APLValueMath_OpMul: "*",
+	proto.APLValueMath_OpDiv: "/",
+}
+
+// Prefix of OtherAction names which can be left out in action IDs, e.g. other:Potion.
+const aplTextOtherActionPrefix = "OtherAction"
+
+// Converts a rotation to the APL text format.
+func APLRotationToText(rot *proto.APLRotation) string {
+	// Sections are separated by empty lines.
+	var sections []string
+	var sb strings.Builder
+	endSection := func() {
+		if sb.Len() > 0 {
+			sections = append(sections, sb.String())
+			sb.Reset()
+		}
+	}
+
+	if rot.Type != proto.APLRotation_TypeAPL {
+		fmt.Fprintf(&sb, "type %s\n", rot.Type)
+	}
+	if rot.Simple != nil {
+		fmt.Fprintf(&sb, "simple %s\n", aplTextCompactJson(rot.Simple))
+	}
+	endSection()
+
+	for _, variable := range rot.Variables {
+		sb.WriteString("variable " + aplTextName(variable.Name))
+		if variable.Value != nil {
+			sb.WriteString(" = " + aplTextValue(variable.Value))
+		}
+		sb.WriteString("\n")
+	}
+	endSection()
+
+	for _, prepullAction := range rot.PrepullActions {
+		if prepullAction.Hide {
+			sb.WriteString("hide ")
+		}
+		sb.WriteString("prepull")
+		if prepullAction.DoAtValue != nil {
+			sb.WriteString(" ")
+			sb.WriteString(aplTextValue(prepullAction.DoAtValue))
+		}
+		sb.WriteString(": ")
+		sb.WriteString(aplTextAction(prepullAction.Action))
+		sb.WriteString("\n")
+	}
+	endSection()
+
+	aplTextListItems(&sb, rot.PriorityList, "")
+	endSection()
+
+	for _, list := range rot.ActionLists {
+		if len(list.PriorityList) == 0 {
+			sb.WriteString("list " + aplTextName(list.Name) + "\n")
+		}
+		aplTextListItems(&sb, list.PriorityList, "list "+aplTextName(list.Name)+": ")
+		endSection()
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+func aplTextListItems(sb *strings.Builder, items []*proto.APLListItem, prefix string) {
+	for _, item := range items {
+		if item.Notes != "" {
+			for _, line := range strings.Split(item.Notes, "\n") {
+				if line == "" {
+					sb.WriteString("##\n")
+				} else {
+					sb.WriteString("## " + line + "\n")
+				}
+			}
+		}
+		if item.Hide {
+			sb.WriteString("hide ")
+		}
+		sb.WriteString(prefix)
+		sb.WriteString(aplTextAction(item.Action))
+		sb.WriteString("\n")
+	}
+}
+
+var aplTextIdentRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Returns the name of a variable or action list, in quotes unless it is a valid identifier.
+func aplTextName(name string) string {
+	if aplTextIdentRegex.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func aplTextCompactJson(msg protoreflect.ProtoMessage) string {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	// protojson randomizes its whitespace, compact it for a stable output.
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
+
+func aplTextAction(action *proto.APLAction) string {
+	if action == nil {
+		return "none"
+	}
+
+	var text string
+	if field := action.ProtoReflect().WhichOneof(aplActionOneof()); field != nil {
+		text = aplTextCall(field.Name(), action.ProtoReflect().Get(field).Message())
+	} else {
+		text = "none"
+	}
+	if action.Condition != nil {
+		text += " if " + aplTextValue(action.Condition)
+	}
+	return text
+}
+
+func aplTextValue(value *proto.APLValue) string {
+	text, _ := aplTextValueWithPrec(value)
+	return text
+}
+
+// Returns the text of the value and the precedence of its outermost operator.
+func aplTextValueWithPrec(value *proto.APLValue) (string, int) {
+	switch v := value.GetValue().(type) {
+	case nil:
+		return "none", aplTextPrecPrimary
+	case *proto.APLValue_Const:
+		val := v.Const.Val
+		if val == "true" || val == "false" || aplTextNumberRegex.MatchString(strings.TrimPrefix(val, "-")) {
+			return val, aplTextPrecPrimary
+		}
+		return strconv.Quote(val), aplTextPrecPrimary
+	case *proto.APLValue_And:
+		if len(v.And.Vals) >= 2 {
+			return aplTextInfixList(v.And.Vals, " && ", aplTextPrecAnd), aplTextPrecAnd
+		}
+	case *proto.APLValue_Or:
+		if len(v.Or.Vals) >= 2 {
+			return aplTextInfixList(v.Or.Vals, " || ", aplTextPrecOr), aplTextPrecOr
+		}
+	case *proto.APLValue_Not:
+		if v.Not.Val != nil {
+			return "!" + aplTextOperand(v.Not.Val, aplTextPrecUnary), aplTextPrecUnary
+		}
+	case *proto.APLValue_Cmp:
+		if op, ok := aplTextCmpOps[v.Cmp.Op]; ok && v.Cmp.Lhs != nil && v.Cmp.Rhs != nil {
+			// Comparisons are not associative, so nested ones always need parentheses.
+			return aplTextOperand(v.Cmp.Lhs, aplTextPrecCmp+1) + " " + op + " " + aplTextOperand(v.Cmp.Rhs, aplTextPrecCmp+1), aplTextPrecCmp
+		}
+	case *proto.APLValue_VariableRef:
+		return "$" + aplTextName(v.VariableRef.Name), aplTextPrecPrimary
+	case *proto.APLValue_Math:
+		if op, ok := aplTextMathOps[v.Math.Op]; ok && v.Math.Lhs != nil && v.Math.Rhs != nil {
+			prec := aplTextPrecAdd
+			if v.Math.Op == proto.APLValueMath_OpMul || v.Math.Op == proto.APLValueMath_OpDiv {
+				prec = aplTextPrecMul
+			}
+			// Operators are left-associative, so the right operand needs parentheses at the same precedence.
+			return aplTextOperand(v.Math.Lhs, prec) + " " + op + " " + aplTextOperand(v.Math.Rhs, prec+1), prec
+		}
+	}
+
+	// Everything else, including operators with missing operands, uses the call syntax.
+	field := value.ProtoReflect().WhichOneof(aplValueOneof())
+	return aplTextCall(field.Name(), value.ProtoReflect().Get(field).Message()), aplTextPrecPrimary
+}
+
+// Returns the text of an operand, in parentheses if it binds weaker than minPrec.
+func aplTextOperand(value *proto.APLValue, minPrec int) string {
+	text, prec := aplTextValueWithPrec(value)
+	if prec < minPrec {
+		return "(" + text + ")"
+	}
+	return text
+}
+
+func aplTextInfixList(vals []*proto.APLValue, op string, prec int) string {
+	texts := make([]string, len(vals))
+	for i, val := range vals {
+		// Nested lists of the same operator need parentheses to not be merged when parsing.
+		texts[i] = aplTextOperand(val, prec+1)
+	}
+	return strings.Join(texts, op)
+}
+
+// Returns the field of a message which is passed without its name, or nil if none.
+func aplTextPositionalField(desc protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	fields := desc.Fields()
+	var actionIdField protoreflect.FieldDescriptor
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Kind() == protoreflect.MessageKind && field.Message().FullName() == (&proto.ActionID{}).ProtoReflect().Descriptor().FullName() {
+			if actionIdField != nil {
+				return nil
+			}
+			actionIdField = field
+		}
+	}
+	if actionIdField != nil {
+		return actionIdField
+	}
+	if fields.Len() == 1 {
+		return fields.Get(0)
+	}
+	return nil
+}
+
+func aplTextCall(name protoreflect.Name, msg protoreflect.Message) string {
+	args := aplTextArgs(msg)
+	if len(args) == 0 {
+		return string(name)
+	}
+	return string(name) + "(" + strings.Join(args, ", ") + ")"
+}
+
+func aplTextArgs(msg protoreflect.Message) []string {
+	var args []string
+	positional := aplTextPositionalField(msg.Descriptor())
+	if positional != nil && msg.Has(positional) {
+		if positional.IsList() {
+			list := msg.Get(positional).List()
+			for i := 0; i < list.Len(); i++ {
+				args = append(args, aplTextFieldValue(positional, list.Get(i)))
+			}
+		} else {
+			args = append(args, aplTextFieldValue(positional, msg.Get(positional)))
+		}
+	}
+
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field == positional || !msg.Has(field) {
+			continue
+		}
+		if field.IsList() {
+			list := msg.Get(field).List()
+			items := make([]string, list.Len())
+			for j := range items {
+				items[j] = aplTextFieldValue(field, list.Get(j))
+			}
+			args = append(args, string(field.Name())+"=["+strings.Join(items, ", ")+"]")
+		} else {
+			args = append(args, string(field.Name())+"="+aplTextFieldValue(field, msg.Get(field)))
+		}
+	}
+	return args
+}
+
+func aplTextFieldValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch field.Kind() {
+	case protoreflect.MessageKind:
+		switch msg := value.Message().Interface().(type) {
+		case *proto.APLValue:
+			return aplTextValue(msg)
+		case *proto.APLAction:
+			return aplTextAction(msg)
+		case *proto.ActionID:
+			return aplTextActionID(msg)
+		case *proto.UnitReference:
+			return aplTextUnitReference(msg)
+		default:
+			return "(" + strings.Join(aplTextArgs(value.Message()), ", ") + ")"
+		}
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return strconv.Itoa(int(value.Enum()))
+	case protoreflect.StringKind:
+		return strconv.Quote(value.String())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	default:
+		return value.String()
+	}
+}
+
+func aplTextActionID(id *proto.ActionID) string {
+	var text string
+	switch rawId := id.RawId.(type) {
+	case *proto.ActionID_SpellId:
+		text = fmt.Sprintf("spell:%d", rawId.SpellId)
+	case *proto.ActionID_ItemId:
+		text = fmt.Sprintf("item:%d", rawId.ItemId)
+	case *proto.ActionID_OtherId:
+		if _, ok := proto.OtherAction_value[rawId.OtherId.String()]; ok {
+			text = "other:" + strings.TrimPrefix(rawId.OtherId.String(), aplTextOtherActionPrefix)
+		} else {
+			text = fmt.Sprintf("other:%d", rawId.OtherId)
+		}
+	default:
+		text = "none"
+	}
+	if id.Tag != 0 {
+		text += fmt.Sprintf(":%d", id.Tag)
+	}
+	return text
+}
+
+func aplTextUnitReference(unit *proto.UnitReference) string {
+	text := aplTextSnakeCase(unit.Type.String())
+	if _, ok := proto.UnitReference_Type_name[int32(unit.Type)]; !ok {
+		text = strconv.Itoa(int(unit.Type))
+	}
+	if unit.Index != 0 {
+		text += fmt.Sprintf(":%d", unit.Index)
+	}
+	if unit.Owner != nil {
+		text += "@" + aplTextUnitReference(unit.Owner)
+	}
+	return text
+}
+
+// Converts CamelCase enum names to snake_case, e.g. CurrentTarget to current_target.
+func aplTextSnakeCase(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func aplActionOneof() protoreflect.OneofDescriptor {
+	return (&proto.APLAction{}).ProtoReflect().Descriptor().Oneofs().ByName("action")
+}
+
+func aplValueOneof() protoreflect.OneofDescriptor {
+	return (&proto.APLValue{}).ProtoReflect().Descriptor().Oneofs().ByName("value")
+}
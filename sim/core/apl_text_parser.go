@@ -0,0 +1,741 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Error in APL text, with the 1-based position where it was found.
+type APLTextError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (err *APLTextError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", err.Line, err.Column, err.Msg)
+}
+
+type aplTokenKind int
+
+const (
+	aplTokenEOF aplTokenKind = iota
+	aplTokenNewline
+	aplTokenNote // A '##' line, text holds the note without the prefix.
+	aplTokenRaw  // Rest of a 'simple' line.
+	aplTokenIdent
+	aplTokenNumber
+	aplTokenString
+	aplTokenOp
+)
+
+type aplToken struct {
+	kind   aplTokenKind
+	text   string
+	line   int
+	column int
+}
+
+func (tok aplToken) String() string {
+	switch tok.kind {
+	case aplTokenEOF:
+		return "end of input"
+	case aplTokenNewline:
+		return "end of line"
+	case aplTokenNote:
+		return "note"
+	case aplTokenString:
+		return tok.text
+	default:
+		return "'" + tok.text + "'"
+	}
+}
+
+var aplTextOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "(", ")", "[", "]", ",", "=", ":", "@", "$"}
+
+// Splits APL text into tokens. Newlines inside parentheses or brackets do not end a statement.
+func tokenizeAPLText(text string) ([]aplToken, error) {
+	var tokens []aplToken
+	line, lineStart := 1, 0
+	depth := 0
+	atStatementStart := func() bool {
+		return len(tokens) == 0 || tokens[len(tokens)-1].kind == aplTokenNewline || tokens[len(tokens)-1].kind == aplTokenNote
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		column := i - lineStart + 1
+		errorf := func(format string, args ...interface{}) error {
+			return &APLTextError{Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+		}
+		restOfLine := func() string {
+			end := strings.IndexByte(text[i:], '\n')
+			if end == -1 {
+				end = len(text) - i
+			}
+			return strings.TrimSuffix(text[i:i+end], "\r")
+		}
+
+		switch {
+		case c == '\n':
+			if depth == 0 && !atStatementStart() {
+				tokens = append(tokens, aplToken{kind: aplTokenNewline, text: "\n", line: line, column: column})
+			}
+			i++
+			line, lineStart = line+1, i
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(text[i:], "##") && depth == 0 && atStatementStart():
+			note := strings.TrimPrefix(restOfLine()[2:], " ")
+			tokens = append(tokens, aplToken{kind: aplTokenNote, text: note, line: line, column: column})
+			i += len(restOfLine())
+		case c == '#':
+			i += len(restOfLine())
+		case c == '"':
+			end := i + 1
+			for end < len(text) && text[end] != '"' && text[end] != '\n' {
+				if text[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(text) || text[end] != '"' {
+				return nil, errorf("unterminated string")
+			}
+			str, err := strconv.Unquote(text[i : end+1])
+			if err != nil {
+				return nil, errorf("invalid string %s", text[i:end+1])
+			}
+			tokens = append(tokens, aplToken{kind: aplTokenString, text: str, line: line, column: column})
+			i = end + 1
+		case c >= '0' && c <= '9' || c == '.':
+			end := i
+			for end < len(text) && (text[end] >= '0' && text[end] <= '9' || text[end] == '.' || unicode.IsLetter(rune(text[end])) || text[end] == '%') {
+				end++
+			}
+			tokens = append(tokens, aplToken{kind: aplTokenNumber, text: text[i:end], line: line, column: column})
+			i = end
+		case c == '_' || unicode.IsLetter(rune(c)):
+			end := i
+			for end < len(text) && (text[end] == '_' || unicode.IsLetter(rune(text[end])) || unicode.IsDigit(rune(text[end]))) {
+				end++
+			}
+			ident := text[i:end]
+			if ident == "simple" && depth == 0 && atStatementStart() {
+				tokens = append(tokens, aplToken{kind: aplTokenIdent, text: ident, line: line, column: column})
+				i = end
+				tokens = append(tokens, aplToken{kind: aplTokenRaw, text: strings.TrimSpace(restOfLine()), line: line, column: i - lineStart + 1})
+				i += len(restOfLine())
+			} else {
+				tokens = append(tokens, aplToken{kind: aplTokenIdent, text: ident, line: line, column: column})
+				i = end
+			}
+		default:
+			op := ""
+			for _, candidate := range aplTextOperators {
+				if strings.HasPrefix(text[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, errorf("unexpected character %q", c)
+			}
+			switch op {
+			case "(", "[":
+				depth++
+			case ")", "]":
+				if depth > 0 {
+					depth--
+				}
+			}
+			tokens = append(tokens, aplToken{kind: aplTokenOp, text: op, line: line, column: column})
+			i += len(op)
+		}
+	}
+
+	if !atStatementStart() {
+		tokens = append(tokens, aplToken{kind: aplTokenNewline, text: "\n", line: line, column: len(text) - lineStart + 1})
+	}
+	tokens = append(tokens, aplToken{kind: aplTokenEOF, line: line, column: len(text) - lineStart + 1})
+	return tokens, nil
+}
+
+type aplTextParser struct {
+	tokens []aplToken
+	pos    int
+}
+
+// Parses a rotation from the APL text format, see APLRotationToText.
+func APLRotationFromText(text string) (rot *proto.APLRotation, err error) {
+	tokens, err := tokenizeAPLText(text)
+	if err != nil {
+		return nil, err
+	}
+
+	// Errors are raised as panics deep inside the recursive descent and returned here.
+	defer func() {
+		if r := recover(); r != nil {
+			textErr, ok := r.(*APLTextError)
+			if !ok {
+				panic(r)
+			}
+			rot, err = nil, textErr
+		}
+	}()
+
+	parser := &aplTextParser{tokens: tokens}
+	return parser.parseRotation(), nil
+}
+
+func (parser *aplTextParser) peek() aplToken {
+	return parser.tokens[parser.pos]
+}
+
+func (parser *aplTextParser) next() aplToken {
+	tok := parser.tokens[parser.pos]
+	if tok.kind != aplTokenEOF {
+		parser.pos++
+	}
+	return tok
+}
+
+func (parser *aplTextParser) failAt(tok aplToken, format string, args ...interface{}) {
+	panic(&APLTextError{Line: tok.line, Column: tok.column, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (parser *aplTextParser) isOp(op string) bool {
+	tok := parser.peek()
+	return tok.kind == aplTokenOp && tok.text == op
+}
+
+func (parser *aplTextParser) acceptOp(op string) bool {
+	if parser.isOp(op) {
+		parser.next()
+		return true
+	}
+	return false
+}
+
+func (parser *aplTextParser) expectOp(op string) {
+	if !parser.acceptOp(op) {
+		parser.failAt(parser.peek(), "expected '%s' but found %s", op, parser.peek())
+	}
+}
+
+func (parser *aplTextParser) expectIdent() aplToken {
+	tok := parser.next()
+	if tok.kind != aplTokenIdent {
+		parser.failAt(tok, "expected a name but found %s", tok)
+	}
+	return tok
+}
+
+func (parser *aplTextParser) expectEndOfStatement() {
+	if tok := parser.next(); tok.kind != aplTokenNewline {
+		parser.failAt(tok, "expected end of line but found %s", tok)
+	}
+}
+
+func (parser *aplTextParser) parseRotation() *proto.APLRotation {
+	rot := &proto.APLRotation{Type: proto.APLRotation_TypeAPL}
+	actionLists := map[string]*proto.APLActionList{}
+	getActionList := func(name string) *proto.APLActionList {
+		list, ok := actionLists[name]
+		if !ok {
+			list = &proto.APLActionList{Name: name}
+			actionLists[name] = list
+			rot.ActionLists = append(rot.ActionLists, list)
+		}
+		return list
+	}
+	var notes []string
+	var notesTok aplToken
+
+	for parser.peek().kind != aplTokenEOF {
+		tok := parser.peek()
+		if tok.kind == aplTokenNote {
+			if len(notes) == 0 {
+				notesTok = tok
+			}
+			notes = append(notes, parser.next().text)
+			continue
+		}
+
+		if tok.kind == aplTokenIdent && (tok.text == "type" || tok.text == "simple" || tok.text == "variable") {
+			if len(notes) > 0 {
+				parser.failAt(notesTok, "notes must be followed by a priority list item")
+			}
+			parser.next()
+			if tok.text == "variable" {
+				variable := &proto.APLVariable{Name: parser.parseName()}
+				if parser.acceptOp("=") {
+					variable.Value = parser.parseValue()
+				}
+				rot.Variables = append(rot.Variables, variable)
+			} else if tok.text == "type" {
+				typeTok := parser.expectIdent()
+				rotType, ok := proto.APLRotation_Type_value[typeTok.text]
+				if !ok {
+					parser.failAt(typeTok, "unknown rotation type %s", typeTok.text)
+				}
+				rot.Type = proto.APLRotation_Type(rotType)
+			} else {
+				rawTok := parser.next()
+				rot.Simple = &proto.SimpleRotation{}
+				if err := protojson.Unmarshal([]byte(rawTok.text), rot.Simple); err != nil {
+					parser.failAt(rawTok, "invalid simple rotation: %s", err)
+				}
+			}
+			parser.expectEndOfStatement()
+			continue
+		}
+
+		hide := false
+		if tok.kind == aplTokenIdent && tok.text == "hide" {
+			parser.next()
+			hide = true
+		}
+
+		if parser.peek().kind == aplTokenIdent && parser.peek().text == "prepull" {
+			if len(notes) > 0 {
+				parser.failAt(notesTok, "notes are only supported on priority list items")
+			}
+			parser.next()
+			prepullAction := &proto.APLPrepullAction{Hide: hide}
+			if !parser.isOp(":") {
+				prepullAction.DoAtValue = parser.parseValue()
+			}
+			parser.expectOp(":")
+			prepullAction.Action = parser.parseAction()
+			rot.PrepullActions = append(rot.PrepullActions, prepullAction)
+		} else {
+			priorityList := &rot.PriorityList
+			if parser.peek().kind == aplTokenIdent && parser.peek().text == "list" {
+				parser.next()
+				list := getActionList(parser.parseName())
+				if !parser.acceptOp(":") {
+					// Declares an empty list.
+					if hide || len(notes) > 0 {
+						parser.failAt(parser.peek(), "expected ':' but found %s", parser.peek())
+					}
+					parser.expectEndOfStatement()
+					continue
+				}
+				priorityList = &list.PriorityList
+			}
+			*priorityList = append(*priorityList, &proto.APLListItem{
+				Hide:   hide,
+				Notes:  strings.Join(notes, "\n"),
+				Action: parser.parseAction(),
+			})
+			notes = nil
+		}
+		parser.expectEndOfStatement()
+	}
+
+	if len(notes) > 0 {
+		parser.failAt(notesTok, "notes must be followed by a priority list item")
+	}
+	return rot
+}
+
+// Parses the name of a variable or action list, either an identifier or a string.
+func (parser *aplTextParser) parseName() string {
+	tok := parser.next()
+	if tok.kind != aplTokenIdent && tok.kind != aplTokenString {
+		parser.failAt(tok, "expected a name but found %s", tok)
+	}
+	return tok.text
+}
+
+// Parses an action with an optional 'if' condition.
+func (parser *aplTextParser) parseAction() *proto.APLAction {
+	action := &proto.APLAction{}
+	tok := parser.expectIdent()
+	if tok.text != "none" {
+		field := aplActionOneof().Fields().ByName(protoreflect.Name(tok.text))
+		if field == nil {
+			parser.failAt(tok, "unknown action %s", tok.text)
+		}
+		msg := action.ProtoReflect().Mutable(field).Message()
+		parser.parseCallArgs(tok, msg)
+	}
+
+	if parser.peek().kind == aplTokenIdent && parser.peek().text == "if" {
+		parser.next()
+		action.Condition = parser.parseValue()
+	}
+	return action
+}
+
+func (parser *aplTextParser) parseValue() *proto.APLValue {
+	return parser.parseOr()
+}
+
+func (parser *aplTextParser) parseOr() *proto.APLValue {
+	vals := []*proto.APLValue{parser.parseAnd()}
+	for parser.acceptOp("||") {
+		vals = append(vals, parser.parseAnd())
+	}
+	if len(vals) == 1 {
+		return vals[0]
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: vals}}}
+}
+
+func (parser *aplTextParser) parseAnd() *proto.APLValue {
+	vals := []*proto.APLValue{parser.parseCmp()}
+	for parser.acceptOp("&&") {
+		vals = append(vals, parser.parseCmp())
+	}
+	if len(vals) == 1 {
+		return vals[0]
+	}
+	return &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: vals}}}
+}
+
+func (parser *aplTextParser) acceptCmpOp() (proto.APLValueCompare_ComparisonOperator, bool) {
+	for op, text := range aplTextCmpOps {
+		if parser.acceptOp(text) {
+			return op, true
+		}
+	}
+	return proto.APLValueCompare_OpUnknown, false
+}
+
+func (parser *aplTextParser) parseCmp() *proto.APLValue {
+	lhs := parser.parseMath(aplTextPrecAdd)
+	op, ok := parser.acceptCmpOp()
+	if !ok {
+		return lhs
+	}
+	rhs := parser.parseMath(aplTextPrecAdd)
+	if tok := parser.peek(); tok.kind == aplTokenOp {
+		if _, chained := aplTextCmpOpByText(tok.text); chained {
+			parser.failAt(tok, "comparisons cannot be chained, use parentheses")
+		}
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{Op: op, Lhs: lhs, Rhs: rhs}}}
+}
+
+func aplTextCmpOpByText(text string) (proto.APLValueCompare_ComparisonOperator, bool) {
+	for op, opText := range aplTextCmpOps {
+		if opText == text {
+			return op, true
+		}
+	}
+	return proto.APLValueCompare_OpUnknown, false
+}
+
+func aplTextMathOpByText(text string) (proto.APLValueMath_MathOperator, bool) {
+	for op, opText := range aplTextMathOps {
+		if opText == text {
+			return op, true
+		}
+	}
+	return proto.APLValueMath_OpUnknown, false
+}
+
+// Parses left-associative math operators of the given precedence or higher.
+func (parser *aplTextParser) parseMath(prec int) *proto.APLValue {
+	if prec > aplTextPrecMul {
+		return parser.parseUnary()
+	}
+
+	lhs := parser.parseMath(prec + 1)
+	for {
+		tok := parser.peek()
+		op, ok := aplTextMathOpByText(tok.text)
+		opPrec := aplTextPrecAdd
+		if op == proto.APLValueMath_OpMul || op == proto.APLValueMath_OpDiv {
+			opPrec = aplTextPrecMul
+		}
+		if tok.kind != aplTokenOp || !ok || opPrec != prec {
+			return lhs
+		}
+		parser.next()
+		rhs := parser.parseMath(prec + 1)
+		lhs = &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{Op: op, Lhs: lhs, Rhs: rhs}}}
+	}
+}
+
+func (parser *aplTextParser) parseUnary() *proto.APLValue {
+	if parser.acceptOp("!") {
+		return &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: parser.parseUnary()}}}
+	}
+	if minusTok := parser.peek(); parser.acceptOp("-") {
+		if parser.peek().kind != aplTokenNumber {
+			parser.failAt(minusTok, "unary minus is only supported on numbers")
+		}
+		return aplTextConst("-" + parser.parseNumber().text)
+	}
+	return parser.parsePrimary()
+}
+
+func aplTextConst(val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}}
+}
+
+func (parser *aplTextParser) parseNumber() aplToken {
+	tok := parser.next()
+	if tok.kind != aplTokenNumber || !aplTextNumberRegex.MatchString(tok.text) {
+		parser.failAt(tok, "invalid number %s", tok)
+	}
+	return tok
+}
+
+func (parser *aplTextParser) parsePrimary() *proto.APLValue {
+	tok := parser.peek()
+	switch tok.kind {
+	case aplTokenNumber:
+		return aplTextConst(parser.parseNumber().text)
+	case aplTokenString:
+		return aplTextConst(parser.next().text)
+	case aplTokenOp:
+		if parser.acceptOp("(") {
+			value := parser.parseValue()
+			parser.expectOp(")")
+			return value
+		}
+		if parser.acceptOp("$") {
+			return &proto.APLValue{Value: &proto.APLValue_VariableRef{VariableRef: &proto.APLValueVariableRef{Name: parser.parseName()}}}
+		}
+	case aplTokenIdent:
+		parser.next()
+		switch tok.text {
+		case "true", "false":
+			return aplTextConst(tok.text)
+		case "none":
+			return &proto.APLValue{}
+		}
+		field := aplValueOneof().Fields().ByName(protoreflect.Name(tok.text))
+		if field == nil {
+			parser.failAt(tok, "unknown value %s", tok.text)
+		}
+		value := &proto.APLValue{}
+		parser.parseCallArgs(tok, value.ProtoReflect().Mutable(field).Message())
+		return value
+	}
+	parser.failAt(tok, "expected a value but found %s", tok)
+	return nil
+}
+
+// Parses the optional argument list of a call into msg.
+func (parser *aplTextParser) parseCallArgs(nameTok aplToken, msg protoreflect.Message) {
+	if !parser.acceptOp("(") {
+		return
+	}
+	parser.parseArgs(nameTok, msg, ")")
+}
+
+// Parses comma-separated arguments until the closing operator.
+func (parser *aplTextParser) parseArgs(nameTok aplToken, msg protoreflect.Message, closing string) {
+	fields := msg.Descriptor().Fields()
+	positional := aplTextPositionalField(msg.Descriptor())
+	seen := map[protoreflect.Name]bool{}
+
+	for !parser.acceptOp(closing) {
+		argTok := parser.peek()
+		var field protoreflect.FieldDescriptor
+		if argTok.kind == aplTokenIdent && parser.tokens[parser.pos+1].kind == aplTokenOp && parser.tokens[parser.pos+1].text == "=" {
+			parser.next()
+			parser.next()
+			field = fields.ByName(protoreflect.Name(argTok.text))
+			if field == nil {
+				parser.failAt(argTok, "unknown argument %s of %s", argTok.text, nameTok.text)
+			}
+			if seen[field.Name()] {
+				parser.failAt(argTok, "duplicate argument %s of %s", argTok.text, nameTok.text)
+			}
+			seen[field.Name()] = true
+
+			if field.IsList() {
+				parser.expectOp("[")
+				list := msg.Mutable(field).List()
+				for !parser.acceptOp("]") {
+					list.Append(parser.parseFieldValue(field, list.NewElement()))
+					if !parser.isOp("]") {
+						parser.expectOp(",")
+					}
+				}
+			} else {
+				msg.Set(field, parser.parseFieldValue(field, msg.NewField(field)))
+			}
+		} else {
+			if positional == nil {
+				parser.failAt(argTok, "%s only takes named arguments", nameTok.text)
+			}
+			field = positional
+			if field.IsList() {
+				list := msg.Mutable(field).List()
+				list.Append(parser.parseFieldValue(field, list.NewElement()))
+			} else {
+				if seen[field.Name()] {
+					parser.failAt(argTok, "duplicate argument %s of %s", field.Name(), nameTok.text)
+				}
+				msg.Set(field, parser.parseFieldValue(field, msg.NewField(field)))
+			}
+			seen[field.Name()] = true
+		}
+
+		if !parser.isOp(closing) {
+			parser.expectOp(",")
+		}
+	}
+}
+
+// Parses a single value of the field, newValue is an empty value of its type.
+func (parser *aplTextParser) parseFieldValue(field protoreflect.FieldDescriptor, newValue protoreflect.Value) protoreflect.Value {
+	tok := parser.peek()
+	switch field.Kind() {
+	case protoreflect.MessageKind:
+		switch newValue.Message().Interface().(type) {
+		case *proto.APLValue:
+			return protoreflect.ValueOfMessage(parser.parseValue().ProtoReflect())
+		case *proto.APLAction:
+			return protoreflect.ValueOfMessage(parser.parseAction().ProtoReflect())
+		case *proto.ActionID:
+			return protoreflect.ValueOfMessage(parser.parseActionID().ProtoReflect())
+		case *proto.UnitReference:
+			return protoreflect.ValueOfMessage(parser.parseUnitReference().ProtoReflect())
+		default:
+			parser.expectOp("(")
+			parser.parseArgs(aplToken{text: string(field.Name())}, newValue.Message(), ")")
+			return newValue
+		}
+	case protoreflect.EnumKind:
+		if tok.kind == aplTokenIdent {
+			parser.next()
+			enumValue := field.Enum().Values().ByName(protoreflect.Name(tok.text))
+			if enumValue == nil {
+				parser.failAt(tok, "unknown %s value %s", field.Enum().Name(), tok.text)
+			}
+			return protoreflect.ValueOfEnum(enumValue.Number())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(parser.parseInt(32)))
+	case protoreflect.BoolKind:
+		parser.next()
+		if tok.kind != aplTokenIdent || (tok.text != "true" && tok.text != "false") {
+			parser.failAt(tok, "expected true or false but found %s", tok)
+		}
+		return protoreflect.ValueOfBool(tok.text == "true")
+	case protoreflect.StringKind:
+		parser.next()
+		if tok.kind != aplTokenString {
+			parser.failAt(tok, "expected a string but found %s", tok)
+		}
+		return protoreflect.ValueOfString(tok.text)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		text := parser.parseSignedNumber()
+		val, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			parser.failAt(tok, "invalid number %s", text)
+		}
+		if field.Kind() == protoreflect.FloatKind {
+			return protoreflect.ValueOfFloat32(float32(val))
+		}
+		return protoreflect.ValueOfFloat64(val)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(parser.parseInt(32)))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(parser.parseInt(64))
+	}
+	parser.failAt(tok, "arguments of type %s are not supported", field.Kind())
+	return newValue
+}
+
+// Returns the text of a number with an optional minus sign.
+func (parser *aplTextParser) parseSignedNumber() string {
+	sign := ""
+	if parser.acceptOp("-") {
+		sign = "-"
+	}
+	tok := parser.next()
+	if tok.kind != aplTokenNumber {
+		parser.failAt(tok, "expected a number but found %s", tok)
+	}
+	return sign + tok.text
+}
+
+func (parser *aplTextParser) parseInt(bitSize int) int64 {
+	tok := parser.peek()
+	text := parser.parseSignedNumber()
+	val, err := strconv.ParseInt(text, 10, bitSize)
+	if err != nil {
+		parser.failAt(tok, "invalid integer %s", text)
+	}
+	return val
+}
+
+// Parses action IDs like spell:12294, item:76095, other:Potion or spell:107570:1 with a tag.
+func (parser *aplTextParser) parseActionID() *proto.ActionID {
+	kindTok := parser.expectIdent()
+	id := &proto.ActionID{}
+	switch kindTok.text {
+	case "spell":
+		parser.expectOp(":")
+		id.RawId = &proto.ActionID_SpellId{SpellId: int32(parser.parseInt(32))}
+	case "item":
+		parser.expectOp(":")
+		id.RawId = &proto.ActionID_ItemId{ItemId: int32(parser.parseInt(32))}
+	case "other":
+		parser.expectOp(":")
+		if tok := parser.peek(); tok.kind == aplTokenIdent {
+			parser.next()
+			otherId, ok := proto.OtherAction_value[aplTextOtherActionPrefix+tok.text]
+			if !ok {
+				otherId, ok = proto.OtherAction_value[tok.text]
+			}
+			if !ok {
+				parser.failAt(tok, "unknown other action %s", tok.text)
+			}
+			id.RawId = &proto.ActionID_OtherId{OtherId: proto.OtherAction(otherId)}
+		} else {
+			id.RawId = &proto.ActionID_OtherId{OtherId: proto.OtherAction(parser.parseInt(32))}
+		}
+	case "none":
+	default:
+		parser.failAt(kindTok, "expected an action ID like spell:123 but found %s", kindTok)
+	}
+
+	if parser.acceptOp(":") {
+		id.Tag = int32(parser.parseInt(32))
+	}
+	return id
+}
+
+// Parses unit references like target, target:1 or pet:0@player:2 with an owner.
+func (parser *aplTextParser) parseUnitReference() *proto.UnitReference {
+	unit := &proto.UnitReference{}
+	if tok := parser.peek(); tok.kind == aplTokenIdent {
+		parser.next()
+		found := false
+		for name, val := range proto.UnitReference_Type_value {
+			if aplTextSnakeCase(name) == tok.text {
+				unit.Type, found = proto.UnitReference_Type(val), true
+				break
+			}
+		}
+		if !found {
+			parser.failAt(tok, "unknown unit %s", tok.text)
+		}
+	} else {
+		unit.Type = proto.UnitReference_Type(parser.parseInt(32))
+	}
+
+	if parser.acceptOp(":") {
+		unit.Index = int32(parser.parseInt(32))
+	}
+	if parser.acceptOp("@") {
+		unit.Owner = parser.parseUnitReference()
+	}
+	return unit
+}
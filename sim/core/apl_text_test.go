@@ -0,0 +1,196 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	goproto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestAPLTextRoundTripsDefaultRotations(t *testing.T) {
+	files, err := filepath.Glob("../../ui/*/*/apls/*.apl.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("No default APLs found")
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Some default APLs still contain fields of removed options.
+		rot := &proto.APLRotation{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, rot); err != nil {
+			t.Fatalf("%s: %s", file, err)
+		}
+
+		text := APLRotationToText(rot)
+		parsed, err := APLRotationFromText(text)
+		if err != nil {
+			t.Fatalf("%s: failed to parse its text: %s\n%s", file, err, text)
+		}
+		if !goproto.Equal(rot, parsed) {
+			t.Fatalf("%s: rotation changed by a round trip through its text:\n%s", file, text)
+		}
+		if reprinted := APLRotationToText(parsed); reprinted != text {
+			t.Fatalf("%s: text changed by a round trip:\n%s\n\nvs\n\n%s", file, text, reprinted)
+		}
+	}
+}
+
+func TestAPLTextParse(t *testing.T) {
+	text := `
+# Comments are ignored.
+prepull -1.5s: cast_spell(other:Potion)
+
+## Keep the bleed up.
+cast_spell(spell:12294) if aura_remaining_time(spell:60503, source_unit=target:1) < 2s && current_rage > 30
+hide cast_spell(spell:107570:1) if !(unknown_value)
+`
+	_, err := APLRotationFromText(text)
+	var textErr *APLTextError
+	if !errors.As(err, &textErr) || textErr.Line != 7 || textErr.Column != 38 {
+		t.Fatalf("Expected an error at line 7, column 38, got %v", err)
+	}
+
+	text = `
+prepull -1.5s: cast_spell(other:Potion)
+
+## Keep the bleed up.
+cast_spell(spell:12294) if aura_remaining_time(spell:60503, source_unit=target:1) < 2s && current_rage > 30
+hide cast_spell(spell:107570:1) if !(current_rage < 10 || gcd_is_ready) && current_rage - max_rage * 2 >= -5
+`
+	rot, err := APLRotationFromText(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PrepullActions: []*proto.APLPrepullAction{{
+			DoAtValue: aplTextConst("-1.5s"),
+			Action: &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+				SpellId: &proto.ActionID{RawId: &proto.ActionID_OtherId{OtherId: proto.OtherAction_OtherActionPotion}},
+			}}},
+		}},
+		PriorityList: []*proto.APLListItem{
+			{
+				Notes: "Keep the bleed up.",
+				Action: &proto.APLAction{
+					Condition: &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: []*proto.APLValue{
+						{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+							Op: proto.APLValueCompare_OpLt,
+							Lhs: &proto.APLValue{Value: &proto.APLValue_AuraRemainingTime{AuraRemainingTime: &proto.APLValueAuraRemainingTime{
+								SourceUnit: &proto.UnitReference{Type: proto.UnitReference_Target, Index: 1},
+								AuraId:     &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 60503}},
+							}}},
+							Rhs: aplTextConst("2s"),
+						}}},
+						{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+							Op:  proto.APLValueCompare_OpGt,
+							Lhs: &proto.APLValue{Value: &proto.APLValue_CurrentRage{CurrentRage: &proto.APLValueCurrentRage{}}},
+							Rhs: aplTextConst("30"),
+						}}},
+					}}}},
+					Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+						SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 12294}},
+					}},
+				},
+			},
+			{
+				Hide: true,
+				Action: &proto.APLAction{
+					Condition: &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: []*proto.APLValue{
+						{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: &proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: []*proto.APLValue{
+							{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+								Op:  proto.APLValueCompare_OpLt,
+								Lhs: &proto.APLValue{Value: &proto.APLValue_CurrentRage{CurrentRage: &proto.APLValueCurrentRage{}}},
+								Rhs: aplTextConst("10"),
+							}}},
+							{Value: &proto.APLValue_GcdIsReady{GcdIsReady: &proto.APLValueGCDIsReady{}}},
+						}}}}}}},
+						{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+							Op: proto.APLValueCompare_OpGe,
+							Lhs: &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{
+								Op:  proto.APLValueMath_OpSub,
+								Lhs: &proto.APLValue{Value: &proto.APLValue_CurrentRage{CurrentRage: &proto.APLValueCurrentRage{}}},
+								Rhs: &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{
+									Op:  proto.APLValueMath_OpMul,
+									Lhs: &proto.APLValue{Value: &proto.APLValue_MaxRage{MaxRage: &proto.APLValueMaxRage{}}},
+									Rhs: aplTextConst("2"),
+								}}},
+							}}},
+							Rhs: aplTextConst("-5"),
+						}}},
+					}}}},
+					Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+						SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 107570}, Tag: 1},
+					}},
+				},
+			},
+		},
+	}
+	if !goproto.Equal(rot, expected) {
+		t.Fatalf("Unexpected rotation:\n%s", APLRotationToText(rot))
+	}
+}
+
+func TestAPLTextOperatorParentheses(t *testing.T) {
+	// Nested operators which need parentheses to keep their structure.
+	for _, text := range []string{
+		"wait(1s - (2s - 3s))\n",
+		"wait((1 < 2) == true)\n",
+		"wait_until((gcd_is_ready || front_of_target) && gcd_is_ready)\n",
+		"wait_until((gcd_is_ready && gcd_is_ready) && gcd_is_ready)\n",
+		"wait_until(!(gcd_is_ready || gcd_is_ready))\n",
+		"wait(and(gcd_is_ready))\n",
+		`wait("10 yards")` + "\n",
+	} {
+		rot, err := APLRotationFromText(text)
+		if err != nil {
+			t.Fatalf("%q: %s", text, err)
+		}
+		if reprinted := APLRotationToText(rot); reprinted != text {
+			t.Fatalf("Expected %q to be printed unchanged, got %q", text, reprinted)
+		}
+	}
+}
+
+func TestAPLTextVariablesAndLists(t *testing.T) {
+	text := `variable execute = $"low health" && current_rage > 30
+variable "low health" = is_execute_phase(E20)
+variable unset
+
+call_list("aoe") if number_targets > 2
+run_list("single target")
+
+## Only with many targets.
+list aoe: cast_spell(spell:1680) if $execute
+hide list aoe: wait(1s)
+
+list "single target"
+
+list "unused list": wait(1s)
+`
+	rot, err := APLRotationFromText(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rot.Variables) != 3 || rot.Variables[1].Name != "low health" || rot.Variables[2].Value != nil {
+		t.Fatalf("Unexpected variables: %v", rot.Variables)
+	}
+	if len(rot.ActionLists) != 3 || len(rot.ActionLists[0].PriorityList) != 2 || len(rot.ActionLists[1].PriorityList) != 0 {
+		t.Fatalf("Unexpected action lists: %v", rot.ActionLists)
+	}
+	if reprinted := APLRotationToText(rot); reprinted != text {
+		t.Fatalf("Expected the text to be printed unchanged, got:\n%s", reprinted)
+	}
+}
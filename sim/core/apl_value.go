@@ -259,6 +259,10 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 	case *proto.APLValue_SequenceTimeToReady:
 		value = rot.newValueSequenceTimeToReady(config.GetSequenceTimeToReady(), config.Uuid)
 
+	// Variables
+	case *proto.APLValue_VariableRef:
+		value = rot.newValueVariableRef(config.GetVariableRef(), config.Uuid)
+
 	// Properties
 	case *proto.APLValue_ChannelClipDelay:
 		value = rot.newValueChannelClipDelay(config.GetChannelClipDelay(), config.Uuid)
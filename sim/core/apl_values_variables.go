@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type APLVariable struct {
+	rot    *APLRotation
+	name   string
+	config *proto.APLValue
+	value  APLValue
+
+	// Validation warnings of the value, reported like those of list items.
+	validations []*proto.APLValidation
+
+	// Used to detect variables which depend on themselves while parsing.
+	parsing bool
+	parsed  bool
+
+	// Result of the last evaluation, valid during the same decision.
+	cachedDecision int
+	cachedTime     time.Duration
+	boolVal        bool
+	intVal         int32
+	floatVal       float64
+	durationVal    time.Duration
+	stringVal      string
+}
+
+// Returns the variable with the given name, parsing its value first if needed.
+func (rot *APLRotation) getVariable(name string) *APLVariable {
+	variable, ok := rot.variablesByName[name]
+	if !ok {
+		rot.ValidationMessage(proto.LogLevel_Warning, "No variable with name: '%s'", name)
+		return nil
+	}
+
+	if variable.parsing {
+		rot.ValidationMessage(proto.LogLevel_Warning, "Variable '%s' depends on itself", name)
+		return nil
+	}
+	if !variable.parsed {
+		variable.parsing = true
+		rot.doAndRecordNestedWarnings(&variable.validations, func() {
+			variable.value = rot.newAPLValue(variable.config)
+		})
+		variable.parsing = false
+		variable.parsed = true
+	}
+
+	if variable.value == nil {
+		rot.ValidationMessage(proto.LogLevel_Warning, "Variable '%s' does not have a valid value", name)
+		return nil
+	}
+	return variable
+}
+
+func (variable *APLVariable) reset() {
+	variable.cachedDecision = -1
+}
+
+// Evaluates the variable unless it was already evaluated for the current decision.
+func (variable *APLVariable) evaluate(sim *Simulation) {
+	if variable.cachedDecision == variable.rot.decisionIdx && variable.cachedTime == sim.CurrentTime {
+		return
+	}
+	variable.cachedDecision = variable.rot.decisionIdx
+	variable.cachedTime = sim.CurrentTime
+
+	switch variable.value.Type() {
+	case proto.APLValueType_ValueTypeBool:
+		variable.boolVal = variable.value.GetBool(sim)
+	case proto.APLValueType_ValueTypeInt:
+		variable.intVal = variable.value.GetInt(sim)
+	case proto.APLValueType_ValueTypeFloat:
+		variable.floatVal = variable.value.GetFloat(sim)
+	case proto.APLValueType_ValueTypeDuration:
+		variable.durationVal = variable.value.GetDuration(sim)
+	case proto.APLValueType_ValueTypeString:
+		variable.stringVal = variable.value.GetString(sim)
+	}
+}
+
+type APLValueVariableRef struct {
+	DefaultAPLValueImpl
+	variable *APLVariable
+}
+
+func (rot *APLRotation) newValueVariableRef(config *proto.APLValueVariableRef, _ *proto.UUID) APLValue {
+	variable := rot.getVariable(config.Name)
+	if variable == nil {
+		return nil
+	}
+	return &APLValueVariableRef{
+		variable: variable,
+	}
+}
+func (value *APLValueVariableRef) Type() proto.APLValueType {
+	return value.variable.value.Type()
+}
+
+// Getters other than the one of the value type are passed through uncached, like for constants.
+func (value *APLValueVariableRef) GetBool(sim *Simulation) bool {
+	if value.Type() != proto.APLValueType_ValueTypeBool {
+		return value.variable.value.GetBool(sim)
+	}
+	value.variable.evaluate(sim)
+	return value.variable.boolVal
+}
+func (value *APLValueVariableRef) GetInt(sim *Simulation) int32 {
+	if value.Type() != proto.APLValueType_ValueTypeInt {
+		return value.variable.value.GetInt(sim)
+	}
+	value.variable.evaluate(sim)
+	return value.variable.intVal
+}
+func (value *APLValueVariableRef) GetFloat(sim *Simulation) float64 {
+	if value.Type() != proto.APLValueType_ValueTypeFloat {
+		return value.variable.value.GetFloat(sim)
+	}
+	value.variable.evaluate(sim)
+	return value.variable.floatVal
+}
+func (value *APLValueVariableRef) GetDuration(sim *Simulation) time.Duration {
+	if value.Type() != proto.APLValueType_ValueTypeDuration {
+		return value.variable.value.GetDuration(sim)
+	}
+	value.variable.evaluate(sim)
+	return value.variable.durationVal
+}
+func (value *APLValueVariableRef) GetString(sim *Simulation) string {
+	if value.Type() != proto.APLValueType_ValueTypeString {
+		return value.variable.value.GetString(sim)
+	}
+	value.variable.evaluate(sim)
+	return value.variable.stringVal
+}
+func (value *APLValueVariableRef) String() string {
+	return fmt.Sprintf("Variable(%s)", value.variable.name)
+}
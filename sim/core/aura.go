@@ -205,6 +205,10 @@ func (aura *Aura) SetStacks(sim *Simulation, newStacks int32) {
 	if sim.Log != nil {
 		aura.Unit.Log(sim, "%s stacks: %d --> %d", aura.ActionID, oldStacks, newStacks)
 	}
+	// Stacks are cleared when an aura fades, which the fade event already reports.
+	if sim.CombatLog != nil && !aura.ActionID.IsEmptyAction() && aura.IsActive() {
+		sim.CombatLog.AuraStacks(sim, aura, oldStacks, newStacks)
+	}
 	aura.stacks = newStacks
 	if aura.OnStacksChange != nil {
 		aura.OnStacksChange(aura, sim, oldStacks, newStacks)
@@ -739,6 +743,9 @@ func (aura *Aura) Activate(sim *Simulation) {
 	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
 		aura.Unit.Log(sim, "Aura gained: %s", aura.ActionID)
 	}
+	if sim.CombatLog != nil && !aura.ActionID.IsEmptyAction() {
+		sim.CombatLog.AuraGained(sim, aura)
+	}
 
 	// don't invoke possible callbacks until the internal state is consistent
 	if aura.OnGain != nil {
@@ -761,13 +768,16 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		}
 	}
 
-	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
+	if (sim.Log != nil || sim.CombatLog != nil) && !aura.ActionID.IsEmptyAction() {
 		// fix logging timestamps for lazy aura expiration
 		oldTime := sim.CurrentTime
 		sim.CurrentTime = min(sim.CurrentTime, aura.expires)
 		if sim.Log != nil {
 			aura.Unit.Log(sim, "Aura faded: %s", aura.ActionID)
 		}
+		if sim.CombatLog != nil {
+			sim.CombatLog.AuraFaded(sim, aura)
+		}
 		sim.CurrentTime = oldTime
 	}
 
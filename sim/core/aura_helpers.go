@@ -655,6 +655,24 @@ func (unit *Unit) NewDamageAbsorptionAura(config AbsorptionAuraConfig) *DamageAb
 	return aura
 }
 
+// Adds to the remaining absorb of a stacking shield, activating or refreshing
+// it, with the total capped at maxStrength. Returns the absorb actually added.
+func (aura *DamageAbsorptionAura) AddShieldStrength(sim *Simulation, amount float64, maxStrength float64) float64 {
+	current := TernaryFloat64(aura.IsActive(), aura.ShieldStrength, 0)
+	newStrength := min(current+amount, maxStrength)
+	if newStrength <= current {
+		return 0
+	}
+
+	aura.Aura.Activate(sim)
+	aura.ShieldStrength = newStrength
+	stacks := max(1, int32(newStrength))
+	aura.Aura.MaxStacks = max(aura.Aura.MaxStacks, stacks)
+	aura.Aura.SetStacks(sim, stacks)
+
+	return newStrength - current
+}
+
 func (aura *DamageAbsorptionAura) AttachOnDamageAbsorbed(callback OnDamageAbsorbedCallback) *DamageAbsorptionAura {
 	aura.OnDamageAbsorbed = append(aura.OnDamageAbsorbed, callback)
 
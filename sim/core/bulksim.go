@@ -18,6 +18,9 @@ import (
 
 const (
 	defaultIterationsPerCombo = 1000
+
+	// Z-score of the two-sided 95% confidence interval used to race combos in fast mode.
+	bulkSimConfidenceZ = 1.96
 )
 
 // raidSimRunner runs a standard raid simulation.
@@ -29,6 +32,8 @@ type bulkSimRunner struct {
 	SingleRaidSimRunner raidSimRunner
 	// Request used for this bulk simulation.
 	Request *proto.BulkSimRequest
+	// Number of combos simmed at once, defaults to the number of CPUs.
+	Concurrency int
 }
 
 func BulkSim(signals simsignals.Signals, request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics) *proto.BulkSimResult {
@@ -37,7 +42,12 @@ func BulkSim(signals simsignals.Signals, request *proto.BulkSimRequest, progress
 		Request:             request,
 	}
 
-	result := bulk.Run(signals, progress)
+	return bulk.runWithProgress(signals, progress)
+}
+
+// Runs the bulk sim, sending the final result to the progress channel and closing it.
+func (b *bulkSimRunner) runWithProgress(signals simsignals.Signals, progress chan *proto.ProgressMetrics) *proto.BulkSimResult {
+	result := b.Run(signals, progress)
 
 	if progress != nil {
 		progress <- &proto.ProgressMetrics{
@@ -77,6 +87,13 @@ func BulkSimCombos(signals simsignals.Signals, req *proto.BulkSimCombosRequest)
 	// clean to reduce memory
 	player.Database = nil
 
+	filteredLoadouts, err := applyTalentSearch(req.BaseSettings, req.BulkSettings, player)
+	if err != nil {
+		return &proto.BulkSimCombosResult{
+			ErrorResult: err.Error(),
+		}
+	}
+
 	validCombos, iterations, err := buildCombos(signals, req.BaseSettings, req.BulkSettings, player)
 	if err != nil {
 		return &proto.BulkSimCombosResult{
@@ -85,8 +102,9 @@ func BulkSimCombos(signals simsignals.Signals, req *proto.BulkSimCombosRequest)
 	}
 
 	result := &proto.BulkSimCombosResult{
-		NumCombinations: int32(len(validCombos)),
-		NumIterations:   int32(len(validCombos)) * iterations,
+		NumCombinations:           int32(len(validCombos)),
+		NumIterations:             int32(len(validCombos)) * iterations,
+		NumFilteredTalentLoadouts: int32(len(filteredLoadouts)),
 	}
 
 	return result
@@ -143,6 +161,13 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 		originalIterations = defaultIterationsPerCombo
 	}
 
+	filteredLoadouts, err := applyTalentSearch(b.Request.BaseSettings, b.Request.BulkSettings, player)
+	if err != nil {
+		return &proto.BulkSimResult{
+			Error: &proto.ErrorOutcome{Message: err.Error()},
+		}
+	}
+
 	validCombos, newIters, err := buildCombos(signals, b.Request.BaseSettings, b.Request.BulkSettings, player)
 	if err != nil {
 		return &proto.BulkSimResult{
@@ -153,45 +178,9 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 	// TODO(Riotdog-GehennasEU): Make this configurable?
 	maxResults := 30
 
-	var rankedResults []*itemSubstitutionSimResult
-	var baseResult *itemSubstitutionSimResult
-
-	for {
-		var tempBase *itemSubstitutionSimResult
-		var errorOutcome *proto.ErrorOutcome
-		// TODO: we could theoretically make getRankedResults accept a channel of validCombos that stream in to it and launches sims as it gets them...
-		rankedResults, tempBase, errorOutcome = b.getRankedResults(signals, validCombos, newIters, progress)
-
-		if errorOutcome != nil {
-			return &proto.BulkSimResult{Error: errorOutcome}
-		}
-		// keep replacing the base result with more refined base until we don't have base in the ranked results anymore.
-		if tempBase != nil {
-			baseResult = tempBase
-		}
-
-		// If we aren't doing fast mode, or if halving our results will be less than the maxResults, be done.
-		if !b.Request.BulkSettings.FastMode || len(rankedResults) <= maxResults*2 {
-			break
-		}
-
-		// we have reached max accuracy now
-		if newIters >= originalIterations {
-			break
-		}
-
-		// Increase accuracy
-		newIters *= 2
-		newNumCombos := len(rankedResults) / 2
-		validCombos = validCombos[:newNumCombos]
-		rankedResults = rankedResults[:newNumCombos]
-		for i, comb := range rankedResults {
-			validCombos[i] = singleBulkSim{
-				req: comb.Request,
-				cl:  comb.ChangeLog,
-				eq:  comb.Substitution,
-			}
-		}
+	rankedResults, baseResult, errorOutcome := b.raceCombos(signals, validCombos, newIters, originalIterations, maxResults, progress)
+	if errorOutcome != nil {
+		return &proto.BulkSimResult{Error: errorOutcome}
 	}
 
 	if baseResult == nil {
@@ -212,10 +201,15 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 	bum.Resources = nil
 	bum.Pets = nil
 
+	baseLower, baseUpper := baseResult.ConfidenceInterval()
 	result = &proto.BulkSimResult{
 		EquippedGearResult: &proto.BulkComboResult{
 			UnitMetrics: bum,
+			DpsCiLower:  baseLower,
+			DpsCiUpper:  baseUpper,
+			Iterations:  baseResult.Iterations(),
 		},
+		FilteredTalentLoadouts: filteredLoadouts,
 	}
 
 	for _, r := range rankedResults {
@@ -224,10 +218,14 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 		um.Auras = nil
 		um.Resources = nil
 		um.Pets = nil
+		lower, upper := r.ConfidenceInterval()
 		result.Results = append(result.Results, &proto.BulkComboResult{
 			ItemsAdded:    r.ChangeLog.AddedItems,
 			UnitMetrics:   um,
 			TalentLoadout: r.ChangeLog.TalentLoadout,
+			DpsCiLower:    lower,
+			DpsCiUpper:    upper,
+			Iterations:    r.Iterations(),
 		})
 	}
 
@@ -240,10 +238,77 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 	return result
 }
 
+// raceCombos sims all combos with the given number of iterations. In fast mode it then keeps
+// re-simming the combos that could still be the best one with twice the iterations, until the
+// best combo is separated from the rest or the full number of iterations is reached.
+func (b *bulkSimRunner) raceCombos(signals simsignals.Signals, validCombos []singleBulkSim, iterations int32, maxIterations int32, maxResults int, progress chan *proto.ProgressMetrics) ([]*itemSubstitutionSimResult, *itemSubstitutionSimResult, *proto.ErrorOutcome) {
+	var rankedResults []*itemSubstitutionSimResult
+	var separatedResults []*itemSubstitutionSimResult
+	var baseResult *itemSubstitutionSimResult
+
+	for {
+		var tempBase *itemSubstitutionSimResult
+		var errorOutcome *proto.ErrorOutcome
+		// TODO: we could theoretically make getRankedResults accept a channel of validCombos that stream in to it and launches sims as it gets them...
+		rankedResults, tempBase, errorOutcome = b.getRankedResults(signals, validCombos, iterations, progress)
+
+		if errorOutcome != nil {
+			return nil, nil, errorOutcome
+		}
+		// keep replacing the base result with more refined base until we don't have base in the ranked results anymore.
+		if tempBase != nil {
+			baseResult = tempBase
+		}
+
+		// If we aren't doing fast mode, or we have reached max accuracy, be done.
+		if !b.Request.BulkSettings.GetFastMode() || iterations >= maxIterations {
+			break
+		}
+
+		// Only keep racing combos that could still be the best one.
+		contenders, separated := partitionContenders(rankedResults)
+		separatedResults = append(separatedResults, separated...)
+
+		// Confidence intervals of low iteration sims overlap a lot, so keep halving large
+		// fields until only a couple of times the number of displayed results is left.
+		if limit := max(len(rankedResults)/2, maxResults*2); len(contenders) > limit {
+			separatedResults = append(separatedResults, contenders[limit:]...)
+			contenders = contenders[:limit]
+		}
+		rankedResults = contenders
+
+		// The best combo is statistically separated from everything else.
+		if len(contenders) <= 1 {
+			break
+		}
+
+		// Increase accuracy
+		iterations = min(iterations*2, maxIterations)
+		validCombos = validCombos[:len(contenders)]
+		for i, comb := range contenders {
+			validCombos[i] = singleBulkSim{
+				req: comb.Request,
+				cl:  comb.ChangeLog,
+				eq:  comb.Substitution,
+			}
+		}
+	}
+
+	// Combos that dropped out of the race keep the result from the round they were separated in.
+	if len(separatedResults) > 0 {
+		rankedResults = append(rankedResults, separatedResults...)
+		sort.Slice(rankedResults, func(i, j int) bool {
+			return rankedResults[i].Score() > rankedResults[j].Score()
+		})
+	}
+
+	return rankedResults, baseResult, nil
+}
+
 func (b *bulkSimRunner) getRankedResults(signals simsignals.Signals, validCombos []singleBulkSim, iterations int32, progress chan *proto.ProgressMetrics) ([]*itemSubstitutionSimResult, *itemSubstitutionSimResult, *proto.ErrorOutcome) {
-	concurrency := runtime.NumCPU() + 1
+	concurrency := b.Concurrency
 	if concurrency <= 0 {
-		concurrency = 2
+		concurrency = runtime.NumCPU() + 1
 	}
 
 	tickets := make(chan struct{}, concurrency)
@@ -492,6 +557,43 @@ func (r *itemSubstitutionSimResult) Score() float64 {
 	return r.Result.RaidMetrics.Dps.Avg
 }
 
+// Iterations returns the number of iterations the result was simmed with.
+func (r *itemSubstitutionSimResult) Iterations() int32 {
+	return r.Request.GetSimOptions().GetIterations()
+}
+
+// ConfidenceInterval returns the 95% confidence interval of the score.
+func (r *itemSubstitutionSimResult) ConfidenceInterval() (float64, float64) {
+	score := r.Score()
+	iterations := r.Iterations()
+	if iterations <= 0 || r.Result == nil || r.Result.Error != nil {
+		return score, score
+	}
+	halfWidth := bulkSimConfidenceZ * r.Result.RaidMetrics.Dps.Stdev / math.Sqrt(float64(iterations))
+	return score - halfWidth, score + halfWidth
+}
+
+// partitionContenders splits ranked results into the combos whose confidence interval overlaps
+// the best combo's interval, and the combos that are statistically worse than the best one.
+// The order of the ranked results is preserved within both partitions.
+func partitionContenders(rankedResults []*itemSubstitutionSimResult) ([]*itemSubstitutionSimResult, []*itemSubstitutionSimResult) {
+	if len(rankedResults) == 0 {
+		return nil, nil
+	}
+
+	bestLower, _ := rankedResults[0].ConfidenceInterval()
+
+	var contenders, separated []*itemSubstitutionSimResult
+	for _, r := range rankedResults {
+		if _, upper := r.ConfidenceInterval(); upper >= bestLower {
+			contenders = append(contenders, r)
+		} else {
+			separated = append(separated, r)
+		}
+	}
+	return contenders, separated
+}
+
 // equipmentSubstitution specifies all items to be used as replacements for the equipped gear.
 type equipmentSubstitution struct {
 	Items []*itemWithSlot
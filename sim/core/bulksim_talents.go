@@ -0,0 +1,295 @@
+package core
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	goproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Talent search for bulk sims: MoP only has 6 talent tiers with 3 choices each plus 3 major
+// glyphs, which is small enough to enumerate and rank with the bulk sim.
+
+const (
+	numTalentTiers             = 6
+	numMajorGlyphSlots         = 3
+	defaultMaxTalentSearchSize = 5000
+)
+
+// Adds the loadouts of the talent search to the talents to sim, returning the loadouts which the
+// APL cannot use.
+func applyTalentSearch(baseSettings *proto.RaidSimRequest, bulkSettings *proto.BulkSettings, player *proto.Player) ([]*proto.FilteredTalentLoadout, error) {
+	if bulkSettings.TalentSearch == nil {
+		return nil, nil
+	}
+
+	loadouts, err := enumerateTalentLoadouts(player, bulkSettings.TalentSearch)
+	if err != nil {
+		return nil, err
+	}
+
+	validLoadouts, filteredLoadouts := filterTalentLoadoutsByAPL(baseSettings, loadouts)
+	bulkSettings.SimTalents = true
+	bulkSettings.TalentsToSim = append(bulkSettings.TalentsToSim, validLoadouts...)
+	return filteredLoadouts, nil
+}
+
+// Returns every combination of talent choices and major glyphs allowed by the search settings,
+// excluding the player's current loadout.
+func enumerateTalentLoadouts(player *proto.Player, search *proto.TalentSearchSettings) ([]*proto.TalentLoadout, error) {
+	currentTalents := player.TalentsString
+	if len(currentTalents) != numTalentTiers {
+		return nil, fmt.Errorf("talent search needs a %d-digit talent string, got %q", numTalentTiers, currentTalents)
+	}
+
+	lockedTiers := make([]bool, numTalentTiers)
+	for _, tier := range search.LockedTiers {
+		if tier < 0 || tier >= numTalentTiers {
+			return nil, fmt.Errorf("invalid locked talent tier %d, must be between 0 and %d", tier, numTalentTiers-1)
+		}
+		lockedTiers[tier] = true
+	}
+
+	talentStrings := []string{""}
+	for tier := 0; tier < numTalentTiers; tier++ {
+		choices := []byte{'1', '2', '3'}
+		if lockedTiers[tier] {
+			choices = []byte{currentTalents[tier]}
+		}
+
+		var next []string
+		for _, prefix := range talentStrings {
+			for _, choice := range choices {
+				next = append(next, prefix+string(choice))
+			}
+		}
+		talentStrings = next
+	}
+
+	currentGlyphs := player.Glyphs
+	if currentGlyphs == nil {
+		currentGlyphs = &proto.Glyphs{}
+	}
+	currentMajors := []int32{currentGlyphs.Major1, currentGlyphs.Major2, currentGlyphs.Major3}
+
+	glyphSets := [][]int32{currentMajors}
+	if search.SearchGlyphs {
+		var err error
+		glyphSets, err = enumerateMajorGlyphSets(player.Class, search)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	maxLoadouts := search.MaxLoadouts
+	if maxLoadouts <= 0 {
+		maxLoadouts = defaultMaxTalentSearchSize
+	}
+	if numLoadouts := len(talentStrings) * len(glyphSets); numLoadouts > int(maxLoadouts) {
+		return nil, fmt.Errorf("talent search has %d loadouts, more than the maximum of %d. Lock talent tiers or glyphs to narrow it down", numLoadouts, maxLoadouts)
+	}
+
+	glyphEnum := majorGlyphEnum(player.Class)
+	var loadouts []*proto.TalentLoadout
+	for _, talents := range talentStrings {
+		for _, majors := range glyphSets {
+			if talents == currentTalents && sameGlyphSet(majors, currentMajors) {
+				continue
+			}
+
+			glyphs := goproto.Clone(currentGlyphs).(*proto.Glyphs)
+			glyphs.Major1, glyphs.Major2, glyphs.Major3 = majors[0], majors[1], majors[2]
+			loadouts = append(loadouts, &proto.TalentLoadout{
+				TalentsString: talents,
+				Glyphs:        glyphs,
+				Name:          talentLoadoutName(talents, majors, glyphEnum),
+			})
+		}
+	}
+	return loadouts, nil
+}
+
+// Returns all sets of 3 major glyphs which contain the locked glyphs, with empty slots as 0.
+func enumerateMajorGlyphSets(class proto.Class, search *proto.TalentSearchSettings) ([][]int32, error) {
+	var locked []int32
+	for _, glyph := range search.LockedMajorGlyphs {
+		if glyph != 0 && !slices.Contains(locked, glyph) {
+			locked = append(locked, glyph)
+		}
+	}
+	if len(locked) > numMajorGlyphSlots {
+		return nil, fmt.Errorf("cannot lock %d major glyphs, only %d slots exist", len(locked), numMajorGlyphSlots)
+	}
+
+	options := search.MajorGlyphOptions
+	if len(options) == 0 {
+		glyphEnum := majorGlyphEnum(class)
+		if glyphEnum == nil {
+			return nil, fmt.Errorf("no major glyphs known for class %s", class)
+		}
+		values := glyphEnum.Values()
+		for i := 0; i < values.Len(); i++ {
+			options = append(options, int32(values.Get(i).Number()))
+		}
+	}
+
+	var candidates []int32
+	for _, glyph := range options {
+		if glyph != 0 && !slices.Contains(locked, glyph) && !slices.Contains(candidates, glyph) {
+			candidates = append(candidates, glyph)
+		}
+	}
+	slices.Sort(candidates)
+
+	// Fill the free slots with every combination of candidates, leaving slots empty only if
+	// there are not enough candidates.
+	numFree := min(numMajorGlyphSlots-len(locked), len(candidates))
+	var glyphSets [][]int32
+	var choose func(start int, chosen []int32)
+	choose = func(start int, chosen []int32) {
+		if len(chosen) == numFree {
+			glyphSet := make([]int32, numMajorGlyphSlots)
+			copy(glyphSet, locked)
+			copy(glyphSet[len(locked):], chosen)
+			glyphSets = append(glyphSets, glyphSet)
+			return
+		}
+		for i := start; i < len(candidates); i++ {
+			choose(i+1, append(chosen, candidates[i]))
+		}
+	}
+	choose(0, make([]int32, 0, numFree))
+	return glyphSets, nil
+}
+
+// Returns the major glyph enum of the class, e.g. proto.WarriorMajorGlyph, or nil if none exists.
+func majorGlyphEnum(class proto.Class) protoreflect.EnumDescriptor {
+	fullName := proto.Class(0).Descriptor().FullName().Parent().Append(protoreflect.Name(strings.TrimPrefix(class.String(), "Class") + "MajorGlyph"))
+	enumType, err := protoregistry.GlobalTypes.FindEnumByName(fullName)
+	if err != nil {
+		return nil
+	}
+	return enumType.Descriptor()
+}
+
+func sameGlyphSet(a []int32, b []int32) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func talentLoadoutName(talents string, majors []int32, glyphEnum protoreflect.EnumDescriptor) string {
+	var glyphNames []string
+	for _, glyph := range majors {
+		if glyph == 0 {
+			continue
+		}
+		name := fmt.Sprintf("%d", glyph)
+		if glyphEnum != nil {
+			if value := glyphEnum.Values().ByNumber(protoreflect.EnumNumber(glyph)); value != nil {
+				name = string(value.Name())
+			}
+		}
+		glyphNames = append(glyphNames, name)
+	}
+	return fmt.Sprintf("%s [%s]", talents, strings.Join(glyphNames, ", "))
+}
+
+// Splits the loadouts into those the APL can use and those for which it reports validation
+// warnings that the player's current loadout does not have, e.g. casting a spell of an untaken
+// talent.
+func filterTalentLoadoutsByAPL(baseSettings *proto.RaidSimRequest, loadouts []*proto.TalentLoadout) ([]*proto.TalentLoadout, []*proto.FilteredTalentLoadout) {
+	baselineWarnings := aplValidationWarnings(baseSettings.Raid, baseSettings.Encounter)
+
+	// Loadouts are validated concurrently, each one builds a full environment.
+	newWarnings := make([][]string, len(loadouts))
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				newWarnings[i] = newAPLValidationWarnings(baseSettings, loadouts[i], baselineWarnings)
+			}
+		}()
+	}
+	for i := range loadouts {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var validLoadouts []*proto.TalentLoadout
+	var filteredLoadouts []*proto.FilteredTalentLoadout
+	for i, loadout := range loadouts {
+		if len(newWarnings[i]) > 0 {
+			filteredLoadouts = append(filteredLoadouts, &proto.FilteredTalentLoadout{
+				TalentLoadout: loadout,
+				Warnings:      newWarnings[i],
+			})
+		} else {
+			validLoadouts = append(validLoadouts, loadout)
+		}
+	}
+	return validLoadouts, filteredLoadouts
+}
+
+func newAPLValidationWarnings(baseSettings *proto.RaidSimRequest, loadout *proto.TalentLoadout, baselineWarnings []string) (newWarnings []string) {
+	defer func() {
+		if err := recover(); err != nil {
+			newWarnings = []string{fmt.Sprintf("%v", err)}
+		}
+	}()
+
+	raid := goproto.Clone(baseSettings.Raid).(*proto.Raid)
+	raid.Parties[0].Players[0].TalentsString = loadout.TalentsString
+	raid.Parties[0].Players[0].Glyphs = loadout.Glyphs
+	for _, warning := range aplValidationWarnings(raid, baseSettings.Encounter) {
+		if !slices.Contains(baselineWarnings, warning) {
+			newWarnings = append(newWarnings, warning)
+		}
+	}
+	return newWarnings
+}
+
+// Returns the APL validation warnings of the first player in the raid.
+func aplValidationWarnings(raid *proto.Raid, encounter *proto.Encounter) []string {
+	_, raidStats, _ := NewEnvironment(raid, encounter, false)
+	rotationStats := raidStats.Parties[0].Players[0].RotationStats
+
+	var warnings []string
+	addWarnings := func(validations []*proto.APLValidation) {
+		for _, validation := range validations {
+			if validation.LogLevel >= proto.LogLevel_Warning && !slices.Contains(warnings, validation.Validation) {
+				warnings = append(warnings, validation.Validation)
+			}
+		}
+	}
+	for _, actionStats := range rotationStats.GetPrepullActions() {
+		addWarnings(actionStats.Validations)
+	}
+	for _, actionStats := range rotationStats.GetPriorityList() {
+		addWarnings(actionStats.Validations)
+	}
+	for _, variableStats := range rotationStats.GetVariables() {
+		addWarnings(variableStats.Validations)
+	}
+	for _, listStats := range rotationStats.GetActionLists() {
+		for _, actionStats := range listStats.PriorityList {
+			addWarnings(actionStats.Validations)
+		}
+	}
+	for _, uuidValidations := range rotationStats.GetUuidValidations() {
+		addWarnings(uuidValidations.Validations)
+	}
+	return warnings
+}
@@ -1,12 +1,15 @@
 package core
 
 import (
+	"math"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/simsignals"
 	"google.golang.org/protobuf/encoding/protojson"
+	goproto "google.golang.org/protobuf/proto"
 )
 
 const (
@@ -474,3 +477,184 @@ func TestGenerateAllEquipmentSubstitutions(t *testing.T) {
 		})
 	}
 }
+
+func TestPartitionContenders(t *testing.T) {
+	newResult := func(avg float64, stdev float64, iterations int32) *itemSubstitutionSimResult {
+		return &itemSubstitutionSimResult{
+			Request: &proto.RaidSimRequest{SimOptions: &proto.SimOptions{Iterations: iterations}},
+			Result: &proto.RaidSimResult{
+				RaidMetrics: &proto.RaidMetrics{Dps: &proto.DistributionMetrics{Avg: avg, Stdev: stdev}},
+			},
+		}
+	}
+
+	// 1.96 * 500 / sqrt(100) = 98
+	best := newResult(10000, 500, 100)
+	overlapping := newResult(9850, 500, 100)
+	wideOverlapping := newResult(9000, 5000, 100)
+	separated := newResult(9700, 500, 100)
+
+	lower, upper := best.ConfidenceInterval()
+	if math.Abs(lower-9902) > 1e-9 || math.Abs(upper-10098) > 1e-9 {
+		t.Fatalf("ConfidenceInterval() = (%v, %v), want (9902, 10098)", lower, upper)
+	}
+
+	contenders, rest := partitionContenders([]*itemSubstitutionSimResult{best, overlapping, separated, wideOverlapping})
+	if len(contenders) != 3 || contenders[0] != best || contenders[1] != overlapping || contenders[2] != wideOverlapping {
+		t.Errorf("partitionContenders() returned unexpected contenders: %v", contenders)
+	}
+	if len(rest) != 1 || rest[0] != separated {
+		t.Errorf("partitionContenders() returned unexpected separated results: %v", rest)
+	}
+
+	contenders, rest = partitionContenders([]*itemSubstitutionSimResult{best, separated})
+	if len(contenders) != 1 || len(rest) != 1 {
+		t.Errorf("partitionContenders() should separate the best combo, got %d contenders and %d separated", len(contenders), len(rest))
+	}
+}
+
+func TestRaceCombos(t *testing.T) {
+	newCombos := func(dps []float64) ([]singleBulkSim, map[*proto.RaidSimRequest]float64) {
+		combos := make([]singleBulkSim, len(dps))
+		dpsByRequest := make(map[*proto.RaidSimRequest]float64, len(dps))
+		for i := range dps {
+			eq := &equipmentSubstitution{}
+			if i > 0 {
+				eq.Items = []*itemWithSlot{starshardEdge1}
+			}
+			combos[i] = singleBulkSim{
+				req: &proto.RaidSimRequest{SimOptions: &proto.SimOptions{}},
+				cl:  &raidSimRequestChangeLog{},
+				eq:  eq,
+			}
+			dpsByRequest[combos[i].req] = dps[i]
+		}
+		return combos, dpsByRequest
+	}
+
+	runRace := func(dps []float64, stdev float64, maxResults int) ([]*itemSubstitutionSimResult, *itemSubstitutionSimResult, map[int32]int) {
+		combos, dpsByRequest := newCombos(dps)
+
+		var mu sync.Mutex
+		simsByIterations := map[int32]int{}
+		bulk := &bulkSimRunner{
+			SingleRaidSimRunner: func(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool, signals simsignals.Signals) *proto.RaidSimResult {
+				mu.Lock()
+				simsByIterations[rsr.SimOptions.Iterations]++
+				mu.Unlock()
+
+				result := &proto.RaidSimResult{
+					RaidMetrics: &proto.RaidMetrics{Dps: &proto.DistributionMetrics{Avg: dpsByRequest[rsr], Stdev: stdev}},
+				}
+				progress <- &proto.ProgressMetrics{CompletedIterations: rsr.SimOptions.Iterations, FinalRaidResult: result}
+				return result
+			},
+			Request:     &proto.BulkSimRequest{BulkSettings: &proto.BulkSettings{FastMode: true}},
+			Concurrency: 4,
+		}
+
+		progress := make(chan *proto.ProgressMetrics)
+		go func() {
+			for range progress {
+			}
+		}()
+
+		ranked, base, errorOutcome := bulk.raceCombos(simsignals.CreateSignals(), combos, 100, 1600, maxResults, progress)
+		if errorOutcome != nil {
+			t.Fatalf("raceCombos() returned error: %v", errorOutcome.Message)
+		}
+		return ranked, base, simsByIterations
+	}
+
+	// Overlapping combos are still halved each round until twice the number of results is left.
+	dps := make([]float64, 100)
+	for i := range dps {
+		dps[i] = 10000 - float64(i)
+	}
+	ranked, base, simsByIterations := runRace(dps, 1e6, 10)
+	if diff := cmp.Diff(map[int32]int{100: 100, 200: 50, 400: 25, 800: 20, 1600: 20}, simsByIterations); diff != "" {
+		t.Errorf("raceCombos() simmed unexpected rounds (-want +got):\n%s", diff)
+	}
+	if len(ranked) != len(dps) {
+		t.Fatalf("raceCombos() returned %d results, want %d", len(ranked), len(dps))
+	}
+	for i, r := range ranked {
+		if r.Score() != dps[i] {
+			t.Errorf("raceCombos() result %d has score %v, want %v", i, r.Score(), dps[i])
+		}
+	}
+	if ranked[0].Iterations() != 1600 || ranked[len(ranked)-1].Iterations() != 100 {
+		t.Errorf("raceCombos() results have %d and %d iterations, want 1600 and 100", ranked[0].Iterations(), ranked[len(ranked)-1].Iterations())
+	}
+	if base == nil || base.Score() != dps[0] {
+		t.Errorf("raceCombos() did not return the base result")
+	}
+
+	// A combo that is clearly better than the rest ends the race after the first round.
+	_, _, simsByIterations = runRace([]float64{10000, 5000, 4000, 3000}, 100, 10)
+	if diff := cmp.Diff(map[int32]int{100: 4}, simsByIterations); diff != "" {
+		t.Errorf("raceCombos() simmed unexpected rounds (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnumerateTalentLoadouts(t *testing.T) {
+	player := &proto.Player{
+		Class:         proto.Class_ClassWarrior,
+		TalentsString: "213211",
+		Glyphs: &proto.Glyphs{
+			Major1: int32(proto.WarriorMajorGlyph_GlyphOfUnendingRage),
+			Minor1: int32(proto.WarriorMinorGlyph_GlyphOfBloodyHealing),
+		},
+	}
+
+	loadouts, err := enumerateTalentLoadouts(player, &proto.TalentSearchSettings{LockedTiers: []int32{1, 3}})
+	if err != nil {
+		t.Fatalf("enumerateTalentLoadouts() failed: %v", err)
+	}
+	// 3^4 talent combinations, excluding the current loadout.
+	if len(loadouts) != 80 {
+		t.Fatalf("Expected 80 loadouts, got %d", len(loadouts))
+	}
+	for _, loadout := range loadouts {
+		if loadout.TalentsString[1] != '1' || loadout.TalentsString[3] != '2' {
+			t.Fatalf("Loadout %s does not keep the locked tiers", loadout.TalentsString)
+		}
+		if !goproto.Equal(loadout.Glyphs, player.Glyphs) {
+			t.Fatalf("Loadout %s does not keep the current glyphs", loadout.TalentsString)
+		}
+	}
+
+	loadouts, err = enumerateTalentLoadouts(player, &proto.TalentSearchSettings{
+		LockedTiers:  []int32{0, 1, 2, 3, 4, 5},
+		SearchGlyphs: true,
+		MajorGlyphOptions: []int32{
+			int32(proto.WarriorMajorGlyph_GlyphOfLongCharge),
+			int32(proto.WarriorMajorGlyph_GlyphOfUnendingRage),
+			int32(proto.WarriorMajorGlyph_GlyphOfEnragedSpeed),
+			int32(proto.WarriorMajorGlyph_GlyphOfHinderingStrikes),
+		},
+		LockedMajorGlyphs: []int32{int32(proto.WarriorMajorGlyph_GlyphOfUnendingRage)},
+	})
+	if err != nil {
+		t.Fatalf("enumerateTalentLoadouts() failed: %v", err)
+	}
+	// Choose 2 of the 3 unlocked candidates.
+	if len(loadouts) != 3 {
+		t.Fatalf("Expected 3 loadouts, got %d", len(loadouts))
+	}
+	for _, loadout := range loadouts {
+		if loadout.Glyphs.Major1 != int32(proto.WarriorMajorGlyph_GlyphOfUnendingRage) || loadout.Glyphs.Major2 == 0 || loadout.Glyphs.Major3 == 0 {
+			t.Fatalf("Loadout %s has unexpected glyphs %v", loadout.Name, loadout.Glyphs)
+		}
+		if loadout.Glyphs.Minor1 != player.Glyphs.Minor1 {
+			t.Fatalf("Loadout %s does not keep the minor glyphs", loadout.Name)
+		}
+	}
+	if loadouts[0].Name != "213211 [GlyphOfUnendingRage, GlyphOfLongCharge, GlyphOfEnragedSpeed]" {
+		t.Fatalf("Unexpected loadout name %q", loadouts[0].Name)
+	}
+
+	if _, err := enumerateTalentLoadouts(player, &proto.TalentSearchSettings{SearchGlyphs: true, MaxLoadouts: 1000}); err == nil {
+		t.Fatalf("Expected talent search over all warrior glyphs to exceed 1000 loadouts")
+	}
+}
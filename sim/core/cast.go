@@ -176,6 +176,10 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 					spell.ActionID, max(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 			}
 
+			if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+				sim.CombatLog.CastStart(sim, spell, target)
+			}
+
 			spell.Unit.Hardcast = Hardcast{
 				Expires:  sim.CurrentTime + spell.CurCast.CastTime,
 				ActionID: spell.ActionID,
@@ -183,6 +187,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 					if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
 						spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 					}
+					if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+						sim.CombatLog.CastFinish(sim, spell, target)
+					}
 
 					if spell.Cost != nil {
 						spell.Cost.SpendCost(sim, spell)
@@ -219,6 +226,10 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 				spell.ActionID, max(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+			sim.CombatLog.CastStart(sim, spell, target)
+			sim.CombatLog.CastFinish(sim, spell, target)
+		}
 
 		if spell.Cost != nil {
 			spell.Cost.SpendCost(sim, spell)
@@ -257,6 +268,10 @@ func (spell *Spell) triggerCooldown(sim *Simulation) {
 
 	if cd > 0 {
 		spell.CD.Set(sim.CurrentTime + cd)
+
+		if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+			sim.CombatLog.CooldownUsed(sim, spell, cd)
+		}
 	}
 }
 
@@ -299,6 +314,10 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 				spell.ActionID, 0.0, "0s", "0s")
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+			sim.CombatLog.CastStart(sim, spell, target)
+			sim.CombatLog.CastFinish(sim, spell, target)
+		}
 
 		if spell.MaxCharges > 0 {
 			spell.ConsumeCharge(sim)
@@ -329,6 +348,10 @@ func (spell *Spell) makeCastFuncAutosOrProcs() CastSuccessFunc {
 				spell.ActionID, 0.0, "0s", "0s")
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+			sim.CombatLog.CastStart(sim, spell, target)
+			sim.CombatLog.CastFinish(sim, spell, target)
+		}
 
 		spell.applyEffects(sim, target)
 
@@ -349,6 +372,10 @@ func (spell *Spell) Proc(sim *Simulation, target *Unit) {
 			spell.ActionID, 0.0, "0s", "0s")
 		spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 	}
+	if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+		sim.CombatLog.CastStart(sim, spell, target)
+		sim.CombatLog.CastFinish(sim, spell, target)
+	}
 
 	spell.applyEffects(sim, target)
 
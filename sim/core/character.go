@@ -416,6 +416,7 @@ func (character *Character) initialize(agent Agent) {
 			if sim.Options.Interactive {
 				if character.GCD.IsReady(sim) {
 					sim.NeedsInput = true
+					sim.InputCharacter = character
 				}
 				return
 			}
@@ -457,14 +458,19 @@ func (character *Character) FillPlayerStats(playerStats *proto.PlayerStats) {
 
 	playerStats.Metadata = character.GetMetadata()
 	for _, pet := range character.Pets {
-		playerStats.Pets = append(playerStats.Pets, &proto.PetStats{
+		petStats := &proto.PetStats{
 			Metadata: pet.GetMetadata(),
-		})
+		}
+		if pet.Rotation != nil && !pet.Rotation.isCustomRotation {
+			petStats.RotationStats = pet.Rotation.getStats()
+		}
+		playerStats.Pets = append(playerStats.Pets, petStats)
 	}
 
 	if character.Rotation != nil {
 		playerStats.RotationStats = character.Rotation.getStats()
 	}
+	playerStats.UnsimulatedEffects = character.Equipment.GetUnsimulatedEffects()
 }
 
 func (character *Character) reset(sim *Simulation, agent Agent) {
@@ -676,6 +682,10 @@ func (character *Character) GetMetricsProto() *proto.UnitMetrics {
 	metrics.Name = character.Name
 	metrics.UnitIndex = character.UnitIndex
 	metrics.Auras = character.auraTracker.GetMetricsProto()
+	metrics.UnsimulatedEffects = character.Equipment.GetUnsimulatedEffects()
+	if character.Rotation != nil && !character.Rotation.isCustomRotation {
+		metrics.RotationStats = character.Rotation.getProfileStats()
+	}
 
 	metrics.Pets = make([]*proto.UnitMetrics, len(character.Pets))
 	for i, pet := range character.Pets {
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// CombatLog collects typed combat events, as an alternative to the free-text
+// debug logs. It is only attached to the sim for the first iteration, see
+// SimOptions.CombatLog.
+type CombatLog struct {
+	events []*proto.CombatLogEvent
+}
+
+func (cl *CombatLog) Events() []*proto.CombatLogEvent {
+	return cl.events
+}
+
+func (cl *CombatLog) newEvent(sim *Simulation, eventType proto.CombatLogEventType, source *Unit, actionID ActionID) *proto.CombatLogEvent {
+	event := &proto.CombatLogEvent{
+		Timestamp: sim.CurrentTime.Seconds(),
+		Type:      eventType,
+		Source:    source.Label,
+		Id:        actionID.ToProto(),
+	}
+	cl.events = append(cl.events, event)
+	return event
+}
+
+func (cl *CombatLog) CastStart(sim *Simulation, spell *Spell, target *Unit) {
+	event := cl.newEvent(sim, proto.CombatLogEventType_CombatLogEventCastStart, spell.Unit, spell.ActionID)
+	event.SpellSchool = int32(spell.SpellSchool)
+	event.Duration = spell.CurCast.CastTime.Seconds()
+	if target != nil {
+		event.Target = target.Label
+	}
+}
+
+func (cl *CombatLog) CastFinish(sim *Simulation, spell *Spell, target *Unit) {
+	event := cl.newEvent(sim, proto.CombatLogEventType_CombatLogEventCastFinish, spell.Unit, spell.ActionID)
+	event.SpellSchool = int32(spell.SpellSchool)
+	if target != nil {
+		event.Target = target.Label
+	}
+}
+
+func (cl *CombatLog) Damage(sim *Simulation, spell *Spell, result *SpellResult, isPeriodic bool) {
+	event := cl.newEvent(sim, proto.CombatLogEventType_CombatLogEventDamage, spell.Unit, spell.ActionID)
+	event.Target = result.Target.Label
+	event.SpellSchool = int32(spell.SpellSchool)
+	event.Amount = result.Damage
+	event.Outcome = result.Outcome.String()
+	event.Periodic = isPeriodic
+	event.Threat = result.Threat
+}
+
+func (cl *CombatLog) Heal(sim *Simulation, spell *Spell, result *SpellResult, isPeriodic bool) {
+	event := cl.newEvent(sim, proto.CombatLogEventType_CombatLogEventHeal, spell.Unit, spell.ActionID)
+	event.Target = result.Target.Label
+	event.SpellSchool = int32(spell.SpellSchool)
+	event.Amount = result.Damage
+	event.Outcome = result.Outcome.String()
+	event.Periodic = isPeriodic
+	event.Threat = result.Threat
+}
+
+func (cl *CombatLog) AuraGained(sim *Simulation, aura *Aura) {
+	event := cl.newEvent(sim, proto.CombatLogEventType_CombatLogEventAuraGained, aura.Unit, aura.ActionID)
+	event.ValueAfter = float64(aura.stacks)
+}
+
+func (cl *CombatLog) AuraFaded(sim *Simulation, aura *Aura) {
+	event := cl.newEvent(sim, proto.CombatLogEventType_CombatLogEventAuraFaded, aura.Unit, aura.ActionID)
+	event.ValueBefore = float64(aura.stacks)
+}
+
+func (cl *CombatLog) AuraStacks(sim *Simulation, aura *Aura, oldStacks int32, newStacks int32) {
+	event := cl.newEvent(sim, proto.CombatLogEventType_CombatLogEventAuraStacks, aura.Unit, aura.ActionID)
+	event.ValueBefore = float64(oldStacks)
+	event.ValueAfter = float64(newStacks)
+}
+
+// ResourceChange records a gain or spend tracked by the given metrics. Amount is
+// the requested change (negative for spends), while the before/after values
+// reflect what was actually applied after caps.
+func (cl *CombatLog) ResourceChange(sim *Simulation, unit *Unit, metrics *ResourceMetrics, amount float64, before float64, after float64) {
+	event := cl.newEvent(sim, proto.CombatLogEventType_CombatLogEventResourceChange, unit, metrics.ActionID)
+	event.ResourceType = metrics.Type
+	event.Amount = amount
+	event.ValueBefore = before
+	event.ValueAfter = after
+}
+
+func (cl *CombatLog) CooldownUsed(sim *Simulation, spell *Spell, cd time.Duration) {
+	event := cl.newEvent(sim, proto.CombatLogEventType_CombatLogEventCooldownUsed, spell.Unit, spell.ActionID)
+	event.Duration = cd.Seconds()
+}
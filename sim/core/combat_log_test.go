@@ -0,0 +1,119 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func TestCombatLogAuraEvents(t *testing.T) {
+	sim := &Simulation{CombatLog: &CombatLog{}}
+	target := Unit{
+		Type:         EnemyUnit,
+		Label:        "Target 1",
+		Level:        93,
+		auraTracker:  newAuraTracker(),
+		initialStats: stats.Stats{stats.Armor: 24835},
+		PseudoStats:  stats.NewPseudoStats(),
+		Metrics:      NewUnitMetrics(),
+	}
+	target.stats = target.initialStats
+
+	aura := WeakenedArmorAura(&target)
+	aura.Activate(sim)
+	aura.SetStacks(sim, 2)
+	sim.CurrentTime = time.Second
+	aura.Deactivate(sim)
+
+	expected := []proto.CombatLogEventType{
+		proto.CombatLogEventType_CombatLogEventAuraGained,
+		proto.CombatLogEventType_CombatLogEventAuraStacks,
+		proto.CombatLogEventType_CombatLogEventAuraFaded,
+	}
+	events := sim.CombatLog.Events()
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d combat log events but found %d", len(expected), len(events))
+	}
+	for i, event := range events {
+		if event.Type != expected[i] {
+			t.Fatalf("Event %d: expected type %s but found %s", i, expected[i], event.Type)
+		}
+		if event.Source != "Target 1" {
+			t.Fatalf("Event %d: unexpected source %q", i, event.Source)
+		}
+	}
+	if events[1].ValueBefore != 0 || events[1].ValueAfter != 2 {
+		t.Fatalf("Unexpected stack change %0.0f --> %0.0f", events[1].ValueBefore, events[1].ValueAfter)
+	}
+	if events[2].Timestamp != 1 || events[2].ValueBefore != 2 {
+		t.Fatalf("Unexpected fade event at %f with %0.0f stacks", events[2].Timestamp, events[2].ValueBefore)
+	}
+}
+
+func TestCombatLogCastDamageAndResourceEvents(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.CombatLog = &CombatLog{}
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.AllTargetUnits[0]
+
+	fa.Spell.Cast(sim, target)
+	fa.Dot.TickOnce(sim)
+
+	// The fake caster has no gear, so give it a health pool to spend from.
+	fa.AddStatsDynamic(sim, stats.Stats{stats.Health: 10000})
+	fa.currentHealth = fa.MaxHealth()
+	healthMetrics := fa.NewHealthMetrics(ActionID{SpellID: 43})
+	fa.RemoveHealth(sim, 100)
+	fa.GainHealth(sim, 150, healthMetrics)
+
+	var events []*proto.CombatLogEvent
+	for _, event := range sim.CombatLog.Events() {
+		// The dot application is covered by TestCombatLogAuraEvents.
+		if event.Type != proto.CombatLogEventType_CombatLogEventAuraGained {
+			events = append(events, event)
+		}
+	}
+
+	expected := []proto.CombatLogEventType{
+		proto.CombatLogEventType_CombatLogEventCastStart,
+		proto.CombatLogEventType_CombatLogEventCastFinish,
+		proto.CombatLogEventType_CombatLogEventDamage,
+		proto.CombatLogEventType_CombatLogEventDamage,
+		proto.CombatLogEventType_CombatLogEventResourceChange,
+		proto.CombatLogEventType_CombatLogEventResourceChange,
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d combat log events but found %d", len(expected), len(events))
+	}
+	for i, event := range events {
+		if event.Type != expected[i] {
+			t.Fatalf("Event %d: expected type %s but found %s", i, expected[i], event.Type)
+		}
+		if event.Source != fa.Label {
+			t.Fatalf("Event %d: unexpected source %q", i, event.Source)
+		}
+	}
+
+	for i, event := range events[:4] {
+		if event.Target != target.Label || event.Id.GetSpellId() != 42 || event.SpellSchool != int32(SpellSchoolShadow) {
+			t.Fatalf("Event %d: unexpected target %q, id %s or school %d", i, event.Target, event.Id, event.SpellSchool)
+		}
+	}
+	if events[2].Periodic || events[2].Outcome == "" {
+		t.Fatalf("Expected a direct hit with an outcome but found periodic=%t, outcome=%q", events[2].Periodic, events[2].Outcome)
+	}
+	if !events[3].Periodic || events[3].Amount <= 0 {
+		t.Fatalf("Expected a damaging dot tick but found periodic=%t, amount=%0.3f", events[3].Periodic, events[3].Amount)
+	}
+
+	maxHealth := fa.MaxHealth()
+	if spent := events[4]; spent.ResourceType != proto.ResourceType_ResourceTypeHealth || spent.Amount != -100 || spent.ValueBefore != maxHealth || spent.ValueAfter != maxHealth-100 {
+		t.Fatalf("Unexpected health spend: %s %0.0f (%0.0f --> %0.0f)", spent.ResourceType, spent.Amount, spent.ValueBefore, spent.ValueAfter)
+	}
+	// The requested gain is reported in full, while the values reflect the cap.
+	if gained := events[5]; gained.Id.GetSpellId() != 43 || gained.Amount != 150 || gained.ValueBefore != maxHealth-100 || gained.ValueAfter != maxHealth {
+		t.Fatalf("Unexpected health gain: %0.0f (%0.0f --> %0.0f)", gained.Amount, gained.ValueBefore, gained.ValueAfter)
+	}
+}
@@ -264,6 +264,7 @@ type Gem struct {
 	Stats                   stats.Stats
 	Color                   proto.GemColor
 	DisabledInChallengeMode bool
+	HasEffect               bool
 }
 
 func GemFromProto(pData *proto.SimGem) Gem {
@@ -273,6 +274,7 @@ func GemFromProto(pData *proto.SimGem) Gem {
 		Stats:                   stats.FromProtoArray(pData.Stats),
 		Color:                   pData.Color,
 		DisabledInChallengeMode: pData.DisabledInChallengeMode,
+		HasEffect:               pData.HasEffect,
 	}
 }
 
@@ -550,14 +552,19 @@ func NewItem(itemSpec ItemSpec) Item {
 
 func validateReforging(item *Item, reforging ReforgeStat) bool {
 	// Validate that the item can reforge these to stats
+	reforgeableStats := reforgeableItemStats(item)
+	return (reforgeableStats[reforging.FromStat] > 0) && (reforgeableStats[reforging.ToStat] == 0)
+}
+
+// Returns the stats of the item that can be reforged.
+func reforgeableItemStats(item *Item) stats.Stats {
 	reforgeableStats := stats.Stats{}
 	if item.RandomSuffix.ID != 0 {
 		reforgeableStats = reforgeableStats.Add(item.RandomSuffix.Stats.Multiply(float64(item.RandPropPoints) / 10000.).Floor())
 	} else {
 		reforgeableStats = reforgeableStats.Add(item.Stats)
 	}
-
-	return (reforgeableStats[reforging.FromStat] > 0) && (reforgeableStats[reforging.ToStat] == 0)
+	return reforgeableStats
 }
 
 func NewEquipmentSet(equipSpec EquipmentSpec) Equipment {
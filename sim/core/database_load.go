@@ -68,6 +68,7 @@ func init() {
 			Color:                   gem.Color,
 			Stats:                   gem.Stats,
 			DisabledInChallengeMode: gem.DisabledInChallengeMode,
+			HasEffect:               gem.HasEffect,
 		}
 	}
 
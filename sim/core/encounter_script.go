@@ -0,0 +1,385 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// How often health and add death phase triggers are checked.
+const encounterScriptTriggerInterval = time.Millisecond * 500
+
+// Generic TargetAI which runs an encounter script, so boss mechanics can be
+// shared as encounter settings instead of being written in Go.
+type encounterScriptAI struct {
+	Target *Target
+
+	script *proto.EncounterScript
+	phases []*encounterScriptPhase
+
+	// Per-iteration state.
+	phaseIdx     int
+	phaseActions []*PendingAction
+	addsSeen     []bool
+}
+
+type encounterScriptPhase struct {
+	config  *proto.EncounterPhase
+	casts   []*encounterScriptCast
+	effects []func(sim *Simulation)
+}
+
+type encounterScriptCast struct {
+	config *proto.EncounterCast
+	caster *Target
+	spell  *Spell
+}
+
+// Returns an error for script settings which could never take effect, instead of silently
+// skipping them while simming.
+func validateEncounterScript(script *proto.EncounterScript, numTargets int) error {
+	isAddIndex := func(idx int32) bool {
+		return idx > 0 && int(idx) < numTargets
+	}
+
+	for i, phase := range script.Phases {
+		if i > 0 {
+			switch trigger := phase.Trigger.GetTrigger().(type) {
+			case nil:
+				return fmt.Errorf("encounter script phase %d (%s) has no trigger and would never start", i+1, phase.Name)
+			case *proto.EncounterTrigger_AddDeath:
+				if !isAddIndex(trigger.AddDeath) {
+					return fmt.Errorf("encounter script phase %d (%s) is triggered by the death of target %d, which is not an add", i+1, phase.Name, trigger.AddDeath)
+				}
+			}
+		}
+
+		for _, cast := range phase.Casts {
+			if cast.CasterIndex < 0 || int(cast.CasterIndex) >= numTargets {
+				return fmt.Errorf("encounter script phase %d (%s): cast %q has caster index %d, but there are only %d targets", i+1, phase.Name, cast.Name, cast.CasterIndex, numTargets)
+			}
+		}
+		for _, spawn := range phase.AddSpawns {
+			if !isAddIndex(spawn.TargetIndex) {
+				return fmt.Errorf("encounter script phase %d (%s): add spawn has target index %d, which is not an add", i+1, phase.Name, spawn.TargetIndex)
+			}
+		}
+		for _, effect := range phase.RaidEffects {
+			if effect.DurationSeconds <= 0 {
+				return fmt.Errorf("encounter script phase %d (%s): raid effect %q must have a positive duration, got %0.2f", i+1, phase.Name, effect.Name, effect.DurationSeconds)
+			}
+		}
+	}
+	return nil
+}
+
+func newEncounterScriptAI(script *proto.EncounterScript) TargetAI {
+	return &encounterScriptAI{
+		script: script,
+	}
+}
+
+func (ai *encounterScriptAI) Initialize(target *Target, _ *proto.Target) {
+	ai.Target = target
+
+	tag := int32(0)
+	nextTag := func() int32 {
+		tag++
+		return tag
+	}
+
+	ai.phases = make([]*encounterScriptPhase, len(ai.script.Phases))
+	for i, phaseConfig := range ai.script.Phases {
+		phase := &encounterScriptPhase{
+			config: phaseConfig,
+		}
+		ai.phases[i] = phase
+
+		for _, castConfig := range phaseConfig.Casts {
+			phase.casts = append(phase.casts, ai.registerCast(nextTag(), castConfig))
+		}
+
+		for _, effectConfig := range phaseConfig.RaidEffects {
+			phase.effects = append(phase.effects, ai.registerRaidEffect(nextTag(), effectConfig))
+		}
+	}
+}
+
+func (ai *encounterScriptAI) registerCast(tag int32, config *proto.EncounterCast) *encounterScriptCast {
+	caster := ai.Target.Env.Encounter.AllTargets[config.CasterIndex]
+	players := ai.Target.Env.Raid.AllPlayerUnits
+	numRandomTargets := min(max(int(config.NumTargets), 1), len(players))
+	randomTargets := make([]*Unit, len(players))
+
+	spell := caster.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{OtherID: proto.OtherAction_OtherActionEncounterScript, Tag: tag},
+		SpellSchool:      SpellSchoolFromProto(config.SpellSchool),
+		ProcMask:         ProcMaskSpellDamage,
+		Flags:            SpellFlagIgnoreAttackerModifiers | SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: CastConfig{
+			DefaultCast: Cast{
+				CastTime: DurationFromSeconds(config.CastTimeSeconds),
+			},
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			if config.Damage <= 0 {
+				return
+			}
+			rollDamage := func() float64 {
+				return config.Damage * (1 + config.DamageVariation*(2*sim.RandomFloat("Encounter Script Damage")-1))
+			}
+
+			switch config.TargetType {
+			case proto.EncounterCastTargetType_EncounterCastTargetTypeCurrentTarget:
+				spell.CalcAndDealDamage(sim, target, rollDamage(), spell.OutcomeAlwaysHit)
+			case proto.EncounterCastTargetType_EncounterCastTargetTypeRandomPlayers:
+				// Partial Fisher-Yates shuffle, so no player is hit twice by the same cast.
+				copy(randomTargets, players)
+				for i := 0; i < numRandomTargets; i++ {
+					j := i + int(sim.RandomFloat("Encounter Script Target")*float64(len(randomTargets)-i))
+					randomTargets[i], randomTargets[j] = randomTargets[j], randomTargets[i]
+					spell.CalcAndDealDamage(sim, randomTargets[i], rollDamage(), spell.OutcomeAlwaysHit)
+				}
+			case proto.EncounterCastTargetType_EncounterCastTargetTypeAllPlayers:
+				for _, player := range players {
+					spell.CalcAndDealDamage(sim, player, rollDamage(), spell.OutcomeAlwaysHit)
+				}
+			}
+		},
+	})
+
+	return &encounterScriptCast{
+		config: config,
+		caster: caster,
+		spell:  spell,
+	}
+}
+
+func (ai *encounterScriptAI) registerRaidEffect(tag int32, config *proto.EncounterRaidEffect) func(sim *Simulation) {
+	env := ai.Target.Env
+	actionID := ActionID{OtherID: proto.OtherAction_OtherActionEncounterScript, Tag: tag}
+	duration := DurationFromSeconds(config.DurationSeconds)
+	label := config.Name
+	if label == "" {
+		label = "Encounter Script Effect"
+	}
+
+	switch config.Type {
+	case proto.EncounterRaidEffectType_EncounterRaidEffectTypeForcedMovement:
+		return func(sim *Simulation) {
+			if sim.Log != nil {
+				ai.Target.Log(sim, "%s forces the raid to move for %s", label, duration)
+			}
+			for _, player := range env.Raid.AllPlayerUnits {
+				player.MoveDuration(duration, sim)
+			}
+		}
+	case proto.EncounterRaidEffectType_EncounterRaidEffectTypeDamageTaken:
+		multiplier := config.DamageTakenMultiplier
+		if multiplier <= 0 {
+			multiplier = 1
+		}
+
+		// Player auras are registered once every unit has been initialized.
+		auras := make([]*Aura, len(env.Raid.AllPlayerUnits))
+		env.RegisterPreFinalizeEffect(func() {
+			for i, player := range env.Raid.AllPlayerUnits {
+				auras[i] = player.RegisterAura(Aura{
+					Label:    fmt.Sprintf("%s %d", label, tag),
+					ActionID: actionID,
+					Duration: duration,
+
+					OnGain: func(aura *Aura, sim *Simulation) {
+						aura.Unit.PseudoStats.DamageTakenMultiplier *= multiplier
+					},
+					OnExpire: func(aura *Aura, sim *Simulation) {
+						aura.Unit.PseudoStats.DamageTakenMultiplier /= multiplier
+					},
+				})
+			}
+		})
+
+		return func(sim *Simulation) {
+			for _, aura := range auras {
+				aura.Activate(sim)
+			}
+		}
+	}
+
+	return func(_ *Simulation) {}
+}
+
+func (ai *encounterScriptAI) Reset(sim *Simulation) {
+	ai.phaseIdx = -1
+	ai.phaseActions = ai.phaseActions[:0]
+
+	if len(ai.addsSeen) == 0 {
+		ai.addsSeen = make([]bool, len(ai.Target.Env.Encounter.AllTargets))
+	}
+	for i, target := range ai.Target.Env.Encounter.AllTargets {
+		ai.addsSeen[i] = !target.disabledAtStart
+	}
+
+	if len(ai.phases) > 0 {
+		ai.startPhase(sim, 0)
+	}
+}
+
+// Casts, spawns and raid effects are all driven by pending actions.
+func (ai *encounterScriptAI) ExecuteCustomRotation(_ *Simulation) {}
+
+// Schedules an action for the current phase, which is cancelled when the next phase starts.
+func (ai *encounterScriptAI) schedule(sim *Simulation, delay time.Duration, interval time.Duration, onAction func(sim *Simulation)) {
+	pa := &PendingAction{
+		NextActionAt: sim.CurrentTime + max(delay, 0),
+	}
+
+	pa.OnAction = func(sim *Simulation) {
+		onAction(sim)
+
+		// Re-adding a cancelled action is a no-op, so phase changes from within onAction are safe.
+		if interval > 0 {
+			pa.NextActionAt = sim.CurrentTime + interval
+			sim.AddPendingAction(pa)
+		}
+	}
+
+	sim.AddPendingAction(pa)
+	ai.phaseActions = append(ai.phaseActions, pa)
+}
+
+func (ai *encounterScriptAI) startPhase(sim *Simulation, phaseIdx int) {
+	for _, pa := range ai.phaseActions {
+		pa.Cancel(sim)
+	}
+	ai.phaseActions = ai.phaseActions[:0]
+
+	ai.phaseIdx = phaseIdx
+	phase := ai.phases[phaseIdx]
+	if sim.Log != nil {
+		ai.Target.Log(sim, "Encounter script phase %d (%s) started", phaseIdx+1, phase.config.Name)
+	}
+
+	for _, cast := range phase.casts {
+		ai.schedule(sim, DurationFromSeconds(cast.config.DelaySeconds), DurationFromSeconds(cast.config.IntervalSeconds), func(sim *Simulation) {
+			ai.cast(sim, cast)
+		})
+	}
+
+	for _, spawn := range phase.config.AddSpawns {
+		ai.schedule(sim, DurationFromSeconds(spawn.DelaySeconds), 0, func(sim *Simulation) {
+			ai.spawnAdd(sim, spawn)
+		})
+	}
+
+	for i, effect := range phase.effects {
+		effectConfig := phase.config.RaidEffects[i]
+		ai.schedule(sim, DurationFromSeconds(effectConfig.DelaySeconds), DurationFromSeconds(effectConfig.IntervalSeconds), effect)
+	}
+
+	ai.scheduleNextPhase(sim)
+}
+
+func (ai *encounterScriptAI) scheduleNextPhase(sim *Simulation) {
+	nextIdx := ai.phaseIdx + 1
+	if nextIdx >= len(ai.phases) {
+		return
+	}
+
+	startNextPhase := func(sim *Simulation) {
+		ai.startPhase(sim, nextIdx)
+	}
+
+	trigger := ai.phases[nextIdx].config.Trigger
+	switch trigger.GetTrigger().(type) {
+	case *proto.EncounterTrigger_TimeSeconds:
+		ai.schedule(sim, DurationFromSeconds(trigger.GetTimeSeconds())-sim.CurrentTime, 0, startNextPhase)
+	case *proto.EncounterTrigger_BossHealthPercent, *proto.EncounterTrigger_AddDeath:
+		ai.schedule(sim, 0, encounterScriptTriggerInterval, func(sim *Simulation) {
+			if ai.isTriggered(sim, trigger) {
+				startNextPhase(sim)
+			}
+		})
+	}
+}
+
+func (ai *encounterScriptAI) isTriggered(sim *Simulation, trigger *proto.EncounterTrigger) bool {
+	switch trigger.GetTrigger().(type) {
+	case *proto.EncounterTrigger_BossHealthPercent:
+		return sim.GetRemainingDurationPercent()*100 <= trigger.GetBossHealthPercent()
+	case *proto.EncounterTrigger_AddDeath:
+		addIdx := int(trigger.GetAddDeath())
+		// Adds count as dead once they have been active and are disabled again.
+		add := ai.Target.Env.Encounter.AllTargets[addIdx]
+		if add.IsEnabled() {
+			ai.addsSeen[addIdx] = true
+			return false
+		}
+		return ai.addsSeen[addIdx]
+	}
+	return false
+}
+
+func (ai *encounterScriptAI) spawnAdd(sim *Simulation, spawn *proto.EncounterAddSpawn) {
+	add := ai.Target.Env.Encounter.AllTargets[spawn.TargetIndex]
+	if add.IsEnabled() {
+		return
+	}
+
+	add.Enable(sim)
+	ai.addsSeen[spawn.TargetIndex] = true
+	if sim.Log != nil {
+		ai.Target.Log(sim, "Encounter script spawned %s", add.Label)
+	}
+
+	if spawn.LifetimeSeconds <= 0 {
+		return
+	}
+
+	// Add deaths outlive the phase which spawned the add.
+	sim.AddPendingAction(NewDelayedAction(DelayedActionOptions{
+		DoAt: sim.CurrentTime + DurationFromSeconds(spawn.LifetimeSeconds),
+		OnAction: func(sim *Simulation) {
+			// The only active target cannot be disabled.
+			if !add.IsEnabled() || len(sim.Encounter.ActiveTargets) <= 1 {
+				return
+			}
+
+			add.Disable(sim, true)
+			if sim.Log != nil {
+				ai.Target.Log(sim, "Encounter script add %s died", add.Label)
+			}
+
+			if nextIdx := ai.phaseIdx + 1; nextIdx < len(ai.phases) && ai.isTriggered(sim, ai.phases[nextIdx].config.Trigger) {
+				ai.startPhase(sim, nextIdx)
+			}
+		},
+	}))
+}
+
+func (ai *encounterScriptAI) cast(sim *Simulation, cast *encounterScriptCast) {
+	caster := cast.caster
+	if !caster.IsEnabled() {
+		return
+	}
+
+	// Casts which overlap another cast of the same caster are queued behind it.
+	if caster.Hardcast.Expires > sim.CurrentTime {
+		ai.schedule(sim, caster.Hardcast.Expires-sim.CurrentTime, 0, func(sim *Simulation) {
+			ai.cast(sim, cast)
+		})
+		return
+	}
+
+	target := caster.CurrentTarget
+	if target == nil {
+		target = sim.Raid.AllPlayerUnits[0]
+	}
+
+	cast.spell.Cast(sim, target)
+}
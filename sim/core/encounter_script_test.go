@@ -0,0 +1,143 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func TestEncounterScriptPhases(t *testing.T) {
+	rsr := raidDamageTestRequest()
+	rsr.Encounter.RaidDamage = nil
+	rsr.Encounter.Targets = []*proto.Target{{}, {DisabledAtStart: true}}
+	rsr.Encounter.Script = &proto.EncounterScript{
+		Phases: []*proto.EncounterPhase{
+			{
+				Name: "Adds",
+				Casts: []*proto.EncounterCast{{
+					Damage:          1000,
+					TargetType:      proto.EncounterCastTargetType_EncounterCastTargetTypeAllPlayers,
+					CastTimeSeconds: 1,
+					DelaySeconds:    2,
+					IntervalSeconds: 2,
+				}},
+				AddSpawns: []*proto.EncounterAddSpawn{{
+					TargetIndex:     1,
+					DelaySeconds:    1,
+					LifetimeSeconds: 4.5,
+				}},
+			},
+			{
+				Name:    "Enrage",
+				Trigger: &proto.EncounterTrigger{Trigger: &proto.EncounterTrigger_AddDeath{AddDeath: 1}},
+				Casts: []*proto.EncounterCast{{
+					Damage:          1000,
+					TargetType:      proto.EncounterCastTargetType_EncounterCastTargetTypeAllPlayers,
+					DelaySeconds:    0.25,
+					IntervalSeconds: 1,
+				}},
+				RaidEffects: []*proto.EncounterRaidEffect{{
+					Type:                  proto.EncounterRaidEffectType_EncounterRaidEffectTypeDamageTaken,
+					DurationSeconds:       100,
+					DamageTakenMultiplier: 2,
+				}},
+			},
+			{
+				Name:    "Burn",
+				Trigger: &proto.EncounterTrigger{Trigger: &proto.EncounterTrigger_BossHealthPercent{BossHealthPercent: 50}},
+			},
+		},
+	}
+
+	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
+	sim := newSimWithEnv(env, rsr.SimOptions, simsignals.CreateSignals())
+	sim.runOnce()
+
+	ai := env.Encounter.AllTargets[0].AI.(*encounterScriptAI)
+	if ai.phaseIdx != 2 {
+		t.Fatalf("Expected to end in phase 3 but found phase %d", ai.phaseIdx+1)
+	}
+	if env.Encounter.AllTargets[1].IsEnabled() {
+		t.Fatalf("Expected the add to be dead")
+	}
+
+	boss := env.Encounter.AllTargetUnits[0]
+	for tag, expected := range map[int32]float64{
+		// Finishes casting at 3s and 5s, before the add dies at 5.5s.
+		1: 2 * 1000,
+		// Hits at 5.75s, ..., 9.75s with doubled damage taken, until the boss reaches 50% at 10s.
+		2: 5 * 2000,
+	} {
+		spell := boss.GetSpell(ActionID{OtherID: proto.OtherAction_OtherActionEncounterScript, Tag: tag})
+		for i, dummy := range env.Raid.AllPlayerUnits {
+			if damage := spell.SpellMetrics[dummy.UnitIndex].TotalDamage; damage != expected {
+				t.Fatalf("Cast %d, dummy %d: expected %0.0f damage taken but found %0.0f", tag, i, expected, damage)
+			}
+		}
+	}
+}
+
+func TestEncounterScriptRejectsInvalidSettings(t *testing.T) {
+	timeTrigger := &proto.EncounterTrigger{Trigger: &proto.EncounterTrigger_TimeSeconds{TimeSeconds: 10}}
+
+	for _, tc := range []struct {
+		comment string
+		phases  []*proto.EncounterPhase
+		want    string
+	}{
+		{
+			comment: "cast by a target which does not exist",
+			phases:  []*proto.EncounterPhase{{Casts: []*proto.EncounterCast{{CasterIndex: 2}}}},
+			want:    "caster index 2",
+		},
+		{
+			comment: "raid effect without a duration",
+			phases:  []*proto.EncounterPhase{{RaidEffects: []*proto.EncounterRaidEffect{{}}}},
+			want:    "positive duration",
+		},
+		{
+			comment: "spawn of the boss",
+			phases:  []*proto.EncounterPhase{{AddSpawns: []*proto.EncounterAddSpawn{{TargetIndex: 0}}}},
+			want:    "target index 0",
+		},
+		{
+			comment: "spawn of a target which does not exist",
+			phases:  []*proto.EncounterPhase{{AddSpawns: []*proto.EncounterAddSpawn{{TargetIndex: 2}}}},
+			want:    "target index 2",
+		},
+		{
+			comment: "phase without a trigger",
+			phases:  []*proto.EncounterPhase{{}, {Trigger: timeTrigger}, {Name: "Never"}},
+			want:    "phase 3 (Never) has no trigger",
+		},
+		{
+			comment: "phase triggered by the death of a target which does not exist",
+			phases:  []*proto.EncounterPhase{{}, {Trigger: &proto.EncounterTrigger{Trigger: &proto.EncounterTrigger_AddDeath{AddDeath: 5}}}},
+			want:    "death of target 5",
+		},
+	} {
+		rsr := raidDamageTestRequest()
+		rsr.Encounter.Targets = []*proto.Target{{}, {DisabledAtStart: true}}
+		rsr.Encounter.Script = &proto.EncounterScript{Phases: tc.phases}
+
+		func() {
+			defer func() {
+				err := recover()
+				if err == nil {
+					t.Fatalf("%s: expected NewEnvironment to panic", tc.comment)
+				}
+				if msg, ok := err.(string); !ok || !strings.Contains(msg, tc.want) {
+					t.Fatalf("%s: expected a panic containing %q, got %v", tc.comment, tc.want, err)
+				}
+			}()
+			NewEnvironment(rsr.Raid, rsr.Encounter, false)
+		}()
+	}
+
+	// The first phase starts with the encounter and needs no trigger.
+	if err := validateEncounterScript(&proto.EncounterScript{Phases: []*proto.EncounterPhase{{}, {Trigger: timeTrigger}}}, 2); err != nil {
+		t.Fatalf("Expected a valid script, got %v", err)
+	}
+}
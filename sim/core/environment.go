@@ -135,6 +135,8 @@ func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.
 		}
 	}
 
+	env.setupRaidDamage(encounterProto.RaidDamage)
+
 	for _, party := range env.Raid.Parties {
 		for _, playerOrPet := range party.PlayersAndPets {
 			playerOrPet.GetCharacter().initialize(playerOrPet)
@@ -142,6 +144,7 @@ func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.
 	}
 
 	raidStats := env.Raid.applyCharacterEffects(raidProto)
+	env.Raid.registerCooldownPlan(env, raidProto.CooldownPlan)
 
 	for _, party := range env.Raid.Parties {
 		for _, playerOrPet := range party.PlayersAndPets {
@@ -167,13 +170,23 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 		}
 	}
 
-	for _, party := range env.Raid.Parties {
-		for _, player := range party.Players {
+	for partyIdx, party := range env.Raid.Parties {
+		partyProto := raidProto.Parties[partyIdx]
+		for playerIdx, player := range party.Players {
 			character := player.GetCharacter()
 			character.Finalize()
+
+			var petRotations map[string]*proto.APLRotation
+			if playerIdx < len(partyProto.Players) {
+				petRotations = partyProto.Players[playerIdx].PetRotations
+			}
 			for _, pet := range character.Pets {
 				pet.Finalize()
-				pet.Rotation = pet.newCustomRotation()
+				if petRotation, ok := petRotations[pet.Name]; ok {
+					pet.Rotation = pet.newAPLRotation(petRotation)
+				} else {
+					pet.Rotation = pet.newCustomRotation()
+				}
 			}
 		}
 	}
@@ -280,6 +293,8 @@ func (env *Environment) reset(sim *Simulation) {
 	// Reset primary targets damage taken for tracking health fights.
 	env.Encounter.DamageTaken = 0
 
+	env.Encounter.resetActiveTargets()
+
 	// Targets need to be reset before the raid, so that players can check for
 	// the presence of permanent target auras in their Reset handlers.
 	for _, target := range env.Encounter.AllTargets {
@@ -375,6 +390,13 @@ func (env *Environment) GetUnit(ref *proto.UnitReference, contextUnit *Unit) *Un
 			return nil
 		}
 		return contextUnit.CurrentTarget
+	case proto.UnitReference_Owner:
+		if contextUnit == nil {
+			return nil
+		}
+		if petAgent, ok := env.GetAgentFromUnit(contextUnit).(PetAgent); ok {
+			return &petAgent.GetPet().Owner.Unit
+		}
 	}
 
 	return nil
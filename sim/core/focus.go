@@ -99,6 +99,10 @@ func (fb *focusBar) AddFocus(sim *Simulation, amount float64, metrics *ResourceM
 	}
 	if fb.isPlayer {
 		metrics.AddEvent(amount, newFocus-fb.currentFocus)
+
+		if sim.CombatLog != nil {
+			sim.CombatLog.ResourceChange(sim, fb.unit, metrics, amount, fb.currentFocus, newFocus)
+		}
 	}
 
 	if fb.OnFocusGain != nil {
@@ -119,6 +123,9 @@ func (fb *focusBar) SpendFocus(sim *Simulation, amount float64, metrics *Resourc
 	if sim.Log != nil {
 		fb.unit.Log(sim, "Spent %0.3f focus from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, fb.currentFocus, newFocus, fb.maxFocus)
 	}
+	if sim.CombatLog != nil {
+		sim.CombatLog.ResourceChange(sim, fb.unit, metrics, -amount, fb.currentFocus, newFocus)
+	}
 
 	fb.currentFocus = newFocus
 }
@@ -79,6 +79,10 @@ func (unit *Unit) ReactToEvent(sim *Simulation) {
 // Call this to stop the GCD loop for a unit.
 // This is mostly used for pets that get summoned / expire.
 func (unit *Unit) CancelGCDTimer(sim *Simulation) {
+	if unit.rotationAction == nil {
+		return
+	}
+
 	unit.rotationAction.Cancel(sim)
 }
 
@@ -0,0 +1,405 @@
+package core
+
+import (
+	"errors"
+	"math"
+)
+
+// A small exact mixed integer linear program solver. Linear relaxations are solved with a dense
+// two-phase simplex using Bland's rule, and integrality of binary variables is enforced with a
+// depth-first branch and bound. This is plenty for the problem sizes of the reforge optimizer
+// (a few hundred variables), without pulling in an external solver dependency.
+
+const ilpEpsilon = 1e-9
+
+var (
+	errILPInfeasible = errors.New("ilp: model is infeasible")
+	errILPUnbounded  = errors.New("ilp: model is unbounded")
+	errILPNodeLimit  = errors.New("ilp: node limit reached before a feasible solution was found")
+)
+
+type ilpConstraintKind int
+
+const (
+	ilpLessEq ilpConstraintKind = iota
+	ilpGreaterEq
+	ilpEqual
+)
+
+type ilpTerm struct {
+	Variable    int
+	Coefficient float64
+}
+
+type ilpConstraint struct {
+	Terms []ilpTerm
+	Kind  ilpConstraintKind
+	Rhs   float64
+}
+
+// ilpModel maximizes the objective over non-negative variables, some of which may be restricted to
+// the values 0 or 1. Binary variables must be bounded by 1 through the constraints of the model.
+type ilpModel struct {
+	objective   []float64
+	binary      []bool
+	constraints []ilpConstraint
+}
+
+type ilpSolution struct {
+	Values []float64
+	Value  float64
+
+	// False if the node limit was reached before the solution could be proven optimal.
+	Optimal bool
+}
+
+func (model *ilpModel) numVariables() int {
+	return len(model.objective)
+}
+
+// Adds a new variable with the given objective coefficient and returns its index.
+func (model *ilpModel) addVariable(objective float64, binary bool) int {
+	model.objective = append(model.objective, objective)
+	model.binary = append(model.binary, binary)
+	return len(model.objective) - 1
+}
+
+func (model *ilpModel) addConstraint(terms []ilpTerm, kind ilpConstraintKind, rhs float64) {
+	model.constraints = append(model.constraints, ilpConstraint{
+		Terms: terms,
+		Kind:  kind,
+		Rhs:   rhs,
+	})
+}
+
+// Solves the model exactly, exploring at most maxNodes branch and bound nodes. If the node limit is
+// reached after a feasible solution was found, the best solution so far is returned as non-optimal.
+func (model *ilpModel) solve(maxNodes int) (*ilpSolution, error) {
+	numVars := model.numVariables()
+
+	// -1 for free variables, otherwise the value a binary variable is fixed to.
+	type ilpNode struct {
+		fixed []int8
+	}
+
+	root := ilpNode{fixed: make([]int8, numVars)}
+	for i := range root.fixed {
+		root.fixed[i] = -1
+	}
+
+	var best *ilpSolution
+	stack := []ilpNode{root}
+	nodes := 0
+	optimal := true
+	for len(stack) > 0 {
+		if nodes >= maxNodes {
+			if best == nil {
+				return nil, errILPNodeLimit
+			}
+			optimal = false
+			break
+		}
+		nodes++
+
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		relaxation, err := model.solveRelaxation(node.fixed)
+		if err == errILPInfeasible {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if best != nil && relaxation.Value <= best.Value+ilpEpsilon*math.Max(1, math.Abs(best.Value)) {
+			continue
+		}
+
+		// Branch on the most likely binary variable first, which quickly finds good incumbents
+		// for the choose-one-of-many constraints that make up most models.
+		branchVar := -1
+		branchValue := 0.0
+		for i, isBinary := range model.binary {
+			if !isBinary || node.fixed[i] >= 0 {
+				continue
+			}
+			value := relaxation.Values[i]
+			if value > 1e-6 && value < 1-1e-6 && value > branchValue {
+				branchVar = i
+				branchValue = value
+			}
+		}
+
+		if branchVar == -1 {
+			for i, isBinary := range model.binary {
+				if isBinary {
+					relaxation.Values[i] = math.Round(relaxation.Values[i])
+				}
+			}
+			best = relaxation
+			continue
+		}
+
+		zero := ilpNode{fixed: append([]int8(nil), node.fixed...)}
+		zero.fixed[branchVar] = 0
+		one := ilpNode{fixed: append([]int8(nil), node.fixed...)}
+		one.fixed[branchVar] = 1
+		stack = append(stack, zero, one)
+	}
+
+	if best == nil {
+		return nil, errILPInfeasible
+	}
+	best.Optimal = optimal
+	return best, nil
+}
+
+// Solves the linear relaxation of the model with some binary variables fixed.
+func (model *ilpModel) solveRelaxation(fixed []int8) (*ilpSolution, error) {
+	numVars := model.numVariables()
+
+	columns := make([]int, numVars)
+	var freeVars []int
+	constant := 0.0
+	for i := 0; i < numVars; i++ {
+		if fixed[i] >= 0 {
+			columns[i] = -1
+			constant += model.objective[i] * float64(fixed[i])
+		} else {
+			columns[i] = len(freeVars)
+			freeVars = append(freeVars, i)
+		}
+	}
+
+	objective := make([]float64, len(freeVars))
+	for col, i := range freeVars {
+		objective[col] = model.objective[i]
+	}
+
+	rows := make([][]float64, len(model.constraints))
+	kinds := make([]ilpConstraintKind, len(model.constraints))
+	rhs := make([]float64, len(model.constraints))
+	for r, constraint := range model.constraints {
+		row := make([]float64, len(freeVars))
+		rhs[r] = constraint.Rhs
+		for _, term := range constraint.Terms {
+			if col := columns[term.Variable]; col >= 0 {
+				row[col] += term.Coefficient
+			} else {
+				rhs[r] -= term.Coefficient * float64(fixed[term.Variable])
+			}
+		}
+		rows[r] = row
+		kinds[r] = constraint.Kind
+	}
+
+	values, value, err := solveLP(objective, rows, kinds, rhs)
+	if err != nil {
+		return nil, err
+	}
+
+	solution := &ilpSolution{
+		Values: make([]float64, numVars),
+		Value:  value + constant,
+	}
+	for i := 0; i < numVars; i++ {
+		if col := columns[i]; col >= 0 {
+			solution.Values[i] = values[col]
+		} else {
+			solution.Values[i] = float64(fixed[i])
+		}
+	}
+	return solution, nil
+}
+
+// solveLP maximizes objective·x subject to rows·x (kinds) rhs and x >= 0.
+func solveLP(objective []float64, rows [][]float64, kinds []ilpConstraintKind, rhs []float64) ([]float64, float64, error) {
+	numVars := len(objective)
+	numRows := len(rows)
+
+	// Normalize so that every right hand side is non-negative.
+	kinds = append([]ilpConstraintKind(nil), kinds...)
+	signs := make([]float64, numRows)
+	for r := range rows {
+		signs[r] = 1
+		if rhs[r] < 0 {
+			signs[r] = -1
+			switch kinds[r] {
+			case ilpLessEq:
+				kinds[r] = ilpGreaterEq
+			case ilpGreaterEq:
+				kinds[r] = ilpLessEq
+			}
+		}
+	}
+
+	numSlacks := 0
+	numArtificials := 0
+	for _, kind := range kinds {
+		if kind != ilpEqual {
+			numSlacks++
+		}
+		if kind != ilpLessEq {
+			numArtificials++
+		}
+	}
+
+	artificialStart := numVars + numSlacks
+	numCols := artificialStart + numArtificials
+	tableau := make([][]float64, numRows)
+	basis := make([]int, numRows)
+
+	slack := numVars
+	artificial := artificialStart
+	for r, row := range rows {
+		tableau[r] = make([]float64, numCols+1)
+		for j, a := range row {
+			tableau[r][j] = signs[r] * a
+		}
+		tableau[r][numCols] = signs[r] * rhs[r]
+
+		switch kinds[r] {
+		case ilpLessEq:
+			tableau[r][slack] = 1
+			basis[r] = slack
+			slack++
+		case ilpGreaterEq:
+			tableau[r][slack] = -1
+			slack++
+			tableau[r][artificial] = 1
+			basis[r] = artificial
+			artificial++
+		case ilpEqual:
+			tableau[r][artificial] = 1
+			basis[r] = artificial
+			artificial++
+		}
+	}
+
+	// Phase 1: minimize the sum of artificial variables.
+	if numArtificials > 0 {
+		phase1 := make([]float64, numCols)
+		for j := artificialStart; j < numCols; j++ {
+			phase1[j] = -1
+		}
+		if err := runSimplex(tableau, basis, phase1, numCols); err != nil {
+			return nil, 0, err
+		}
+
+		infeasibility := 0.0
+		for r, b := range basis {
+			if b >= artificialStart {
+				infeasibility += tableau[r][numCols]
+			}
+		}
+		if infeasibility > 1e-7 {
+			return nil, 0, errILPInfeasible
+		}
+
+		// Drive any remaining (zero valued) artificial variables out of the basis.
+		for r, b := range basis {
+			if b < artificialStart {
+				continue
+			}
+			for j := 0; j < artificialStart; j++ {
+				if math.Abs(tableau[r][j]) > ilpEpsilon {
+					pivot(tableau, basis, r, j)
+					break
+				}
+			}
+		}
+	}
+
+	// Phase 2: optimize the real objective, never letting artificial variables re-enter.
+	phase2 := make([]float64, numCols)
+	copy(phase2, objective)
+	if err := runSimplex(tableau, basis, phase2, artificialStart); err != nil {
+		return nil, 0, err
+	}
+
+	values := make([]float64, numVars)
+	value := 0.0
+	for r, b := range basis {
+		if b < numVars {
+			values[b] = tableau[r][numCols]
+		}
+	}
+	for j := range values {
+		value += objective[j] * values[j]
+	}
+	return values, value, nil
+}
+
+// Runs simplex iterations maximizing objective until optimal. Only the first numEntering columns
+// are allowed to enter the basis.
+func runSimplex(tableau [][]float64, basis []int, objective []float64, numEntering int) error {
+	if len(tableau) == 0 {
+		for j := 0; j < numEntering; j++ {
+			if objective[j] > ilpEpsilon {
+				return errILPUnbounded
+			}
+		}
+		return nil
+	}
+
+	numCols := len(tableau[0]) - 1
+	for {
+		// Bland's rule: the lowest index column with a positive reduced profit enters.
+		entering := -1
+		for j := 0; j < numEntering; j++ {
+			reduced := objective[j]
+			for r, b := range basis {
+				reduced -= objective[b] * tableau[r][j]
+			}
+			if reduced > ilpEpsilon {
+				entering = j
+				break
+			}
+		}
+		if entering == -1 {
+			return nil
+		}
+
+		leaving := -1
+		bestRatio := math.Inf(1)
+		for r := range tableau {
+			a := tableau[r][entering]
+			if a <= ilpEpsilon {
+				continue
+			}
+			ratio := tableau[r][numCols] / a
+			if leaving == -1 || ratio < bestRatio-ilpEpsilon || (ratio < bestRatio+ilpEpsilon && basis[r] < basis[leaving]) {
+				leaving = r
+				bestRatio = ratio
+			}
+		}
+		if leaving == -1 {
+			return errILPUnbounded
+		}
+
+		pivot(tableau, basis, leaving, entering)
+	}
+}
+
+func pivot(tableau [][]float64, basis []int, row int, col int) {
+	pivotRow := tableau[row]
+	scale := 1 / pivotRow[col]
+	for j := range pivotRow {
+		pivotRow[j] *= scale
+	}
+	pivotRow[col] = 1
+
+	for r, other := range tableau {
+		if r == row {
+			continue
+		}
+		factor := other[col]
+		if factor == 0 {
+			continue
+		}
+		for j := range other {
+			other[j] -= factor * pivotRow[j]
+		}
+		other[col] = 0
+	}
+	basis[row] = col
+}
@@ -101,6 +101,46 @@ func (equipment *Equipment) applyItemEffects(agent Agent, registeredItemEffects
 	}
 }
 
+// Returns all equipped items, enchants, gems and tinkers which have a special effect
+// in the game data but no registered implementation, so only their stats are simulated.
+func (equipment *Equipment) GetUnsimulatedEffects() []*proto.UnsimulatedEffect {
+	var unsimulated []*proto.UnsimulatedEffect
+	addUnsimulated := func(effectType proto.UnsimulatedEffectType, slot int, id int32, name string) {
+		unsimulated = append(unsimulated, &proto.UnsimulatedEffect{
+			Type: effectType,
+			Slot: proto.ItemSlot(slot),
+			Id:   id,
+			Name: name,
+		})
+	}
+
+	for slot, eq := range equipment {
+		if eq.ID == 0 {
+			continue
+		}
+
+		if eq.ItemEffect != nil && !HasItemEffect(eq.ID) {
+			addUnsimulated(proto.UnsimulatedEffectType_UnsimulatedEffectItem, slot, eq.ID, eq.Name)
+		}
+
+		for _, gem := range eq.Gems {
+			if gem.HasEffect && !HasItemEffect(gem.ID) {
+				addUnsimulated(proto.UnsimulatedEffectType_UnsimulatedEffectGem, slot, gem.ID, gem.Name)
+			}
+		}
+
+		if eq.Enchant.EnchantEffect != nil && !HasEnchantEffect(eq.Enchant.EffectID) {
+			addUnsimulated(proto.UnsimulatedEffectType_UnsimulatedEffectEnchant, slot, eq.Enchant.EffectID, eq.Enchant.Name)
+		}
+
+		if eq.Tinker.EnchantEffect != nil && !HasEnchantEffect(eq.Tinker.EffectID) {
+			addUnsimulated(proto.UnsimulatedEffectType_UnsimulatedEffectTinker, slot, eq.Tinker.EffectID, eq.Tinker.Name)
+		}
+	}
+
+	return unsimulated
+}
+
 // Applies 3% Crit Damage effect
 // https://www.wowhead.com/mop-classic/spell=44797/3-increased-critical-effect
 func ApplyMetaGemCriticalDamageEffect(agent Agent, _ proto.ItemLevelState) {
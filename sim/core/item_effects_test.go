@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestGetUnsimulatedEffects(t *testing.T) {
+	const simulatedItemID = 990001
+	const unsimulatedItemID = 990002
+	const metaGemID = 990003
+	const effectGemID = 990005
+	const unsimulatedEnchantID = 990004
+
+	itemEffects[simulatedItemID] = func(Agent, proto.ItemLevelState) {}
+	t.Cleanup(func() {
+		delete(itemEffects, simulatedItemID)
+	})
+
+	procEffect := &proto.ItemEffect{Effect: &proto.ItemEffect_Proc{Proc: &proto.ProcEffect{}}}
+
+	var equipment Equipment
+	equipment[proto.ItemSlot_ItemSlotTrinket1] = Item{ID: simulatedItemID, Name: "Simulated Trinket", ItemEffect: procEffect}
+	equipment[proto.ItemSlot_ItemSlotTrinket2] = Item{ID: unsimulatedItemID, Name: "Unsimulated Trinket", ItemEffect: procEffect}
+	equipment[proto.ItemSlot_ItemSlotHead] = Item{
+		ID:   1,
+		Name: "Helm",
+		Gems: []Gem{
+			{ID: metaGemID, Name: "Meta Gem", Color: proto.GemColor_GemColorMeta, HasEffect: true},
+			{ID: 2, Name: "Stat Gem", Color: proto.GemColor_GemColorRed},
+			{ID: effectGemID, Name: "Effect Gem", Color: proto.GemColor_GemColorPrismatic, HasEffect: true},
+		},
+		Tinker: Enchant{EffectID: unsimulatedEnchantID, Name: "Tinker", EnchantEffect: procEffect},
+	}
+
+	unsimulated := equipment.GetUnsimulatedEffects()
+
+	expected := []*proto.UnsimulatedEffect{
+		{Type: proto.UnsimulatedEffectType_UnsimulatedEffectGem, Slot: proto.ItemSlot_ItemSlotHead, Id: metaGemID, Name: "Meta Gem"},
+		{Type: proto.UnsimulatedEffectType_UnsimulatedEffectGem, Slot: proto.ItemSlot_ItemSlotHead, Id: effectGemID, Name: "Effect Gem"},
+		{Type: proto.UnsimulatedEffectType_UnsimulatedEffectTinker, Slot: proto.ItemSlot_ItemSlotHead, Id: unsimulatedEnchantID, Name: "Tinker"},
+		{Type: proto.UnsimulatedEffectType_UnsimulatedEffectItem, Slot: proto.ItemSlot_ItemSlotTrinket2, Id: unsimulatedItemID, Name: "Unsimulated Trinket"},
+	}
+
+	if len(unsimulated) != len(expected) {
+		t.Fatalf("Expected %d unsimulated effects, got %d: %v", len(expected), len(unsimulated), unsimulated)
+	}
+	for i := range expected {
+		if unsimulated[i].Type != expected[i].Type || unsimulated[i].Slot != expected[i].Slot || unsimulated[i].Id != expected[i].Id || unsimulated[i].Name != expected[i].Name {
+			t.Errorf("Unsimulated effect %d: expected %v, got %v", i, expected[i], unsimulated[i])
+		}
+	}
+}
@@ -88,6 +88,7 @@ type UnitMetrics struct {
 	dtps   DistributionMetrics
 	tmi    DistributionMetrics
 	hps    DistributionMetrics
+	ehps   DistributionMetrics
 	tto    DistributionMetrics
 
 	tmiList   []tmiListItem
@@ -174,6 +175,7 @@ type SpellMetrics struct {
 	TotalHealing           float64 // Healing done by all casts of this spell.
 	TotalCritHealing       float64 // Healing done by all critical casts of this spell.
 	TotalShielding         float64 // Shielding done by all casts of this spell.
+	TotalOverhealing       float64 // Healing done by all casts of this spell in excess of the target's missing health.
 	TotalCastTime          time.Duration
 }
 
@@ -205,6 +207,7 @@ type TargetedActionMetrics struct {
 	Healing           float64
 	CritHealing       float64
 	Shielding         float64
+	Overhealing       float64
 	CastTime          time.Duration
 }
 
@@ -237,6 +240,7 @@ func (tam *TargetedActionMetrics) ToProto() *proto.TargetedActionMetrics {
 		CritHealing:       tam.CritHealing,
 		Shielding:         tam.Shielding,
 		CastTimeMs:        float64(tam.CastTime.Milliseconds()),
+		Overhealing:       tam.Overhealing,
 	}
 }
 
@@ -247,6 +251,7 @@ func NewUnitMetrics() UnitMetrics {
 		dtps:    NewDistributionMetrics(),
 		tmi:     NewDistributionMetrics(),
 		hps:     NewDistributionMetrics(),
+		ehps:    NewDistributionMetrics(),
 		tto:     NewDistributionMetrics(),
 		actions: make(map[ActionID]*ActionMetrics),
 	}
@@ -397,6 +402,7 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 		tam.Healing += spellTargetMetrics.TotalHealing
 		tam.CritHealing += spellTargetMetrics.TotalCritHealing
 		tam.Shielding += spellTargetMetrics.TotalShielding
+		tam.Overhealing += spellTargetMetrics.TotalOverhealing
 		if !spell.Flags.Matches(SpellFlagPassiveSpell) {
 			tam.CastTime += spellTargetMetrics.TotalCastTime
 		}
@@ -409,6 +415,7 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 			unitMetrics.threat.Total += spellTargetMetrics.TotalThreat
 		} else {
 			unitMetrics.hps.Total += spellTargetMetrics.TotalHealing + spellTargetMetrics.TotalShielding
+			unitMetrics.ehps.Total += spellTargetMetrics.TotalHealing + spellTargetMetrics.TotalShielding - spellTargetMetrics.TotalOverhealing
 		}
 	}
 }
@@ -443,6 +450,7 @@ func (unitMetrics *UnitMetrics) reset() {
 	unitMetrics.tmi.reset()
 	unitMetrics.tmiList = nil
 	unitMetrics.hps.reset()
+	unitMetrics.ehps.reset()
 	unitMetrics.tto.reset()
 	unitMetrics.CharacterIterationMetrics = CharacterIterationMetrics{}
 
@@ -491,6 +499,7 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	unitMetrics.dtps.doneIteration(sim)
 	unitMetrics.tmi.doneIteration(sim)
 	unitMetrics.hps.doneIteration(sim)
+	unitMetrics.ehps.doneIteration(sim)
 	unitMetrics.tto.doneIteration(sim)
 
 	unitMetrics.oomTimeSum += unitMetrics.OOMTime.Seconds()
@@ -579,6 +588,7 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		Dtps:          unitMetrics.dtps.ToProto(),
 		Tmi:           unitMetrics.tmi.ToProto(),
 		Hps:           unitMetrics.hps.ToProto(),
+		Ehps:          unitMetrics.ehps.ToProto(),
 		Tto:           unitMetrics.tto.ToProto(),
 		SecondsOomAvg: unitMetrics.oomTimeSum / n,
 		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_BeastMasteryHunter{},
+		proto.Spec_SpecBeastMasteryHunter,
+		NewFakePetOwner,
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_BeastMasteryHunter)
+			if !ok {
+				panic("Invalid spec value for Beast Mastery Hunter!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+type FakePet struct {
+	Pet
+	CustomRotationCalls int
+}
+
+func (pet *FakePet) GetPet() *Pet {
+	return &pet.Pet
+}
+
+func (pet *FakePet) Reset(_ *Simulation)            {}
+func (pet *FakePet) OnEncounterStart(_ *Simulation) {}
+
+func (pet *FakePet) ExecuteCustomRotation(sim *Simulation) {
+	pet.CustomRotationCalls++
+	pet.WaitUntil(sim, sim.CurrentTime+time.Second)
+}
+
+func NewFakePetOwner(char *Character, _ *proto.Player) Agent {
+	fa := &FakeAgent{
+		Character: *char,
+	}
+	fa.AddPet(&FakePet{
+		Pet: NewPet(PetConfig{
+			Name:           "Fake Pet",
+			Owner:          &fa.Character,
+			EnabledOnStart: true,
+			NonHitExpStatInheritance: func(ownerStats stats.Stats) stats.Stats {
+				return stats.Stats{}
+			},
+		}),
+	})
+	return fa
+}
+
+func runFakePetSim(petRotations map[string]*proto.APLRotation) (*Character, *FakePet) {
+	rsr := &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{
+				Players: []*proto.Player{{
+					Name:         "Owner",
+					Class:        proto.Class_ClassHunter,
+					Buffs:        &proto.IndividualBuffs{},
+					Spec:         &proto.Player_BeastMasteryHunter{},
+					Equipment:    &proto.EquipmentSpec{},
+					PetRotations: petRotations,
+				}},
+				Buffs: &proto.PartyBuffs{},
+			}},
+		},
+		Encounter: &proto.Encounter{
+			Duration: 10,
+			Targets:  []*proto.Target{{}},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations: 1,
+			RandomSeed: 101,
+		},
+	}
+
+	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
+	sim := newSimWithEnv(env, rsr.SimOptions, simsignals.CreateSignals())
+	sim.runOnce()
+
+	owner := env.Raid.Parties[0].Players[0].GetCharacter()
+	return owner, owner.PetAgents[0].(*FakePet)
+}
+
+func TestPetRotationDefaultsToCustomRotation(t *testing.T) {
+	_, pet := runFakePetSim(map[string]*proto.APLRotation{
+		"Other Pet": {PriorityList: []*proto.APLListItem{}},
+	})
+
+	if !pet.Rotation.isCustomRotation || pet.CustomRotationCalls == 0 {
+		t.Fatalf("Expected the custom rotation to be used, with %d calls", pet.CustomRotationCalls)
+	}
+}
+
+func TestPetRotationFromPlayer(t *testing.T) {
+	config, err := APLRotationFromText(`
+custom_rotation if false
+wait(1s) if current_health_percent(owner) > 0.5
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, pet := runFakePetSim(map[string]*proto.APLRotation{"Fake Pet": config})
+
+	if pet.Rotation.isCustomRotation || pet.CustomRotationCalls != 0 {
+		t.Fatalf("Expected the pet rotation to be used instead of the custom rotation, which was called %d times", pet.CustomRotationCalls)
+	}
+	if unit := pet.Rotation.GetSourceUnit(&proto.UnitReference{Type: proto.UnitReference_Owner}).Get(); unit != &owner.Unit {
+		t.Fatalf("Expected the owner unit reference to resolve to the owner")
+	}
+	if executions := pet.Rotation.getProfileStats().PriorityList[1].Executions; executions < 10 {
+		t.Fatalf("Expected the pet to wait at least 10 times, got %d", executions)
+	}
+}
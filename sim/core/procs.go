@@ -178,6 +178,19 @@ func (character *Character) NewSetBonusRPPMProcManager(spellID int32, setBonusAu
 	return &dpm
 }
 
+// RPPM proc manager for effects that are not tied to an item slot, like meta gems.
+// Has no item swap callback and does not scale with item level.
+func (character *Character) NewStaticRPPMProcManager(procMask ProcMask, rppmConfig RPPMConfig) *DynamicProcManager {
+	if procMask == ProcMaskUnknown {
+		panic("Cannot create a static RPPM proc manager without a proc mask")
+	}
+
+	return &DynamicProcManager{
+		procMasks:   []ProcMask{procMask},
+		procChances: []DynamicProc{NewRPPMProc(character, rppmConfig)},
+	}
+}
+
 // Creates a new RPPM proc manager for the given effectID.
 // Will manage all equiped items that use the given effect ID and overwrite the given configuration's ilvl accordingly.
 //
@@ -119,6 +119,9 @@ func (rb *rageBar) AddRage(sim *Simulation, amount float64, metrics *ResourceMet
 	if sim.Log != nil {
 		rb.unit.Log(sim, "Gained %0.3f rage from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rb.currentRage, newRage, 100.0)
 	}
+	if sim.CombatLog != nil {
+		sim.CombatLog.ResourceChange(sim, rb.unit, metrics, amount, rb.currentRage, newRage)
+	}
 
 	rb.currentRage = newRage
 	if !sim.Options.Interactive {
@@ -137,6 +140,9 @@ func (rb *rageBar) SpendRage(sim *Simulation, amount float64, metrics *ResourceM
 	if sim.Log != nil {
 		rb.unit.Log(sim, "Spent %0.3f rage from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rb.currentRage, newRage, 100.0)
 	}
+	if sim.CombatLog != nil {
+		sim.CombatLog.ResourceChange(sim, rb.unit, metrics, -amount, rb.currentRage, newRage)
+	}
 
 	rb.currentRage = newRage
 }
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"cmp"
 	"slices"
 
 	"github.com/wowsims/mop/sim/core/proto"
@@ -17,16 +18,18 @@ type Party struct {
 
 	PlayersAndPets []Agent // Cached list of players + pets, concatenated.
 
-	dpsMetrics DistributionMetrics
-	hpsMetrics DistributionMetrics
+	dpsMetrics  DistributionMetrics
+	hpsMetrics  DistributionMetrics
+	ehpsMetrics DistributionMetrics
 }
 
 func NewParty(raid *Raid, index int, partyConfig *proto.Party) *Party {
 	party := &Party{
-		Raid:       raid,
-		Index:      index,
-		dpsMetrics: NewDistributionMetrics(),
-		hpsMetrics: NewDistributionMetrics(),
+		Raid:        raid,
+		Index:       index,
+		dpsMetrics:  NewDistributionMetrics(),
+		hpsMetrics:  NewDistributionMetrics(),
+		ehpsMetrics: NewDistributionMetrics(),
 	}
 
 	for playerIndex, playerConfig := range partyConfig.Players {
@@ -78,6 +81,7 @@ func (party *Party) reset(sim *Simulation) {
 
 	party.dpsMetrics.reset()
 	party.hpsMetrics.reset()
+	party.ehpsMetrics.reset()
 }
 
 func (party *Party) doneIteration(sim *Simulation) {
@@ -85,16 +89,19 @@ func (party *Party) doneIteration(sim *Simulation) {
 		agent.GetCharacter().doneIteration(sim)
 		party.dpsMetrics.Total += agent.GetCharacter().Metrics.dps.Total
 		party.hpsMetrics.Total += agent.GetCharacter().Metrics.hps.Total
+		party.ehpsMetrics.Total += agent.GetCharacter().Metrics.ehps.Total
 	}
 
 	party.dpsMetrics.doneIteration(sim)
 	party.hpsMetrics.doneIteration(sim)
+	party.ehpsMetrics.doneIteration(sim)
 }
 
 func (party *Party) GetMetrics() *proto.PartyMetrics {
 	metrics := &proto.PartyMetrics{
-		Dps: party.dpsMetrics.ToProto(),
-		Hps: party.hpsMetrics.ToProto(),
+		Dps:  party.dpsMetrics.ToProto(),
+		Hps:  party.hpsMetrics.ToProto(),
+		Ehps: party.ehpsMetrics.ToProto(),
 	}
 
 	playerIdx := 0
@@ -116,8 +123,13 @@ func (party *Party) GetMetrics() *proto.PartyMetrics {
 type Raid struct {
 	Parties []*Party
 
-	dpsMetrics DistributionMetrics
-	hpsMetrics DistributionMetrics
+	dpsMetrics  DistributionMetrics
+	hpsMetrics  DistributionMetrics
+	ehpsMetrics DistributionMetrics
+
+	// Raid members below the raid damage profile's low health threshold,
+	// integrated over time.
+	lowHealthMetrics DistributionMetrics
 
 	AllPlayerUnits   []*Unit // Cached list of all Players in the raid.
 	AllUnits         []*Unit // Cached list of all Units (players and pets) in the raid.
@@ -128,6 +140,8 @@ type Raid struct {
 	replenishmentUnits         []*Unit   // All units who can receive replenishment.
 	curReplenishmentUnits      [][]*Unit // Units that currently have replenishment active, separated by source.
 	leftoverReplenishmentUnits []*Unit   // Units without replenishment currently active.
+
+	cooldownAssignments []*raidCooldownAssignment
 }
 
 func (raid *Raid) GetActiveUnits() []*Unit {
@@ -150,16 +164,66 @@ func (raid *Raid) GetActiveAllyUnits() []*Unit {
 	return activeAllyUnits
 }
 
+// Returns the active allies with a health bar, which are the ones heals can
+// meaningfully target.
+func (raid *Raid) GetHealableAllyUnits() []*Unit {
+	healableAllyUnits := []*Unit{}
+	for _, unit := range raid.AllUnits {
+		if unit.IsActive() && unit.Type != EnemyUnit && unit.HasHealthBar() {
+			healableAllyUnits = append(healableAllyUnits, unit)
+		}
+	}
+	return healableAllyUnits
+}
+
+// Returns the ally with the lowest health percentage. Comparing percentages
+// keeps target dummies and players with very different max health comparable.
 func (raid *Raid) GetLowestHealthAllyUnit() *Unit {
 	var lowestHealthUnit *Unit
 	for _, unit := range raid.AllUnits {
-		if unit.IsActive() && unit.Type != EnemyUnit && unit.HasHealthBar() && (lowestHealthUnit == nil || unit.CurrentHealth() < lowestHealthUnit.CurrentHealth()) {
+		if unit.IsActive() && unit.Type != EnemyUnit && unit.HasHealthBar() && (lowestHealthUnit == nil || unit.CurrentHealthPercent() < lowestHealthUnit.CurrentHealthPercent()) {
 			lowestHealthUnit = unit
 		}
 	}
 	return lowestHealthUnit
 }
 
+// Returns up to maxTargets allies for a smart heal, starting with the primary
+// target and followed by the lowest health allies. Units without a health bar,
+// e.g. target dummies, count as being at full health.
+func (raid *Raid) GetSmartHealTargets(primary *Unit, maxTargets int) []*Unit {
+	targets := []*Unit{primary}
+
+	var candidates []*Unit
+	for _, unit := range raid.AllUnits {
+		if unit == primary || unit.Type == EnemyUnit || !unit.IsEnabled() {
+			continue
+		}
+		if unit.HasHealthBar() && !unit.IsActive() {
+			continue
+		}
+		candidates = append(candidates, unit)
+	}
+
+	healthPercent := func(unit *Unit) float64 {
+		if !unit.HasHealthBar() {
+			return 1
+		}
+		return unit.CurrentHealthPercent()
+	}
+	slices.SortStableFunc(candidates, func(a, b *Unit) int {
+		return cmp.Compare(healthPercent(a), healthPercent(b))
+	})
+
+	for _, unit := range candidates {
+		if len(targets) >= maxTargets {
+			break
+		}
+		targets = append(targets, unit)
+	}
+	return targets
+}
+
 // Makes a new raid.
 func NewRaid(raidConfig *proto.Raid) *Raid {
 	numParties := int(raidConfig.NumActiveParties)
@@ -168,9 +232,11 @@ func NewRaid(raidConfig *proto.Raid) *Raid {
 	}
 
 	raid := &Raid{
-		dpsMetrics:   NewDistributionMetrics(),
-		hpsMetrics:   NewDistributionMetrics(),
-		nextPetIndex: int32(numParties) * 5,
+		dpsMetrics:       NewDistributionMetrics(),
+		hpsMetrics:       NewDistributionMetrics(),
+		ehpsMetrics:      NewDistributionMetrics(),
+		lowHealthMetrics: NewDistributionMetrics(),
+		nextPetIndex:     int32(numParties) * 5,
 	}
 
 	for partyIndex, partyConfig := range raidConfig.Parties {
@@ -404,6 +470,9 @@ func (raid *Raid) reset(sim *Simulation) {
 	}
 	raid.dpsMetrics.reset()
 	raid.hpsMetrics.reset()
+	raid.ehpsMetrics.reset()
+	raid.lowHealthMetrics.reset()
+	raid.resetCooldownPlan()
 }
 
 func (raid *Raid) doneIteration(sim *Simulation) {
@@ -411,16 +480,23 @@ func (raid *Raid) doneIteration(sim *Simulation) {
 		party.doneIteration(sim)
 		raid.dpsMetrics.Total += party.dpsMetrics.Total
 		raid.hpsMetrics.Total += party.hpsMetrics.Total
+		raid.ehpsMetrics.Total += party.ehpsMetrics.Total
 	}
 
 	raid.dpsMetrics.doneIteration(sim)
 	raid.hpsMetrics.doneIteration(sim)
+	raid.ehpsMetrics.doneIteration(sim)
+	raid.lowHealthMetrics.doneIteration(sim)
+	raid.doneIterationCooldownPlan(sim)
 }
 
 func (raid *Raid) GetMetrics() *proto.RaidMetrics {
 	metrics := &proto.RaidMetrics{
-		Dps: raid.dpsMetrics.ToProto(),
-		Hps: raid.hpsMetrics.ToProto(),
+		Dps:               raid.dpsMetrics.ToProto(),
+		Hps:               raid.hpsMetrics.ToProto(),
+		Ehps:              raid.ehpsMetrics.ToProto(),
+		LowHealthMembers:  raid.lowHealthMetrics.ToProto(),
+		ExternalCooldowns: raid.getCooldownPlanMetrics(),
 	}
 	for _, party := range raid.Parties {
 		metrics.Parties = append(metrics.Parties, party.GetMetrics())
@@ -0,0 +1,444 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const PowerInfusionAuraTag = "PowerInfusion"
+const PowerInfusionDuration = time.Second * 20
+const PowerInfusionCD = time.Minute * 2
+
+func PowerInfusionAura(character *Unit, actionTag int32) *Aura {
+	actionID := ActionID{SpellID: 10060, Tag: actionTag}
+	aura := character.GetOrRegisterAura(Aura{
+		Label:    "PowerInfusion-" + actionID.String(),
+		Tag:      PowerInfusionAuraTag,
+		ActionID: actionID,
+		Duration: PowerInfusionDuration,
+	}).AttachMultiplicativePseudoStatBuff(&character.PseudoStats.DamageDealtMultiplier, 1.05).AttachMultiplyCastSpeed(1.2)
+
+	aura.NewExclusiveEffect("ManaCost", true, ExclusiveEffect{
+		Priority: -20,
+		OnGain: func(ee *ExclusiveEffect, sim *Simulation) {
+			ee.Aura.Unit.PseudoStats.SpellCostPercentModifier -= 20
+		},
+		OnExpire: func(ee *ExclusiveEffect, sim *Simulation) {
+			ee.Aura.Unit.PseudoStats.SpellCostPercentModifier += 20
+		},
+	})
+	return aura
+}
+
+const HandOfPurityAuraTag = "HandOfPurity"
+const HandOfPurityDuration = time.Second * 6
+const HandOfPurityCD = time.Second * 30
+
+func HandOfPurityAura(character *Unit, actionTag int32) *Aura {
+	actionID := ActionID{SpellID: 114039, Tag: actionTag}
+	return character.GetOrRegisterAura(Aura{
+		Label:    "HandOfPurity-" + actionID.String(),
+		Tag:      HandOfPurityAuraTag,
+		ActionID: actionID,
+		Duration: HandOfPurityDuration,
+	}).AttachMultiplicativePseudoStatBuff(&character.PseudoStats.DamageTakenMultiplier, 0.9)
+}
+
+// Static description of a cooldown which can be scheduled by a RaidCooldownPlan.
+type externalCooldownConfig struct {
+	ProviderClass proto.Class
+	ActionID      ActionID
+	Duration      time.Duration
+	Cooldown      time.Duration
+
+	// Raid-wide cooldowns affect the whole raid unless the assignment names targets.
+	RaidWide bool
+
+	// Registers the aura granted to a single target player. Nil for Shattering Throw,
+	// which is a debuff on the primary encounter target instead.
+	RegisterAura func(target *Character, actionTag int32) *Aura
+
+	// Returns the part of a hit by a buffed player which is attributed to the cooldown.
+	DamageGain func(spell *Spell, result *SpellResult, actionTag int32) float64
+}
+
+var externalCooldownConfigs = map[proto.ExternalCooldown]externalCooldownConfig{
+	proto.ExternalCooldown_ExternalCooldownPowerInfusion: {
+		ProviderClass: proto.Class_ClassPriest,
+		ActionID:      ActionID{SpellID: 10060},
+		Duration:      PowerInfusionDuration,
+		Cooldown:      PowerInfusionCD,
+		RegisterAura: func(target *Character, actionTag int32) *Aura {
+			return PowerInfusionAura(&target.Unit, actionTag)
+		},
+		DamageGain: func(spell *Spell, result *SpellResult, _ int32) float64 {
+			// The 20% cast speed adds 20% more hits of spells that are limited by it.
+			if damageScalesWithCastSpeed(spell, result.Target) {
+				return result.Damage * (1 - 1/(1.05*1.2))
+			}
+			return result.Damage * (1 - 1/1.05)
+		},
+	},
+	proto.ExternalCooldown_ExternalCooldownTricksOfTheTrade: {
+		ProviderClass: proto.Class_ClassRogue,
+		ActionID:      ActionID{SpellID: 57933},
+		Duration:      time.Second * 6,
+		Cooldown:      time.Second * 30,
+		RegisterAura: func(target *Character, actionTag int32) *Aura {
+			return TricksOfTheTradeAura(&target.Unit, actionTag, 1.15)
+		},
+		DamageGain: func(_ *Spell, result *SpellResult, _ int32) float64 {
+			return result.Damage * (1 - 1/1.15)
+		},
+	},
+	proto.ExternalCooldown_ExternalCooldownHandOfPurity: {
+		ProviderClass: proto.Class_ClassPaladin,
+		ActionID:      ActionID{SpellID: 114039},
+		Duration:      HandOfPurityDuration,
+		Cooldown:      HandOfPurityCD,
+		RegisterAura: func(target *Character, actionTag int32) *Aura {
+			return HandOfPurityAura(&target.Unit, actionTag)
+		},
+		DamageGain: func(_ *Spell, _ *SpellResult, _ int32) float64 {
+			return 0
+		},
+	},
+	proto.ExternalCooldown_ExternalCooldownStormlashTotem: {
+		ProviderClass: proto.Class_ClassShaman,
+		ActionID:      ActionID{SpellID: 120668},
+		Duration:      StormLashDuration,
+		Cooldown:      StormLashCD,
+		RaidWide:      true,
+		RegisterAura: func(target *Character, actionTag int32) *Aura {
+			return StormLashAura(target, actionTag)
+		},
+		DamageGain: func(spell *Spell, result *SpellResult, actionTag int32) float64 {
+			// All Stormlash damage from this provider's totem is a gain.
+			if spell.ActionID == (ActionID{SpellID: 120687, Tag: actionTag}) {
+				return result.Damage
+			}
+			return 0
+		},
+	},
+	proto.ExternalCooldown_ExternalCooldownSkullBanner: {
+		ProviderClass: proto.Class_ClassWarrior,
+		ActionID:      SkullBannerActionID,
+		Duration:      SkullBannerDuration,
+		Cooldown:      SkullBannerCD,
+		RaidWide:      true,
+		RegisterAura: func(target *Character, actionTag int32) *Aura {
+			return SkullBannerAura(target, actionTag)
+		},
+		DamageGain: func(_ *Spell, result *SpellResult, _ int32) float64 {
+			if !result.DidCrit() {
+				return 0
+			}
+			return result.Damage * (1 - 1/1.2)
+		},
+	},
+	proto.ExternalCooldown_ExternalCooldownShatteringThrow: {
+		ProviderClass: proto.Class_ClassWarrior,
+		ActionID:      ActionID{SpellID: 1249459},
+		Duration:      ShatteringThrowDuration,
+		Cooldown:      ShatteringThrowCD,
+		RaidWide:      true,
+		DamageGain: func(spell *Spell, result *SpellResult, _ int32) float64 {
+			armorMultiplier := result.ArmorMultiplier
+			if !spell.SpellSchool.Matches(SpellSchoolPhysical) || armorMultiplier <= 0 || armorMultiplier >= 1 {
+				return 0
+			}
+
+			// Recover the effective armor from the mitigation, and compare against the
+			// mitigation without the 20% armor reduction.
+			armorConstant := float64(spell.Unit.Level)*4037.5 - 317117.5
+			armor := armorConstant * (1 - armorMultiplier) / armorMultiplier
+			unreducedMultiplier := armorConstant / (armor/0.8 + armorConstant)
+			return result.Damage * (1 - unreducedMultiplier/armorMultiplier)
+		},
+	},
+}
+
+// Returns whether the rate at which a spell deals damage scales with cast speed, which is the case
+// for hard casts, GCD-bound spells and periodic effects with hasted ticks.
+func damageScalesWithCastSpeed(spell *Spell, target *Unit) bool {
+	if spell.DefaultCast.CastTime > 0 || spell.DefaultCast.GCD > 0 {
+		return true
+	}
+	dot := spell.Dot(target)
+	return dot != nil && dot.affectedByCastSpeed
+}
+
+// Per-target state of a cooldown assignment.
+type raidCooldownTarget struct {
+	character *Character
+	aura      *Aura
+
+	// Hidden aura on the target which attributes damage while the cooldown is active.
+	tracker *Aura
+
+	dpsGain DistributionMetrics
+}
+
+type raidCooldownAssignment struct {
+	config   *proto.RaidCooldownAssignment
+	cdConfig externalCooldownConfig
+
+	provider  *Character
+	targets   []*raidCooldownTarget
+	cd        Cooldown
+	condition APLValue
+
+	// Shattering Throw debuff, which is shared by all targets.
+	debuff *Aura
+
+	casts      int
+	totalCasts int
+}
+
+// Validates the cooldown plan of the raid and registers all of its auras and scheduling.
+func (raid *Raid) registerCooldownPlan(env *Environment, plan *proto.RaidCooldownPlan) {
+	if plan == nil {
+		return
+	}
+
+	type providerKey struct {
+		cooldown proto.ExternalCooldown
+		provider *Character
+	}
+	seen := make(map[providerKey]bool)
+
+	for i, config := range plan.Assignments {
+		cdConfig, ok := externalCooldownConfigs[config.Cooldown]
+		if !ok {
+			panic(fmt.Sprintf("Raid cooldown assignment %d has unknown cooldown %s", i, config.Cooldown))
+		}
+
+		provider := raid.getCooldownPlanPlayer(env, config.Provider)
+		if provider == nil {
+			panic(fmt.Sprintf("Raid cooldown assignment %d (%s): provider %s is not a player in the raid", i, config.Cooldown, config.Provider))
+		}
+		if provider.Class != cdConfig.ProviderClass {
+			panic(fmt.Sprintf("Raid cooldown assignment %d (%s): provider %s is a %s, not a %s", i, config.Cooldown, provider.Label, provider.Class, cdConfig.ProviderClass))
+		}
+
+		key := providerKey{config.Cooldown, provider}
+		if seen[key] {
+			panic(fmt.Sprintf("Raid cooldown assignment %d (%s): %s already has an assignment for this cooldown", i, config.Cooldown, provider.Label))
+		}
+		seen[key] = true
+
+		for _, timing := range config.Timings {
+			if timing < 0 {
+				panic(fmt.Sprintf("Raid cooldown assignment %d (%s): timings must not be negative, got %0.2f", i, config.Cooldown, timing))
+			}
+		}
+
+		var targets []*Character
+		for _, ref := range config.Targets {
+			target := raid.getCooldownPlanPlayer(env, ref)
+			if target == nil {
+				panic(fmt.Sprintf("Raid cooldown assignment %d (%s): target %s is not a player in the raid", i, config.Cooldown, ref))
+			}
+			targets = append(targets, target)
+		}
+		if len(targets) == 0 {
+			if !cdConfig.RaidWide {
+				panic(fmt.Sprintf("Raid cooldown assignment %d (%s): a target is required", i, config.Cooldown))
+			}
+			for _, party := range raid.Parties {
+				for _, player := range party.Players {
+					if _, isDummy := player.(*TargetDummy); !isDummy {
+						targets = append(targets, player.GetCharacter())
+					}
+				}
+			}
+		} else if !cdConfig.RaidWide && len(targets) > 1 {
+			panic(fmt.Sprintf("Raid cooldown assignment %d (%s): single target cooldowns can only have 1 target", i, config.Cooldown))
+		}
+
+		assignment := &raidCooldownAssignment{
+			config:   config,
+			cdConfig: cdConfig,
+			provider: provider,
+			cd: Cooldown{
+				Timer:    provider.NewTimer(),
+				Duration: cdConfig.Cooldown,
+			},
+		}
+		if cdConfig.RegisterAura == nil {
+			assignment.debuff = ShatteringThrowAura(env.GetTargetUnitByIndex(0), provider.Index)
+		}
+		for _, target := range targets {
+			assignment.addTarget(target)
+		}
+		assignment.registerScheduling(env)
+
+		raid.cooldownAssignments = append(raid.cooldownAssignments, assignment)
+	}
+}
+
+func (raid *Raid) getCooldownPlanPlayer(env *Environment, ref *proto.UnitReference) *Character {
+	if ref == nil || ref.Type != proto.UnitReference_Player {
+		return nil
+	}
+	unit := env.GetUnit(ref, nil)
+	if unit == nil {
+		return nil
+	}
+	agent := raid.GetPlayerFromUnit(unit)
+	if agent == nil {
+		return nil
+	}
+	if _, isDummy := agent.(*TargetDummy); isDummy {
+		return nil
+	}
+	return agent.GetCharacter()
+}
+
+func (assignment *raidCooldownAssignment) addTarget(character *Character) {
+	actionTag := assignment.provider.Index
+	target := &raidCooldownTarget{
+		character: character,
+		dpsGain:   NewDistributionMetrics(),
+	}
+	if assignment.cdConfig.RegisterAura != nil {
+		target.aura = assignment.cdConfig.RegisterAura(character, actionTag)
+	}
+
+	debuff := assignment.debuff
+	damageGain := assignment.cdConfig.DamageGain
+	onDamage := func(_ *Aura, _ *Simulation, spell *Spell, result *SpellResult) {
+		if !result.Landed() || result.Damage <= 0 {
+			return
+		}
+		if debuff != nil && (result.Target != debuff.Unit || !debuff.IsActive()) {
+			return
+		}
+		target.dpsGain.Total += damageGain(spell, result, actionTag)
+	}
+	target.tracker = character.RegisterAura(Aura{
+		Label:                 fmt.Sprintf("RaidCooldownPlan-%s-%d", assignment.cdConfig.ActionID, actionTag),
+		Duration:              assignment.cdConfig.Duration,
+		OnSpellHitDealt:       onDamage,
+		OnPeriodicDamageDealt: onDamage,
+	})
+
+	assignment.targets = append(assignment.targets, target)
+}
+
+func (assignment *raidCooldownAssignment) registerScheduling(env *Environment) {
+	provider := assignment.provider
+
+	if assignment.config.Condition != nil {
+		env.RegisterPostFinalizeEffect(func() {
+			rot := provider.Rotation
+			assignment.condition = rot.coerceTo(rot.newAPLValue(assignment.config.Condition), proto.APLValueType_ValueTypeBool)
+		})
+	}
+
+	provider.RegisterResetEffect(func(sim *Simulation) {
+		assignment.casts = 0
+
+		if len(assignment.config.Timings) > 0 {
+			for _, timing := range assignment.config.Timings {
+				pa := sim.GetConsumedPendingActionFromPool()
+				pa.NextActionAt = DurationFromSeconds(timing)
+				pa.Priority = ActionPriorityAuto
+				pa.OnAction = func(sim *Simulation) {
+					assignment.tryCast(sim)
+				}
+				sim.AddPendingAction(pa)
+			}
+			return
+		}
+
+		// Without fixed timings, cast on cooldown and poll the condition while it is false.
+		var checkAt func(doAt time.Duration)
+		checkAt = func(doAt time.Duration) {
+			pa := sim.GetConsumedPendingActionFromPool()
+			pa.NextActionAt = doAt
+			pa.Priority = ActionPriorityAuto
+			pa.OnAction = func(sim *Simulation) {
+				if assignment.tryCast(sim) {
+					checkAt(assignment.cd.ReadyAt())
+				} else {
+					checkAt(max(sim.CurrentTime+time.Millisecond*500, assignment.cd.ReadyAt()))
+				}
+			}
+			sim.AddPendingAction(pa)
+		}
+		checkAt(0)
+	})
+}
+
+func (assignment *raidCooldownAssignment) tryCast(sim *Simulation) bool {
+	if !assignment.cd.IsReady(sim) {
+		return false
+	}
+	if assignment.condition != nil && !assignment.condition.GetBool(sim) {
+		return false
+	}
+
+	assignment.cd.Use(sim)
+	assignment.casts++
+	if sim.Log != nil {
+		assignment.provider.Log(sim, "Casting external cooldown %s (%s)", assignment.config.Cooldown, assignment.cdConfig.ActionID)
+	}
+
+	if assignment.debuff != nil {
+		assignment.debuff.Activate(sim)
+	}
+	for _, target := range assignment.targets {
+		if target.aura != nil {
+			target.aura.Activate(sim)
+		}
+		target.tracker.Activate(sim)
+	}
+	return true
+}
+
+func (raid *Raid) resetCooldownPlan() {
+	for _, assignment := range raid.cooldownAssignments {
+		for _, target := range assignment.targets {
+			target.dpsGain.reset()
+		}
+	}
+}
+
+func (raid *Raid) doneIterationCooldownPlan(sim *Simulation) {
+	for _, assignment := range raid.cooldownAssignments {
+		assignment.totalCasts += assignment.casts
+		for _, target := range assignment.targets {
+			target.dpsGain.doneIteration(sim)
+		}
+	}
+}
+
+func (raid *Raid) getCooldownPlanMetrics() []*proto.ExternalCooldownMetrics {
+	var metrics []*proto.ExternalCooldownMetrics
+	for _, assignment := range raid.cooldownAssignments {
+		for _, target := range assignment.targets {
+			castsAvg := 0.0
+			if target.dpsGain.n > 0 {
+				castsAvg = float64(assignment.totalCasts) / float64(target.dpsGain.n)
+			}
+			metrics = append(metrics, &proto.ExternalCooldownMetrics{
+				Cooldown: assignment.config.Cooldown,
+				Provider: playerUnitReference(assignment.provider),
+				Target:   playerUnitReference(target.character),
+				DpsGain:  target.dpsGain.ToProto(),
+				CastsAvg: castsAvg,
+			})
+		}
+	}
+	return metrics
+}
+
+func playerUnitReference(character *Character) *proto.UnitReference {
+	return &proto.UnitReference{
+		Type:  proto.UnitReference_Player,
+		Index: character.Index,
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestRaidCooldownPlanRejectsProviderNotInRaid(t *testing.T) {
+	for _, providerIndex := range []int32{2, 7} {
+		rsr := raidDamageTestRequest()
+		rsr.Raid.CooldownPlan = &proto.RaidCooldownPlan{
+			Assignments: []*proto.RaidCooldownAssignment{{
+				Cooldown: proto.ExternalCooldown_ExternalCooldownSkullBanner,
+				Provider: &proto.UnitReference{Type: proto.UnitReference_Player, Index: providerIndex},
+			}},
+		}
+
+		func() {
+			defer func() {
+				err := recover()
+				if err == nil {
+					t.Fatalf("Provider %d: expected NewEnvironment to panic", providerIndex)
+				}
+				if msg, ok := err.(string); !ok || !strings.Contains(msg, "is not a player in the raid") {
+					t.Fatalf("Provider %d: unexpected panic %v", providerIndex, err)
+				}
+			}()
+			NewEnvironment(rsr.Raid, rsr.Encounter, false)
+		}()
+	}
+}
+
+func TestShatteringThrowDamageGain(t *testing.T) {
+	attacker := &Unit{Level: 90}
+	spell := &Spell{Unit: attacker, SpellSchool: SpellSchoolPhysical}
+
+	const targetArmor = 24835.0
+	armorConstant := float64(attacker.Level)*4037.5 - 317117.5
+	shatteredMultiplier := 1 - targetArmor*0.8/(targetArmor*0.8+armorConstant)
+	unshatteredMultiplier := 1 - targetArmor/(targetArmor+armorConstant)
+
+	result := &SpellResult{Damage: 10000, ArmorMultiplier: shatteredMultiplier}
+	gain := externalCooldownConfigs[proto.ExternalCooldown_ExternalCooldownShatteringThrow].DamageGain(spell, result, 0)
+
+	expected := 10000 - 10000*unshatteredMultiplier/shatteredMultiplier
+	if !WithinToleranceFloat64(expected, gain, 1e-6) {
+		t.Fatalf("Expected Shattering Throw gain of %0.4f, got %0.4f", expected, gain)
+	}
+
+	spell.SpellSchool = SpellSchoolFire
+	if gain := externalCooldownConfigs[proto.ExternalCooldown_ExternalCooldownShatteringThrow].DamageGain(spell, result, 0); gain != 0 {
+		t.Fatalf("Expected no Shattering Throw gain for fire damage, got %0.4f", gain)
+	}
+}
+
+func TestPowerInfusionDamageGain(t *testing.T) {
+	damageGain := externalCooldownConfigs[proto.ExternalCooldown_ExternalCooldownPowerInfusion].DamageGain
+	result := &SpellResult{Damage: 12600}
+
+	castSpell := &Spell{DefaultCast: Cast{GCD: GCDDefault}}
+	if gain := damageGain(castSpell, result, 0); !WithinToleranceFloat64(12600-10000, gain, 1e-6) {
+		t.Fatalf("Expected Power Infusion gain of 2600 for a GCD-bound spell, got %0.4f", gain)
+	}
+
+	procSpell := &Spell{}
+	if gain := damageGain(procSpell, result, 0); !WithinToleranceFloat64(12600-12000, gain, 1e-6) {
+		t.Fatalf("Expected Power Infusion gain of 600 for a proc, got %0.4f", gain)
+	}
+}
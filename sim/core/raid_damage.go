@@ -0,0 +1,123 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// How often raid members are checked against the low health threshold.
+const lowHealthSampleInterval = time.Millisecond * 500
+
+// Sets up the encounter's raid damage profile. Target dummies get a health bar
+// and the first target deals the configured damage events to them, so healer
+// sims have something meaningful to heal.
+func (env *Environment) setupRaidDamage(profile *proto.RaidDamageProfile) {
+	if profile == nil || len(profile.Events) == 0 {
+		return
+	}
+
+	dummies := env.Raid.GetTargetDummies()
+	if len(dummies) == 0 {
+		return
+	}
+
+	dummyUnits := make([]*Unit, len(dummies))
+	for i, dummy := range dummies {
+		dummy.enableHealthBar(profile.TargetDummyHealth)
+		dummyUnits[i] = &dummy.Unit
+	}
+
+	source := env.Encounter.AllTargetUnits[0]
+	for i, event := range profile.Events {
+		if event.Damage <= 0 || event.IntervalSeconds <= 0 {
+			continue
+		}
+		source.registerRaidDamageEvent(int32(i+1), event, dummyUnits)
+	}
+
+	env.Raid.trackLowHealthMembers(source, profile.LowHealthThreshold)
+}
+
+func (unit *Unit) registerRaidDamageEvent(tag int32, event *proto.RaidDamageEvent, dummies []*Unit) {
+	numSpikeTargets := min(max(int(event.NumTargets), 1), len(dummies))
+	spikeTargets := make([]*Unit, len(dummies))
+
+	spell := unit.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{OtherID: proto.OtherAction_OtherActionRaidDamage, Tag: tag},
+		SpellSchool:      SpellSchoolFromProto(event.SpellSchool),
+		ProcMask:         ProcMaskSpellDamage,
+		Flags:            SpellFlagIgnoreAttackerModifiers | SpellFlagIgnoreArmor | SpellFlagNoOnCastComplete,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *Simulation, _ *Unit, spell *Spell) {
+			rollDamage := func() float64 {
+				return event.Damage * (1 + event.DamageVariation*(2*sim.RandomFloat("Raid Damage")-1))
+			}
+
+			switch event.Type {
+			case proto.RaidDamageEventType_RaidDamageEventTypeAoE:
+				for _, dummy := range dummies {
+					spell.CalcAndDealDamage(sim, dummy, rollDamage(), spell.OutcomeAlwaysHit)
+				}
+			case proto.RaidDamageEventType_RaidDamageEventTypeRandomSpike:
+				// Partial Fisher-Yates shuffle, so no dummy is hit twice by the same spike.
+				copy(spikeTargets, dummies)
+				for i := 0; i < numSpikeTargets; i++ {
+					j := i + int(sim.RandomFloat("Raid Damage Target")*float64(len(spikeTargets)-i))
+					spikeTargets[i], spikeTargets[j] = spikeTargets[j], spikeTargets[i]
+					spell.CalcAndDealDamage(sim, spikeTargets[i], rollDamage(), spell.OutcomeAlwaysHit)
+				}
+			case proto.RaidDamageEventType_RaidDamageEventTypeTankMelee:
+				spell.CalcAndDealDamage(sim, dummies[0], rollDamage(), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	rollInterval := func(sim *Simulation) time.Duration {
+		variation := event.IntervalVariation * (2*sim.RandomFloat("Raid Damage Interval") - 1)
+		return DurationFromSeconds(max(event.IntervalSeconds+variation, 0.1))
+	}
+
+	unit.RegisterResetEffect(func(sim *Simulation) {
+		pa := &PendingAction{}
+		pa.NextActionAt = rollInterval(sim)
+
+		pa.OnAction = func(sim *Simulation) {
+			spell.Cast(sim, dummies[0])
+
+			pa.NextActionAt = sim.CurrentTime + rollInterval(sim)
+			sim.AddPendingAction(pa)
+		}
+
+		sim.AddPendingAction(pa)
+	})
+}
+
+// Periodically counts the raid members below threshold health. The count is
+// weighted by the sample interval, so the per-iteration metric comes out as
+// the average number of low health raid members.
+func (raid *Raid) trackLowHealthMembers(unit *Unit, threshold float64) {
+	if threshold <= 0 {
+		threshold = 0.5
+	}
+
+	unit.RegisterResetEffect(func(sim *Simulation) {
+		pa := &PendingAction{}
+
+		pa.OnAction = func(sim *Simulation) {
+			numLowHealth := 0
+			for _, player := range raid.AllPlayerUnits {
+				if player.HasHealthBar() && player.CurrentHealthPercent() < threshold {
+					numLowHealth++
+				}
+			}
+			raid.lowHealthMetrics.Total += float64(numLowHealth) * lowHealthSampleInterval.Seconds()
+
+			pa.NextActionAt = sim.CurrentTime + lowHealthSampleInterval
+			sim.AddPendingAction(pa)
+		}
+
+		sim.AddPendingAction(pa)
+	})
+}
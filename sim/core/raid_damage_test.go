@@ -0,0 +1,118 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func raidDamageTestRequest(events ...*proto.RaidDamageEvent) *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties:       []*proto.Party{{}},
+			TargetDummies: 4,
+		},
+		Encounter: &proto.Encounter{
+			Duration: 20,
+			Targets:  []*proto.Target{{}},
+			RaidDamage: &proto.RaidDamageProfile{
+				Events:             events,
+				TargetDummyHealth:  10000,
+				LowHealthThreshold: 0.5,
+			},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations: 1,
+			RandomSeed: 101,
+		},
+	}
+}
+
+func raidDamageTaken(env *Environment, tag int32) []float64 {
+	spell := env.Encounter.AllTargetUnits[0].GetSpell(ActionID{OtherID: proto.OtherAction_OtherActionRaidDamage, Tag: tag})
+	damage := make([]float64, len(env.Raid.AllPlayerUnits))
+	for i, dummy := range env.Raid.AllPlayerUnits {
+		damage[i] = spell.SpellMetrics[dummy.UnitIndex].TotalDamage
+	}
+	return damage
+}
+
+func TestRaidDamageAoEHitsAllDummies(t *testing.T) {
+	rsr := raidDamageTestRequest(&proto.RaidDamageEvent{
+		Type:            proto.RaidDamageEventType_RaidDamageEventTypeAoE,
+		SpellSchool:     proto.SpellSchool_SpellSchoolFire,
+		Damage:          1000,
+		IntervalSeconds: 2,
+	})
+
+	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
+	sim := newSimWithEnv(env, rsr.SimOptions, simsignals.CreateSignals())
+	sim.runOnce()
+
+	// Hits at 2s, 4s, ..., 20s.
+	for i, damage := range raidDamageTaken(env, 1) {
+		if damage != 10000 {
+			t.Fatalf("Dummy %d: expected 10000 damage taken but found %0.0f", i, damage)
+		}
+		if health := env.Raid.AllPlayerUnits[i].CurrentHealth(); health != 0 {
+			t.Fatalf("Dummy %d: expected 0 health but found %0.0f", i, health)
+		}
+	}
+}
+
+func TestRaidDamageSpikesAndTankMelee(t *testing.T) {
+	rsr := raidDamageTestRequest(
+		&proto.RaidDamageEvent{
+			Type:            proto.RaidDamageEventType_RaidDamageEventTypeRandomSpike,
+			Damage:          500,
+			IntervalSeconds: 1,
+			NumTargets:      2,
+		},
+		&proto.RaidDamageEvent{
+			Type:            proto.RaidDamageEventType_RaidDamageEventTypeTankMelee,
+			Damage:          100,
+			IntervalSeconds: 1.5,
+		},
+	)
+
+	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
+	sim := newSimWithEnv(env, rsr.SimOptions, simsignals.CreateSignals())
+	sim.runOnce()
+
+	totalSpikeDamage := 0.0
+	for _, damage := range raidDamageTaken(env, 1) {
+		totalSpikeDamage += damage
+	}
+	if totalSpikeDamage != 20*2*500 {
+		t.Fatalf("Expected %d spike damage but found %0.0f", 20*2*500, totalSpikeDamage)
+	}
+
+	meleeDamage := raidDamageTaken(env, 2)
+	if meleeDamage[0] != 13*100 {
+		t.Fatalf("Expected %d tank melee damage but found %0.0f", 13*100, meleeDamage[0])
+	}
+	for i, damage := range meleeDamage[1:] {
+		if damage != 0 {
+			t.Fatalf("Dummy %d: expected no tank melee damage but found %0.0f", i+2, damage)
+		}
+	}
+}
+
+func TestRaidDamageLowHealthMembers(t *testing.T) {
+	rsr := raidDamageTestRequest(&proto.RaidDamageEvent{
+		Type:            proto.RaidDamageEventType_RaidDamageEventTypeAoE,
+		Damage:          6000,
+		IntervalSeconds: 10,
+	})
+
+	result := RunRaidSim(rsr)
+	if result.Error != nil {
+		t.Fatalf("Sim failed: %s", result.Error.Message)
+	}
+
+	// All 4 dummies drop below 50% at 10s and stay there for the rest of the fight.
+	if lowHealth := result.RaidMetrics.LowHealthMembers.Avg; lowHealth < 1.9 || lowHealth > 2.1 {
+		t.Fatalf("Expected an average of 2 low health raid members but found %0.3f", lowHealth)
+	}
+}
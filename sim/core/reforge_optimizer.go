@@ -0,0 +1,625 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"slices"
+	"sort"
+
+	goproto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+const (
+	reforgeOptimizerMaxNodes = 200000
+
+	// Amount of each gear stat that is added to measure its effect on capped stats.
+	reforgeOptimizerStatProbe = 1000.0
+
+	defaultReforgeOptimizerSimIterations = 3000
+)
+
+// Rating stats used to value capped PseudoStats that have no EP of their own.
+var reforgeOptimizerPseudoStatRatings = map[proto.PseudoStat]stats.Stat{
+	proto.PseudoStat_PseudoStatPhysicalHitPercent:  stats.HitRating,
+	proto.PseudoStat_PseudoStatSpellHitPercent:     stats.HitRating,
+	proto.PseudoStat_PseudoStatPhysicalCritPercent: stats.CritRating,
+	proto.PseudoStat_PseudoStatSpellCritPercent:    stats.CritRating,
+	proto.PseudoStat_PseudoStatMeleeHastePercent:   stats.HasteRating,
+	proto.PseudoStat_PseudoStatRangedHastePercent:  stats.HasteRating,
+	proto.PseudoStat_PseudoStatSpellHastePercent:   stats.HasteRating,
+	proto.PseudoStat_PseudoStatDodgePercent:        stats.DodgeRating,
+	proto.PseudoStat_PseudoStatParryPercent:        stats.ParryRating,
+}
+
+// A single reforge, gem or socket bonus choice of the optimizer.
+type reforgeOptimizerOption struct {
+	Slot        proto.ItemSlot
+	ReforgeID   int32
+	Socket      int // -1 if this is not a gem
+	GemID       int32
+	SocketBonus bool
+	Stats       stats.Stats // Gear stats added by this option
+
+	variable int
+}
+
+// A stat whose value changes at breakpoints, measured on the final character stats.
+type reforgeOptimizerCap struct {
+	UnitStat    *proto.UIStat
+	Breakpoints []float64
+	CapType     proto.StatCapType
+
+	// Value per final stat point, before the first breakpoint and after each breakpoint.
+	PreCapEP   float64
+	PostCapEPs []float64
+
+	base     float64
+	gradient stats.Stats // Change of the final value per point of each gear stat
+}
+
+type reforgeOptimizer struct {
+	request   *proto.ReforgeOptimizerRequest
+	baseSim   *proto.RaidSimRequest
+	epWeights stats.Stats
+	options   []*reforgeOptimizerOption
+	caps      []*reforgeOptimizerCap
+	model     *ilpModel
+}
+
+// OptimizeReforges finds the reforges, and optionally gems, that maximize the EP of the player in
+// the given settings while respecting stat caps and breakpoints. The best candidates can optionally
+// be verified by running full sims.
+func OptimizeReforges(request *proto.ReforgeOptimizerRequest) (result *proto.ReforgeOptimizerResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.ReforgeOptimizerResult{
+				Error: &proto.ErrorOutcome{
+					Message: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+				},
+			}
+		}
+	}()
+
+	optimizer, err := newReforgeOptimizer(request)
+	if err != nil {
+		return &proto.ReforgeOptimizerResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+
+	candidates, optimal, err := optimizer.solve(max(1, int(request.SimCandidates)))
+	if err != nil {
+		return &proto.ReforgeOptimizerResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+
+	if request.SimCandidates > 0 {
+		if err := optimizer.simCandidates(candidates); err != nil {
+			return &proto.ReforgeOptimizerResult{Error: err}
+		}
+	}
+
+	return &proto.ReforgeOptimizerResult{
+		Candidates: candidates,
+		Optimal:    optimal,
+	}
+}
+
+func newReforgeOptimizer(request *proto.ReforgeOptimizerRequest) (*reforgeOptimizer, error) {
+	settings := request.GetSettings()
+	if settings.GetPlayer().GetEquipment() == nil {
+		return nil, fmt.Errorf("reforge optimizer: settings have no player equipment")
+	}
+	if settings.Player.GetDatabase() != nil {
+		addToDatabase(settings.Player.GetDatabase())
+	}
+
+	epWeights := request.EpWeights
+	if epWeights == nil {
+		epWeights = settings.EpWeightsStats
+	}
+	if epWeights == nil {
+		return nil, fmt.Errorf("reforge optimizer: no EP weights provided")
+	}
+
+	optimizer := &reforgeOptimizer{
+		request:   request,
+		baseSim:   newRaidSimRequestFromSettings(settings),
+		epWeights: stats.FromProtoArray(epWeights.Stats),
+	}
+
+	optimizer.buildOptions()
+
+	if err := optimizer.buildCaps(epWeights); err != nil {
+		return nil, err
+	}
+
+	optimizer.buildModel()
+	return optimizer, nil
+}
+
+// Builds a single player sim request from individual sim settings.
+func newRaidSimRequestFromSettings(settings *proto.IndividualSimSettings) *proto.RaidSimRequest {
+	player := goproto.Clone(settings.Player).(*proto.Player)
+	player.Database = nil
+
+	return &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{player},
+					Buffs:   settings.PartyBuffs,
+				},
+			},
+			Buffs:         settings.RaidBuffs,
+			Debuffs:       settings.Debuffs,
+			Tanks:         settings.Tanks,
+			TargetDummies: settings.TargetDummies,
+		},
+		Encounter: settings.Encounter,
+		SimOptions: &proto.SimOptions{
+			Iterations: settings.GetSettings().GetIterations(),
+			RandomSeed: settings.GetSettings().GetFixedRngSeed(),
+		},
+	}
+}
+
+func (optimizer *reforgeOptimizer) player() *proto.Player {
+	return optimizer.baseSim.Raid.Parties[0].Players[0]
+}
+
+// Collects all reforge and gem choices, and strips the current choices from the base request.
+func (optimizer *reforgeOptimizer) buildOptions() {
+	equipment := optimizer.player().Equipment
+	gemOptions := make([]Gem, 0, len(optimizer.request.GemOptions))
+	for _, gemID := range optimizer.request.GemOptions {
+		gem, ok := GemsByID[gemID]
+		if !ok {
+			panic(fmt.Sprintf("Unknown gem with id %d in reforge optimizer gem options", gemID))
+		}
+		gemOptions = append(gemOptions, gem)
+	}
+
+	reforgeIDs := make([]int32, 0, len(ReforgeStatsByID))
+	for id := range ReforgeStatsByID {
+		reforgeIDs = append(reforgeIDs, id)
+	}
+	slices.Sort(reforgeIDs)
+
+	for slotIdx, itemSpec := range equipment.Items {
+		slot := proto.ItemSlot(slotIdx)
+		if itemSpec == nil || itemSpec.Id == 0 || slices.Contains(optimizer.request.FrozenSlots, slot) {
+			continue
+		}
+
+		itemSpec.Reforging = 0
+		item := NewItem(ProtoToEquipmentSpec(equipment)[slotIdx])
+
+		itemStats := item.Stats.Add(item.RandomSuffix.Stats.Multiply(float64(item.RandPropPoints) / 10000.).Floor())
+		for _, id := range reforgeIDs {
+			reforge := ReforgeStatsByID[id]
+			if !validateReforging(&item, reforge) {
+				continue
+			}
+			amount := math.Floor(itemStats[reforge.FromStat] * reforge.Multiplier)
+			option := &reforgeOptimizerOption{Slot: slot, ReforgeID: id, Socket: -1}
+			option.Stats[reforge.FromStat] = -amount
+			option.Stats[reforge.ToStat] = amount
+			optimizer.options = append(optimizer.options, option)
+		}
+
+		if len(gemOptions) > 0 {
+			optimizer.buildGemOptions(slot, itemSpec, &item, gemOptions)
+		}
+	}
+}
+
+func (optimizer *reforgeOptimizer) buildGemOptions(slot proto.ItemSlot, itemSpec *proto.ItemSpec, item *Item, gemOptions []Gem) {
+	numSockets := max(len(item.GemSockets), len(itemSpec.Gems))
+	if len(itemSpec.Gems) < numSockets {
+		itemSpec.Gems = append(itemSpec.Gems, make([]int32, numSockets-len(itemSpec.Gems))...)
+	}
+
+	socketBonusPossible := len(item.GemSockets) > 0 && item.SocketBonus != stats.Stats{}
+	anyOptimizedSocket := false
+
+	for socket := 0; socket < numSockets; socket++ {
+		// Extra sockets from belt buckles or blacksmithing accept any gem.
+		socketColor := proto.GemColor_GemColorPrismatic
+		if socket < len(item.GemSockets) {
+			socketColor = item.GemSockets[socket]
+		}
+
+		var candidates []Gem
+		for _, gem := range gemOptions {
+			if canSocketGem(socketColor, gem.Color) {
+				candidates = append(candidates, gem)
+			}
+		}
+
+		if len(candidates) == 0 {
+			if socket < len(item.GemSockets) {
+				gem, ok := GemsByID[itemSpec.Gems[socket]]
+				socketBonusPossible = socketBonusPossible && ok && ColorIntersects(socketColor, gem.Color)
+			}
+			continue
+		}
+
+		itemSpec.Gems[socket] = 0
+		anyOptimizedSocket = true
+		for _, gem := range candidates {
+			optimizer.options = append(optimizer.options, &reforgeOptimizerOption{
+				Slot:   slot,
+				Socket: socket,
+				GemID:  gem.ID,
+				Stats:  gem.Stats,
+			})
+		}
+	}
+
+	if socketBonusPossible && anyOptimizedSocket {
+		optimizer.options = append(optimizer.options, &reforgeOptimizerOption{
+			Slot:        slot,
+			Socket:      -1,
+			SocketBonus: true,
+			Stats:       item.SocketBonus,
+		})
+	}
+}
+
+// Returns whether a gem of the given color can be socketed by the optimizer. Meta and Sha-Touched
+// sockets are never touched, and cogwheel sockets only accept cogwheels.
+func canSocketGem(socketColor proto.GemColor, gemColor proto.GemColor) bool {
+	switch socketColor {
+	case proto.GemColor_GemColorMeta, proto.GemColor_GemColorShaTouched:
+		return false
+	case proto.GemColor_GemColorCogwheel:
+		return gemColor == proto.GemColor_GemColorCogwheel
+	}
+	switch gemColor {
+	case proto.GemColor_GemColorMeta, proto.GemColor_GemColorShaTouched, proto.GemColor_GemColorCogwheel:
+		return false
+	}
+	return true
+}
+
+// Collects the configured caps and measures how every gear stat affects them.
+func (optimizer *reforgeOptimizer) buildCaps(epWeights *proto.UnitStats) error {
+	for _, config := range optimizer.request.Breakpoints {
+		if len(config.Breakpoints) == 0 {
+			continue
+		}
+		if config.CapType != proto.StatCapType_TypeSoftCap && config.CapType != proto.StatCapType_TypeThreshold {
+			return fmt.Errorf("reforge optimizer: unsupported cap type %s", config.CapType)
+		}
+		if len(config.PostCap_EPs) == 0 {
+			return fmt.Errorf("reforge optimizer: breakpoints for %v have no post-cap EPs", config.UnitStat)
+		}
+		optimizer.caps = append(optimizer.caps, &reforgeOptimizerCap{
+			UnitStat:    config.UnitStat,
+			Breakpoints: config.Breakpoints,
+			CapType:     config.CapType,
+			PostCapEPs:  config.PostCap_EPs,
+		})
+	}
+
+	statCaps := optimizer.request.StatCaps
+	if statCaps == nil {
+		statCaps = optimizer.request.GetSettings().GetStatCaps()
+	}
+	for stat, value := range statCaps.GetStats() {
+		optimizer.addHardCap(&proto.UIStat{UnitStat: &proto.UIStat_Stat{Stat: proto.Stat(stat)}}, value)
+	}
+	for pseudoStat, value := range statCaps.GetPseudoStats() {
+		optimizer.addHardCap(&proto.UIStat{UnitStat: &proto.UIStat_PseudoStat{PseudoStat: proto.PseudoStat(pseudoStat)}}, value)
+	}
+
+	if len(optimizer.caps) == 0 {
+		return nil
+	}
+
+	baseStats := computePlayerFinalStats(optimizer.baseSim)
+	for _, statCap := range optimizer.caps {
+		statCap.base = unitStatValue(baseStats, statCap.UnitStat)
+	}
+
+	var probedStats stats.Stats
+	for _, option := range optimizer.options {
+		for stat, value := range option.Stats {
+			if value != 0 {
+				probedStats[stat] = 1
+			}
+		}
+	}
+	for stat := range probedStats {
+		if probedStats[stat] == 0 {
+			continue
+		}
+		request := goproto.Clone(optimizer.baseSim).(*proto.RaidSimRequest)
+		player := request.Raid.Parties[0].Players[0]
+		if player.BonusStats == nil {
+			player.BonusStats = &proto.UnitStats{}
+		}
+		if len(player.BonusStats.Stats) < stats.ProtoStatsLen {
+			player.BonusStats.Stats = append(player.BonusStats.Stats, make([]float64, stats.ProtoStatsLen-len(player.BonusStats.Stats))...)
+		}
+		player.BonusStats.Stats[stat] += reforgeOptimizerStatProbe
+
+		probeStats := computePlayerFinalStats(request)
+		for _, statCap := range optimizer.caps {
+			statCap.gradient[stat] = (unitStatValue(probeStats, statCap.UnitStat) - statCap.base) / reforgeOptimizerStatProbe
+		}
+	}
+
+	pseudoWeights := epWeights.GetPseudoStats()
+	for _, statCap := range optimizer.caps {
+		if err := statCap.computePreCapEP(optimizer.epWeights, pseudoWeights); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (optimizer *reforgeOptimizer) addHardCap(unitStat *proto.UIStat, value float64) {
+	if value <= 0 {
+		return
+	}
+	for _, statCap := range optimizer.caps {
+		if goproto.Equal(statCap.UnitStat, unitStat) {
+			return
+		}
+	}
+	optimizer.caps = append(optimizer.caps, &reforgeOptimizerCap{
+		UnitStat:    unitStat,
+		Breakpoints: []float64{value},
+		CapType:     proto.StatCapType_TypeSoftCap,
+		PostCapEPs:  []float64{0},
+	})
+}
+
+// Converts the EP of the capped stat into value per final stat point.
+func (statCap *reforgeOptimizerCap) computePreCapEP(epWeights stats.Stats, pseudoWeights []float64) error {
+	switch unitStat := statCap.UnitStat.GetUnitStat().(type) {
+	case *proto.UIStat_Stat:
+		stat := stats.Stat(unitStat.Stat)
+		statCap.PreCapEP = epWeights[stat]
+		if statCap.gradient[stat] > 0 {
+			statCap.PreCapEP /= statCap.gradient[stat]
+		}
+	case *proto.UIStat_PseudoStat:
+		if int(unitStat.PseudoStat) < len(pseudoWeights) && pseudoWeights[unitStat.PseudoStat] != 0 {
+			statCap.PreCapEP = pseudoWeights[unitStat.PseudoStat]
+			break
+		}
+		rating, ok := reforgeOptimizerPseudoStatRatings[unitStat.PseudoStat]
+		if !ok {
+			return fmt.Errorf("reforge optimizer: cannot cap %s", unitStat.PseudoStat)
+		}
+		if statCap.gradient[rating] > 0 {
+			statCap.PreCapEP = epWeights[rating] / statCap.gradient[rating]
+		}
+	default:
+		return fmt.Errorf("reforge optimizer: cap without a stat")
+	}
+	return nil
+}
+
+// Change of the capped value caused by the given gear stats.
+func (statCap *reforgeOptimizerCap) delta(gearStats stats.Stats) float64 {
+	delta := 0.0
+	for stat, value := range gearStats {
+		delta += statCap.gradient[stat] * value
+	}
+	return delta
+}
+
+func unitStatValue(unitStats *proto.UnitStats, unitStat *proto.UIStat) float64 {
+	switch unitStat := unitStat.GetUnitStat().(type) {
+	case *proto.UIStat_Stat:
+		return unitStats.Stats[unitStat.Stat]
+	case *proto.UIStat_PseudoStat:
+		return unitStats.PseudoStats[unitStat.PseudoStat]
+	}
+	return 0
+}
+
+func computePlayerFinalStats(request *proto.RaidSimRequest) *proto.UnitStats {
+	request = goproto.Clone(request).(*proto.RaidSimRequest)
+	result := ComputeStats(&proto.ComputeStatsRequest{
+		Raid:      request.Raid,
+		Encounter: request.Encounter,
+	})
+	return result.RaidStats.Parties[0].Players[0].FinalStats
+}
+
+// Builds the integer program: one binary variable per option, at most one reforge per item, exactly
+// one gem per optimized socket, and auxiliary variables for the value of capped stats.
+func (optimizer *reforgeOptimizer) buildModel() {
+	model := &ilpModel{}
+
+	reforgesBySlot := map[proto.ItemSlot][]ilpTerm{}
+	gemsBySocket := map[proto.ItemSlot]map[int][]*reforgeOptimizerOption{}
+	for _, option := range optimizer.options {
+		score := 0.0
+		for stat, value := range option.Stats {
+			score += optimizer.epWeights[stat] * value
+		}
+		for _, statCap := range optimizer.caps {
+			if statCap.CapType == proto.StatCapType_TypeThreshold {
+				// Threshold stats are only worth their post-cap EP outside of reaching thresholds.
+				score += (statCap.PostCapEPs[0] - statCap.PreCapEP) * statCap.delta(option.Stats)
+			}
+		}
+		option.variable = model.addVariable(score, true)
+
+		switch {
+		case option.ReforgeID != 0:
+			reforgesBySlot[option.Slot] = append(reforgesBySlot[option.Slot], ilpTerm{option.variable, 1})
+		case option.GemID != 0:
+			if gemsBySocket[option.Slot] == nil {
+				gemsBySocket[option.Slot] = map[int][]*reforgeOptimizerOption{}
+			}
+			gemsBySocket[option.Slot][option.Socket] = append(gemsBySocket[option.Slot][option.Socket], option)
+		}
+	}
+
+	for _, slot := range sortedKeys(reforgesBySlot) {
+		model.addConstraint(reforgesBySlot[slot], ilpLessEq, 1)
+	}
+
+	for _, slot := range sortedKeys(gemsBySocket) {
+		sockets := gemsBySocket[slot]
+		for _, socket := range sortedKeys(sockets) {
+			terms := make([]ilpTerm, 0, len(sockets[socket]))
+			for _, option := range sockets[socket] {
+				terms = append(terms, ilpTerm{option.variable, 1})
+			}
+			model.addConstraint(terms, ilpEqual, 1)
+		}
+	}
+
+	// The socket bonus is only active if every socket holds a matching gem.
+	for _, bonus := range optimizer.options {
+		if !bonus.SocketBonus {
+			continue
+		}
+		item := ItemsByID[optimizer.player().Equipment.Items[bonus.Slot].Id]
+		sockets := gemsBySocket[bonus.Slot]
+		for _, socket := range sortedKeys(sockets) {
+			if socket >= len(item.GemSockets) {
+				continue
+			}
+			terms := []ilpTerm{{bonus.variable, 1}}
+			for _, option := range sockets[socket] {
+				if ColorIntersects(item.GemSockets[socket], GemsByID[option.GemID].Color) {
+					terms = append(terms, ilpTerm{option.variable, -1})
+				}
+			}
+			model.addConstraint(terms, ilpLessEq, 0)
+		}
+		model.addConstraint([]ilpTerm{{bonus.variable, 1}}, ilpLessEq, 1)
+	}
+
+	for _, statCap := range optimizer.caps {
+		var terms []ilpTerm
+		for _, option := range optimizer.options {
+			if delta := statCap.delta(option.Stats); delta != 0 {
+				terms = append(terms, ilpTerm{option.variable, delta})
+			}
+		}
+
+		switch statCap.CapType {
+		case proto.StatCapType_TypeSoftCap:
+			// Every point past a breakpoint loses the EP difference to the previous tier. Overage
+			// variables are pushed to their minimum by the objective, so the value is concave.
+			prevEP := statCap.PreCapEP
+			for i, breakpoint := range statCap.Breakpoints {
+				postEP := statCap.PostCapEPs[min(i, len(statCap.PostCapEPs)-1)]
+				postEP = min(postEP, prevEP)
+				overage := model.addVariable(postEP-prevEP, false)
+				model.addConstraint(append(slices.Clone(terms), ilpTerm{overage, -1}), ilpLessEq, breakpoint-statCap.base)
+				prevEP = postEP
+			}
+		case proto.StatCapType_TypeThreshold:
+			// Reaching a threshold is worth the pre-cap EP of every point up to it.
+			prevBreakpoint := 0.0
+			for _, breakpoint := range statCap.Breakpoints {
+				reached := model.addVariable((statCap.PreCapEP-statCap.PostCapEPs[0])*(breakpoint-prevBreakpoint), true)
+				model.addConstraint(append(slices.Clone(terms), ilpTerm{reached, -breakpoint}), ilpGreaterEq, -statCap.base)
+				model.addConstraint([]ilpTerm{{reached, 1}}, ilpLessEq, 1)
+				prevBreakpoint = breakpoint
+			}
+		}
+	}
+
+	optimizer.model = model
+}
+
+func sortedKeys[K proto.ItemSlot | int, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Solves for the best numCandidates distinct choices of reforges and gems. Also returns whether
+// every candidate was proven optimal within the node limit.
+func (optimizer *reforgeOptimizer) solve(numCandidates int) ([]*proto.ReforgeOptimizerCandidate, bool, error) {
+	var candidates []*proto.ReforgeOptimizerCandidate
+	optimal := true
+	for len(candidates) < numCandidates {
+		solution, err := optimizer.model.solve(reforgeOptimizerMaxNodes)
+		if err == errILPInfeasible && len(candidates) > 0 {
+			break
+		} else if err != nil {
+			return nil, false, fmt.Errorf("reforge optimizer: %w", err)
+		}
+		optimal = optimal && solution.Optimal
+
+		equipment := goproto.Clone(optimizer.player().Equipment).(*proto.EquipmentSpec)
+		var chosen []ilpTerm
+		numChosen := 0
+		for _, option := range optimizer.options {
+			if option.SocketBonus {
+				continue
+			}
+			if solution.Values[option.variable] < 0.5 {
+				chosen = append(chosen, ilpTerm{option.variable, -1})
+				continue
+			}
+			chosen = append(chosen, ilpTerm{option.variable, 1})
+			numChosen++
+			if option.ReforgeID != 0 {
+				equipment.Items[option.Slot].Reforging = option.ReforgeID
+			} else {
+				equipment.Items[option.Slot].Gems[option.Socket] = option.GemID
+			}
+		}
+
+		request := goproto.Clone(optimizer.baseSim).(*proto.RaidSimRequest)
+		request.Raid.Parties[0].Players[0].Equipment = equipment
+		candidates = append(candidates, &proto.ReforgeOptimizerCandidate{
+			Equipment:  equipment,
+			Score:      solution.Value,
+			FinalStats: computePlayerFinalStats(request),
+		})
+
+		if len(chosen) == 0 {
+			break
+		}
+		// Exclude this exact set of choices from the next solve.
+		optimizer.model.addConstraint(chosen, ilpLessEq, float64(numChosen-1))
+	}
+	return candidates, optimal, nil
+}
+
+// Runs a full sim for every candidate and ranks them by DPS.
+func (optimizer *reforgeOptimizer) simCandidates(candidates []*proto.ReforgeOptimizerCandidate) *proto.ErrorOutcome {
+	iterations := optimizer.request.SimIterations
+	if iterations <= 0 {
+		iterations = optimizer.baseSim.SimOptions.Iterations
+	}
+	if iterations <= 0 {
+		iterations = defaultReforgeOptimizerSimIterations
+	}
+
+	for _, candidate := range candidates {
+		request := goproto.Clone(optimizer.baseSim).(*proto.RaidSimRequest)
+		request.Raid.Parties[0].Players[0].Equipment = candidate.Equipment
+		request.SimOptions.Iterations = iterations
+
+		result := RunRaidSimConcurrent(request)
+		if result.Error != nil {
+			return result.Error
+		}
+		candidate.Dps = result.RaidMetrics.Dps
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Dps.Avg > candidates[j].Dps.Avg
+	})
+	return nil
+}
@@ -0,0 +1,263 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func TestILPSolverKnapsack(t *testing.T) {
+	// Classic 0/1 knapsack where the linear relaxation would take a fraction of the densest item.
+	model := &ilpModel{}
+	values := []float64{60, 100, 120}
+	weights := []float64{10, 20, 30}
+	var terms []ilpTerm
+	for i := range values {
+		variable := model.addVariable(values[i], true)
+		terms = append(terms, ilpTerm{variable, weights[i]})
+		model.addConstraint([]ilpTerm{{variable, 1}}, ilpLessEq, 1)
+	}
+	model.addConstraint(terms, ilpLessEq, 50)
+
+	solution, err := model.solve(1000)
+	if err != nil {
+		t.Fatalf("solve() returned error: %v", err)
+	}
+	if math.Abs(solution.Value-220) > 1e-6 {
+		t.Errorf("solve() value = %v, want 220", solution.Value)
+	}
+	if solution.Values[0] != 0 || solution.Values[1] != 1 || solution.Values[2] != 1 {
+		t.Errorf("solve() values = %v, want [0 1 1]", solution.Values)
+	}
+}
+
+func TestILPSolverInfeasible(t *testing.T) {
+	model := &ilpModel{}
+	x := model.addVariable(1, true)
+	model.addConstraint([]ilpTerm{{x, 1}}, ilpLessEq, 1)
+	model.addConstraint([]ilpTerm{{x, 2}}, ilpEqual, 1)
+
+	if _, err := model.solve(1000); err != errILPInfeasible {
+		t.Fatalf("solve() error = %v, want %v", err, errILPInfeasible)
+	}
+}
+
+func TestILPSolverNodeLimit(t *testing.T) {
+	model := &ilpModel{}
+	values := []float64{60, 100, 120}
+	weights := []float64{10, 20, 30}
+	var terms []ilpTerm
+	for i := range values {
+		variable := model.addVariable(values[i], true)
+		terms = append(terms, ilpTerm{variable, weights[i]})
+		model.addConstraint([]ilpTerm{{variable, 1}}, ilpLessEq, 1)
+	}
+	model.addConstraint(terms, ilpLessEq, 50)
+
+	if _, err := model.solve(1); err != errILPNodeLimit {
+		t.Fatalf("solve() error = %v, want %v", err, errILPNodeLimit)
+	}
+
+	// Enough nodes to find an incumbent, but not to prove it optimal.
+	solution, err := model.solve(3)
+	if err != nil {
+		t.Fatalf("solve() returned error: %v", err)
+	}
+	if solution.Optimal {
+		t.Errorf("solve() reported an optimal solution after hitting the node limit")
+	}
+
+	if solution, err = model.solve(1000); err != nil || !solution.Optimal {
+		t.Errorf("solve() = %v, %v, want an optimal solution", solution, err)
+	}
+}
+
+func TestReforgeOptimizerHitCap(t *testing.T) {
+	newReforge := func(slot proto.ItemSlot, from stats.Stat, to stats.Stat, amount float64) *reforgeOptimizerOption {
+		option := &reforgeOptimizerOption{Slot: slot, ReforgeID: int32(from)*100 + int32(to), Socket: -1}
+		option.Stats[from] = -amount
+		option.Stats[to] = amount
+		return option
+	}
+
+	var epWeights stats.Stats
+	epWeights[stats.HitRating] = 2
+	epWeights[stats.CritRating] = 1
+	epWeights[stats.HasteRating] = 1.5
+
+	hitCap := &reforgeOptimizerCap{
+		UnitStat:    &proto.UIStat{UnitStat: &proto.UIStat_Stat{Stat: proto.Stat_StatHitRating}},
+		Breakpoints: []float64{1000},
+		CapType:     proto.StatCapType_TypeSoftCap,
+		PostCapEPs:  []float64{0},
+		base:        800,
+	}
+	hitCap.gradient[stats.HitRating] = 1
+	if err := hitCap.computePreCapEP(epWeights, nil); err != nil {
+		t.Fatalf("computePreCapEP() returned error: %v", err)
+	}
+
+	optimizer := &reforgeOptimizer{
+		epWeights: epWeights,
+		caps:      []*reforgeOptimizerCap{hitCap},
+		options: []*reforgeOptimizerOption{
+			// Only 200 hit is needed to cap, so only one of the two hit reforges is worth it.
+			newReforge(proto.ItemSlot_ItemSlotHead, stats.CritRating, stats.HitRating, 150),
+			newReforge(proto.ItemSlot_ItemSlotHead, stats.CritRating, stats.HasteRating, 150),
+			newReforge(proto.ItemSlot_ItemSlotChest, stats.CritRating, stats.HitRating, 200),
+			newReforge(proto.ItemSlot_ItemSlotChest, stats.CritRating, stats.HasteRating, 200),
+		},
+	}
+	optimizer.buildModel()
+
+	solution, err := optimizer.model.solve(reforgeOptimizerMaxNodes)
+	if err != nil {
+		t.Fatalf("solve() returned error: %v", err)
+	}
+
+	var chosen []int
+	for i, option := range optimizer.options {
+		if solution.Values[option.variable] > 0.5 {
+			chosen = append(chosen, i)
+		}
+	}
+	// Chest to hit exactly caps, and head is better off reforged to haste.
+	if len(chosen) != 2 || chosen[0] != 1 || chosen[1] != 2 {
+		t.Errorf("Expected options [1 2] to be chosen, got %v", chosen)
+	}
+	if want := 150*0.5 + 200*1.0; math.Abs(solution.Value-want) > 1e-6 {
+		t.Errorf("solve() value = %v, want %v", solution.Value, want)
+	}
+}
+
+func TestReforgeOptimizerSocketBonus(t *testing.T) {
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: 990101, Type: proto.ItemType_ItemTypeHead, GemSockets: []proto.GemColor{proto.GemColor_GemColorRed, proto.GemColor_GemColorBlue}},
+		},
+		Gems: []*proto.SimGem{
+			{Id: 990102, Color: proto.GemColor_GemColorRed},
+			{Id: 990103, Color: proto.GemColor_GemColorPurple},
+		},
+	})
+
+	var redStats, purpleStats, bonusStats stats.Stats
+	redStats[stats.Strength] = 160
+	purpleStats[stats.Strength] = 80
+	purpleStats[stats.Stamina] = 120
+	bonusStats[stats.Strength] = 100
+
+	var epWeights stats.Stats
+	epWeights[stats.Strength] = 1
+	epWeights[stats.Stamina] = 0.1
+
+	optimizer := &reforgeOptimizer{
+		baseSim: &proto.RaidSimRequest{
+			Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{{
+				Equipment: &proto.EquipmentSpec{Items: []*proto.ItemSpec{{Id: 990101, Gems: []int32{0, 0}}}},
+			}}}}},
+		},
+		epWeights: epWeights,
+	}
+	for socket := 0; socket < 2; socket++ {
+		optimizer.options = append(optimizer.options,
+			&reforgeOptimizerOption{Slot: proto.ItemSlot_ItemSlotHead, Socket: socket, GemID: 990102, Stats: redStats},
+			&reforgeOptimizerOption{Slot: proto.ItemSlot_ItemSlotHead, Socket: socket, GemID: 990103, Stats: purpleStats},
+		)
+	}
+	optimizer.options = append(optimizer.options, &reforgeOptimizerOption{Slot: proto.ItemSlot_ItemSlotHead, Socket: -1, SocketBonus: true, Stats: bonusStats})
+	optimizer.buildModel()
+
+	solution, err := optimizer.model.solve(reforgeOptimizerMaxNodes)
+	if err != nil {
+		t.Fatalf("solve() returned error: %v", err)
+	}
+
+	// Red + purple with the bonus (160 + 92 + 100) beats two reds without the bonus (320).
+	if want := 160 + 92 + 100.0; math.Abs(solution.Value-want) > 1e-6 {
+		t.Errorf("solve() value = %v, want %v", solution.Value, want)
+	}
+	if solution.Values[optimizer.options[0].variable] != 1 || solution.Values[optimizer.options[3].variable] != 1 {
+		t.Errorf("Expected a red gem in the red socket and a purple gem in the blue socket, got %v", solution.Values)
+	}
+}
+
+func TestOptimizeReforges(t *testing.T) {
+	newItem := func(id int32, itemType proto.ItemType, crit float64) *proto.SimItem {
+		return &proto.SimItem{
+			Id:   id,
+			Type: itemType,
+			ScalingOptions: map[int32]*proto.ScalingItemProperties{
+				int32(proto.ItemLevelState_Base): {
+					Ilvl:  496,
+					Stats: map[int32]float64{int32(proto.Stat_StatCritRating): crit},
+				},
+			},
+		}
+	}
+	newReforge := func(id int32, from proto.Stat, to proto.Stat) *proto.ReforgeStat {
+		return &proto.ReforgeStat{Id: id, FromStat: from, ToStat: to, Multiplier: 0.4}
+	}
+
+	epWeights := make([]float64, stats.ProtoStatsLen)
+	epWeights[stats.HitRating] = 2
+	epWeights[stats.CritRating] = 1
+	epWeights[stats.HasteRating] = 1.5
+	statCaps := make([]float64, stats.ProtoStatsLen)
+	statCaps[stats.HitRating] = 400
+
+	equipment := &proto.EquipmentSpec{Items: make([]*proto.ItemSpec, len(proto.ItemSlot_name))}
+	equipment.Items[proto.ItemSlot_ItemSlotHead] = &proto.ItemSpec{Id: 990301}
+	equipment.Items[proto.ItemSlot_ItemSlotChest] = &proto.ItemSpec{Id: 990302}
+
+	result := OptimizeReforges(&proto.ReforgeOptimizerRequest{
+		Settings: &proto.IndividualSimSettings{
+			Player: &proto.Player{
+				Name:      "Reforger",
+				Class:     proto.Class_ClassShaman,
+				Race:      proto.Race_RaceTroll,
+				Equipment: equipment,
+				Spec:      &proto.Player_ElementalShaman{ElementalShaman: &proto.ElementalShaman{}},
+				Database: &proto.SimDatabase{
+					Items: []*proto.SimItem{
+						newItem(990301, proto.ItemType_ItemTypeHead, 1000),
+						newItem(990302, proto.ItemType_ItemTypeChest, 500),
+					},
+					ReforgeStats: []*proto.ReforgeStat{
+						newReforge(144, proto.Stat_StatCritRating, proto.Stat_StatHitRating),
+						newReforge(145, proto.Stat_StatCritRating, proto.Stat_StatHasteRating),
+					},
+				},
+			},
+			Encounter: &proto.Encounter{
+				Duration: 180,
+				Targets:  []*proto.Target{{Level: 93}},
+			},
+		},
+		EpWeights: &proto.UnitStats{Stats: epWeights},
+		StatCaps:  &proto.UnitStats{Stats: statCaps},
+	})
+	if result.Error != nil {
+		t.Fatalf("OptimizeReforges() returned error: %s", result.Error.Message)
+	}
+	if !result.Optimal {
+		t.Errorf("OptimizeReforges() did not prove its result optimal")
+	}
+	if len(result.Candidates) != 1 {
+		t.Fatalf("OptimizeReforges() returned %d candidates, want 1", len(result.Candidates))
+	}
+
+	// The head alone reaches the hit cap, so the chest goes to haste.
+	best := result.Candidates[0]
+	if head, chest := best.Equipment.Items[proto.ItemSlot_ItemSlotHead], best.Equipment.Items[proto.ItemSlot_ItemSlotChest]; head.Reforging != 144 || chest.Reforging != 145 {
+		t.Errorf("OptimizeReforges() chose reforges %d and %d, want 144 and 145", head.Reforging, chest.Reforging)
+	}
+	if want := 400*1.0 + 200*0.5; math.Abs(best.Score-want) > 1e-6 {
+		t.Errorf("OptimizeReforges() score = %v, want %v", best.Score, want)
+	}
+	if hit := best.FinalStats.Stats[stats.HitRating]; hit != 400 {
+		t.Errorf("OptimizeReforges() candidate has %v hit rating, want 400", hit)
+	}
+}
@@ -19,7 +19,6 @@ type Shield struct {
 }
 
 func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
-	caster := shield.Spell.Unit
 	target := shield.Aura.Unit
 	//attackTable := caster.AttackTables[target.UnitIndex]
 
@@ -34,13 +33,19 @@ func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
 		shield.Aura.SetStacks(sim, stacks)
 	}
 
+	shield.Spell.RecordShielding(sim, target, shieldAmount)
+}
+
+// Records shielding done on target, for absorbs that are applied outside of
+// Shield.Apply, e.g. with a DamageAbsorptionAura.
+func (spell *Spell) RecordShielding(sim *Simulation, target *Unit, shieldAmount float64) {
 	threat := 0.0 // TODO
-	shield.Spell.SpellMetrics[target.UnitIndex].TotalThreat += threat
-	shield.Spell.SpellMetrics[target.UnitIndex].TotalShielding += shieldAmount
-	shield.Spell.SpellMetrics[target.UnitIndex].Hits++
+	spell.SpellMetrics[target.UnitIndex].TotalThreat += threat
+	spell.SpellMetrics[target.UnitIndex].TotalShielding += shieldAmount
+	spell.SpellMetrics[target.UnitIndex].Hits++
 
 	if sim.Log != nil {
-		caster.Log(sim, "%s %s Hit for %0.3f shielding. (Threat: %0.3f)", target.LogLabel(), shield.Spell.ActionID, shieldAmount, threat)
+		spell.Unit.Log(sim, "%s %s Hit for %0.3f shielding. (Threat: %0.3f)", target.LogLabel(), spell.ActionID, shieldAmount, threat)
 	}
 }
 
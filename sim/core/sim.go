@@ -39,12 +39,16 @@ type Simulation struct {
 	CurrentTime       time.Duration // duration that has elapsed in the sim since starting
 	Duration          time.Duration // Duration of current iteration
 	NeedsInput        bool          // Sim is in interactive mode and needs input
+	InputCharacter    *Character    // Character waiting for input, set along with NeedsInput
 
 	ProgressReport func(*proto.ProgressMetrics)
 	Signals        simsignals.Signals
 
 	Log func(string, ...interface{})
 
+	// Structured combat log, nil unless enabled for the current iteration.
+	CombatLog *CombatLog
+
 	executePhase int32 // 20, 25, 35, 45 or 90 for the respective execute range, 100 otherwise
 
 	executePhaseCallbacks []func(*Simulation, int32) // 2nd parameter is 90 for 90%, 45 for 45%, 35 for 35%, 25 for 25% and 20 for 20%
@@ -302,6 +306,10 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		}
 	}
 
+	if sim.Options.CombatLog {
+		sim.CombatLog = &CombatLog{}
+	}
+
 	// Uncomment this to print logs directly to console.
 	// sim.Options.Debug = true
 	// sim.Log = func(message string, vals ...interface{}) {
@@ -319,6 +327,9 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		sim.Log = nil
 	}
 
+	combatLog := sim.CombatLog
+	sim.CombatLog = nil
+
 	var st time.Time
 	for i := int32(1); i < sim.Options.Iterations; i++ {
 		if sim.Signals.Abort.IsTriggered() {
@@ -360,6 +371,9 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		AvgIterationDuration:   totalDuration.Seconds() / float64(sim.Options.Iterations),
 		IterationsDone:         sim.Options.Iterations,
 	}
+	if combatLog != nil {
+		result.CombatLog = combatLog.Events()
+	}
 
 	// Final progress report
 	if sim.ProgressReport != nil {
@@ -421,6 +435,8 @@ func (sim *Simulation) reset() {
 	}
 
 	sim.CurrentTime = 0
+	sim.NeedsInput = false
+	sim.InputCharacter = nil
 
 	sim.trackers = sim.trackers[:0]
 	sim.minTrackerTime = NeverExpires
@@ -42,6 +42,7 @@ func SplitSimRequestForConcurrency(request *proto.RaidSimRequest, splitCount int
 		split[i] = googleProto.Clone(request).(*proto.RaidSimRequest)
 		split[i].SimOptions.Iterations = iterPerSplit
 		split[i].SimOptions.DebugFirstIteration = false // No logs
+		split[i].SimOptions.CombatLog = false
 		split[i].SimOptions.RandomSeed = nextStartSeed
 		nextStartSeed += int64(split[i].SimOptions.Iterations)
 	}
@@ -75,11 +76,15 @@ func (rsrc *raidSimResultCombiner) newUnitMetrics(baseUnit *proto.UnitMetrics) *
 		Dtps:      rsrc.newDistMetrics(),
 		Tmi:       rsrc.newDistMetrics(),
 		Hps:       rsrc.newDistMetrics(),
+		Ehps:      rsrc.newDistMetrics(),
 		Tto:       rsrc.newDistMetrics(),
 		Actions:   make([]*proto.ActionMetrics, 0, len(baseUnit.Actions)),
 		Auras:     make([]*proto.AuraMetrics, len(baseUnit.Auras)),
 		Resources: make([]*proto.ResourceMetrics, 0, len(baseUnit.Resources)),
 		Pets:      make([]*proto.UnitMetrics, len(baseUnit.Pets)),
+
+		UnsimulatedEffects: baseUnit.UnsimulatedEffects,
+		RotationStats:      rsrc.newAPLStats(baseUnit.RotationStats),
 	}
 
 	for i, aura := range baseUnit.Auras {
@@ -96,10 +101,30 @@ func (rsrc *raidSimResultCombiner) newUnitMetrics(baseUnit *proto.UnitMetrics) *
 	return newUm
 }
 
+func (rsrc *raidSimResultCombiner) newAPLStats(baseStats *proto.APLStats) *proto.APLStats {
+	if baseStats == nil {
+		return nil
+	}
+
+	newActionStats := func(baseActions []*proto.APLActionStats) []*proto.APLActionStats {
+		return MapSlice(baseActions, func(baseAction *proto.APLActionStats) *proto.APLActionStats {
+			return &proto.APLActionStats{Profiled: baseAction.Profiled}
+		})
+	}
+	return &proto.APLStats{
+		PrepullActions: newActionStats(baseStats.PrepullActions),
+		PriorityList:   newActionStats(baseStats.PriorityList),
+		ActionLists: MapSlice(baseStats.ActionLists, func(baseList *proto.APLActionListStats) *proto.APLActionListStats {
+			return &proto.APLActionListStats{PriorityList: newActionStats(baseList.PriorityList)}
+		}),
+	}
+}
+
 func (rsrc *raidSimResultCombiner) newPartyMetrics(baseParty *proto.PartyMetrics) *proto.PartyMetrics {
 	newPm := &proto.PartyMetrics{
 		Dps:     rsrc.newDistMetrics(),
 		Hps:     rsrc.newDistMetrics(),
+		Ehps:    rsrc.newDistMetrics(),
 		Players: make([]*proto.UnitMetrics, len(baseParty.Players)),
 	}
 
@@ -194,6 +219,7 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 		baseTgt.Healing += addTgt.Healing
 		baseTgt.CritHealing += addTgt.CritHealing
 		baseTgt.Shielding += addTgt.Shielding
+		baseTgt.Overhealing += addTgt.Overhealing
 		baseTgt.CastTimeMs += addTgt.CastTimeMs
 	}
 }
@@ -236,12 +262,28 @@ func (rsrc *raidSimResultCombiner) addResourceMetrics(unit *proto.UnitMetrics, a
 	rm.ActualGain += add.ActualGain
 }
 
+func (rsrc *raidSimResultCombiner) combineAPLStats(base *proto.APLStats, add *proto.APLStats, isLast bool, iterations int64) {
+	addActions := allAPLActionStats(add)
+	for i, baseAction := range allAPLActionStats(base) {
+		addAction := addActions[i]
+		baseAction.ConditionEvaluations += addAction.ConditionEvaluations
+		baseAction.ConditionTrue += addAction.ConditionTrue
+		baseAction.Executions += addAction.Executions
+		baseAction.ExecutionIntervals += addAction.ExecutionIntervals
+		baseAction.ExecutionIntervalSeconds += addAction.ExecutionIntervalSeconds
+		if isLast {
+			finalizeAPLActionStats(baseAction, iterations)
+		}
+	}
+}
+
 func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, add *proto.UnitMetrics, isLast bool, weight float64) {
 	rsrc.combineDistMetrics(base.Dps, add.Dps, isLast, weight)
 	rsrc.combineDistMetrics(base.Threat, add.Threat, isLast, weight)
 	rsrc.combineDistMetrics(base.Dtps, add.Dtps, isLast, weight)
 	rsrc.combineDistMetrics(base.Tmi, add.Tmi, isLast, weight)
 	rsrc.combineDistMetrics(base.Hps, add.Hps, isLast, weight)
+	rsrc.combineDistMetrics(base.Ehps, add.Ehps, isLast, weight)
 	rsrc.combineDistMetrics(base.Tto, add.Tto, isLast, weight)
 
 	base.SecondsOomAvg += add.SecondsOomAvg * weight
@@ -259,6 +301,10 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 		rsrc.addResourceMetrics(base, addResource)
 	}
 
+	if base.RotationStats != nil {
+		rsrc.combineAPLStats(base.RotationStats, add.RotationStats, isLast, int64(base.Dps.AggregatorData.N))
+	}
+
 	for i, addPet := range add.Pets {
 		rsrc.combineUnitMetrics(base.Pets[i], addPet, isLast, weight)
 	}
@@ -267,11 +313,19 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 func (rsrc *raidSimResultCombiner) AddResult(result *proto.RaidSimResult, isLast bool, weight float64) {
 	rsrc.combineDistMetrics(rsrc.Combined.RaidMetrics.Dps, result.RaidMetrics.Dps, isLast, weight)
 	rsrc.combineDistMetrics(rsrc.Combined.RaidMetrics.Hps, result.RaidMetrics.Hps, isLast, weight)
+	rsrc.combineDistMetrics(rsrc.Combined.RaidMetrics.Ehps, result.RaidMetrics.Ehps, isLast, weight)
+	rsrc.combineDistMetrics(rsrc.Combined.RaidMetrics.LowHealthMembers, result.RaidMetrics.LowHealthMembers, isLast, weight)
+	for i, externalCD := range result.RaidMetrics.ExternalCooldowns {
+		baseExternalCD := rsrc.Combined.RaidMetrics.ExternalCooldowns[i]
+		rsrc.combineDistMetrics(baseExternalCD.DpsGain, externalCD.DpsGain, isLast, weight)
+		baseExternalCD.CastsAvg += externalCD.CastsAvg * weight
+	}
 
 	for partyIdx, party := range result.RaidMetrics.Parties {
 		baseParty := rsrc.Combined.RaidMetrics.Parties[partyIdx]
 		rsrc.combineDistMetrics(baseParty.Dps, party.Dps, isLast, weight)
 		rsrc.combineDistMetrics(baseParty.Hps, party.Hps, isLast, weight)
+		rsrc.combineDistMetrics(baseParty.Ehps, party.Ehps, isLast, weight)
 		for playerIdx, player := range party.Players {
 			rsrc.combineUnitMetrics(baseParty.Players[playerIdx], player, isLast, weight)
 		}
@@ -292,14 +346,17 @@ func (rsrc *raidSimResultCombiner) AddResult(result *proto.RaidSimResult, isLast
 func (rsrc *raidSimResultCombiner) SetBaseResult(baseRsr *proto.RaidSimResult) {
 	newRsr := &proto.RaidSimResult{
 		RaidMetrics: &proto.RaidMetrics{
-			Dps:     rsrc.newDistMetrics(),
-			Hps:     rsrc.newDistMetrics(),
-			Parties: make([]*proto.PartyMetrics, len(baseRsr.RaidMetrics.Parties)),
+			Dps:              rsrc.newDistMetrics(),
+			Hps:              rsrc.newDistMetrics(),
+			Ehps:             rsrc.newDistMetrics(),
+			LowHealthMembers: rsrc.newDistMetrics(),
+			Parties:          make([]*proto.PartyMetrics, len(baseRsr.RaidMetrics.Parties)),
 		},
 		EncounterMetrics: &proto.EncounterMetrics{
 			Targets: make([]*proto.UnitMetrics, len(baseRsr.EncounterMetrics.Targets)),
 		},
 		FirstIterationDuration: baseRsr.FirstIterationDuration,
+		CombatLog:              baseRsr.CombatLog,
 	}
 
 	if !rsrc.Debug {
@@ -310,6 +367,15 @@ func (rsrc *raidSimResultCombiner) SetBaseResult(baseRsr *proto.RaidSimResult) {
 		newRsr.RaidMetrics.Parties[i] = rsrc.newPartyMetrics(party)
 	}
 
+	for _, externalCD := range baseRsr.RaidMetrics.ExternalCooldowns {
+		newRsr.RaidMetrics.ExternalCooldowns = append(newRsr.RaidMetrics.ExternalCooldowns, &proto.ExternalCooldownMetrics{
+			Cooldown: externalCD.Cooldown,
+			Provider: externalCD.Provider,
+			Target:   externalCD.Target,
+			DpsGain:  rsrc.newDistMetrics(),
+		})
+	}
+
 	for i, tar := range baseRsr.EncounterMetrics.Targets {
 		newRsr.EncounterMetrics.Targets[i] = rsrc.newUnitMetrics(tar)
 	}
@@ -0,0 +1,311 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/core/stats"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+const defaultStatScalingZScore = 3.0
+const maxStatScalingPoints = 2500
+
+// StatScaling sweeps one or two stats of the first player across a range, sims every point and
+// detects breakpoints from the changes in slope between adjacent points.
+func StatScaling(request *proto.StatScalingRequest) (result *proto.StatScalingResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.StatScalingResult{
+				Error: &proto.ErrorOutcome{
+					Message: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+				},
+			}
+		}
+	}()
+
+	return runStatScaling(request, simsignals.CreateSignals())
+}
+
+func runStatScaling(request *proto.StatScalingRequest, signals simsignals.Signals) *proto.StatScalingResult {
+	baseSettings := request.GetBaseSettings()
+	if len(baseSettings.GetRaid().GetParties()) == 0 || len(baseSettings.Raid.Parties[0].Players) == 0 {
+		return &proto.StatScalingResult{Error: &proto.ErrorOutcome{Message: "Stat scaling: no player in base settings"}}
+	}
+	axes := request.Axes
+	if len(axes) < 1 || len(axes) > 2 {
+		return &proto.StatScalingResult{Error: &proto.ErrorOutcome{Message: fmt.Sprintf("Stat scaling: expected 1 or 2 axes, got %d", len(axes))}}
+	}
+	numPoints := 1
+	for i, axis := range axes {
+		if axis.UnitStat == nil {
+			return &proto.StatScalingResult{Error: &proto.ErrorOutcome{Message: fmt.Sprintf("Stat scaling: axis %d has no stat", i)}}
+		}
+		if axis.Steps < 2 {
+			return &proto.StatScalingResult{Error: &proto.ErrorOutcome{Message: fmt.Sprintf("Stat scaling: axis %d needs at least 2 steps", i)}}
+		}
+		numPoints *= int(axis.Steps)
+	}
+	if numPoints > maxStatScalingPoints {
+		return &proto.StatScalingResult{Error: &proto.ErrorOutcome{Message: fmt.Sprintf("Stat scaling: %d points exceeds the maximum of %d", numPoints, maxStatScalingPoints)}}
+	}
+
+	baseRequest := goproto.Clone(baseSettings).(*proto.RaidSimRequest)
+	if baseRequest.SimOptions == nil {
+		baseRequest.SimOptions = &proto.SimOptions{}
+	}
+	if request.Iterations > 0 {
+		baseRequest.SimOptions.Iterations = request.Iterations
+	}
+	// Use common random numbers across all points, so that differences between adjacent points
+	// come from the stats rather than from RNG.
+	if baseRequest.SimOptions.RandomSeed == 0 {
+		baseRequest.SimOptions.RandomSeed = time.Now().UnixNano()
+	}
+	baseRequest.SimOptions.UseLabeledRands = true
+
+	player := baseRequest.Raid.Parties[0].Players[0]
+	if player.BonusStats == nil {
+		player.BonusStats = &proto.UnitStats{}
+	}
+	if player.BonusStats.Stats == nil {
+		player.BonusStats.Stats = make([]float64, stats.ProtoStatsLen)
+	}
+	if player.BonusStats.PseudoStats == nil {
+		player.BonusStats.PseudoStats = make([]float64, stats.PseudoStatsLen)
+	}
+
+	finalStats := computePlayerFinalStats(baseRequest)
+	currentValues := make([]float64, len(axes))
+	offsets := make([][]float64, len(axes))
+	for i, axis := range axes {
+		currentValues[i] = unitStatValue(finalStats, axis.UnitStat)
+		offsets[i] = make([]float64, axis.Steps)
+		for step := range offsets[i] {
+			offset := axis.Start + (axis.End-axis.Start)*float64(step)/float64(axis.Steps-1)
+			if axis.Absolute {
+				offset -= currentValues[i]
+			}
+			offsets[i][step] = offset
+		}
+	}
+
+	simFunc := runSimConcurrent
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() {
+		simFunc = RunSim
+	}
+
+	result := &proto.StatScalingResult{}
+	for pointIdx := 0; pointIdx < numPoints; pointIdx++ {
+		steps := statScalingSteps(axes, pointIdx)
+
+		pointRequest := goproto.Clone(baseRequest).(*proto.RaidSimRequest)
+		point := &proto.StatScalingPoint{}
+		for i, axis := range axes {
+			offset := offsets[i][steps[i]]
+			statScalingUnitStat(axis.UnitStat).AddToStatsProto(pointRequest.Raid.Parties[0].Players[0].BonusStats, offset)
+			point.Offsets = append(point.Offsets, offset)
+			point.StatValues = append(point.StatValues, currentValues[i]+offset)
+		}
+
+		simResult := simFunc(pointRequest, nil, signals)
+		if simResult.Error != nil {
+			return &proto.StatScalingResult{Error: simResult.Error}
+		}
+		point.Dps = simResult.RaidMetrics.Parties[0].Players[0].Dps
+		if simResult.IterationsDone > 0 {
+			point.DpsStandardError = point.Dps.Stdev / math.Sqrt(float64(simResult.IterationsDone))
+		}
+		result.Points = append(result.Points, point)
+	}
+
+	zThreshold := request.BreakpointZScore
+	if zThreshold <= 0 {
+		zThreshold = defaultStatScalingZScore
+	}
+	for axisIdx := range axes {
+		otherSteps := 1
+		if len(axes) == 2 {
+			otherSteps = int(axes[1-axisIdx].Steps)
+		}
+		for otherStep := 0; otherStep < otherSteps; otherStep++ {
+			var line []*proto.StatScalingPoint
+			for step := 0; step < int(axes[axisIdx].Steps); step++ {
+				steps := []int{step}
+				if len(axes) == 2 {
+					steps = []int{step, otherStep}
+					if axisIdx == 1 {
+						steps = []int{otherStep, step}
+					}
+				}
+				line = append(line, result.Points[statScalingPointIndex(axes, steps)])
+			}
+
+			for _, breakpoint := range findStatScalingBreakpoints(line, axisIdx, zThreshold) {
+				breakpoint.OtherAxisStep = int32(otherStep)
+				result.Breakpoints = append(result.Breakpoints, breakpoint)
+			}
+		}
+	}
+	result.StatCaps = statScalingStatCaps(axes, result.Breakpoints)
+
+	return result
+}
+
+func statScalingUnitStat(uiStat *proto.UIStat) stats.UnitStat {
+	if pseudoStat, ok := uiStat.UnitStat.(*proto.UIStat_PseudoStat); ok {
+		return stats.UnitStatFromPseudoStat(pseudoStat.PseudoStat)
+	}
+	return stats.UnitStatFromStat(stats.Stat(uiStat.GetStat()))
+}
+
+// Returns the step of each axis for the point at the given index, first axis varying slowest.
+func statScalingSteps(axes []*proto.StatScalingAxis, pointIdx int) []int {
+	steps := make([]int, len(axes))
+	for i := len(axes) - 1; i >= 0; i-- {
+		steps[i] = pointIdx % int(axes[i].Steps)
+		pointIdx /= int(axes[i].Steps)
+	}
+	return steps
+}
+
+func statScalingPointIndex(axes []*proto.StatScalingAxis, steps []int) int {
+	pointIdx := 0
+	for i, axis := range axes {
+		pointIdx = pointIdx*int(axis.Steps) + steps[i]
+	}
+	return pointIdx
+}
+
+// Tests the change in slope at every interior point of a line of points along one axis. A single
+// significant change is a soft cap at that point. A significant increase followed directly by a
+// significant decrease (or vice versa) is a jump between the two points.
+func findStatScalingBreakpoints(line []*proto.StatScalingPoint, axisIdx int, zThreshold float64) []*proto.StatScalingBreakpoint {
+	n := len(line)
+	if n < 3 {
+		return nil
+	}
+
+	x := make([]float64, n)
+	y := make([]float64, n)
+	se := make([]float64, n)
+	for i, point := range line {
+		x[i] = point.StatValues[axisIdx]
+		y[i] = point.Dps.GetAvg()
+		se[i] = point.DpsStandardError
+	}
+
+	slope := func(i int) float64 {
+		return (y[i+1] - y[i]) / (x[i+1] - x[i])
+	}
+
+	// z-score of the slope change at each interior point.
+	z := make([]float64, n)
+	for k := 1; k < n-1; k++ {
+		dxBefore := x[k] - x[k-1]
+		dxAfter := x[k+1] - x[k]
+		if dxBefore == 0 || dxAfter == 0 {
+			continue
+		}
+		change := slope(k) - slope(k-1)
+		if math.Abs(change) <= 1e-9*(math.Abs(slope(k))+math.Abs(slope(k-1))+1) {
+			continue
+		}
+		variance := square(se[k+1]/dxAfter) + square(se[k]*(1/dxAfter+1/dxBefore)) + square(se[k-1]/dxBefore)
+		if variance == 0 {
+			z[k] = math.Copysign(math.Inf(1), change)
+			continue
+		}
+		z[k] = change / math.Sqrt(variance)
+	}
+
+	var breakpoints []*proto.StatScalingBreakpoint
+	for k := 1; k < n-1; k++ {
+		if math.Abs(z[k]) < zThreshold {
+			continue
+		}
+
+		if k+1 < n-1 && math.Abs(z[k+1]) >= zThreshold && math.Signbit(z[k]) != math.Signbit(z[k+1]) {
+			slopeBefore := slope(k - 1)
+			slopeAfter := slope(k + 1)
+			dx := x[k+1] - x[k]
+			breakpoints = append(breakpoints, &proto.StatScalingBreakpoint{
+				Axis:        int32(axisIdx),
+				CapType:     proto.StatCapType_TypeThreshold,
+				Lower:       x[k],
+				Upper:       x[k+1],
+				SlopeBefore: slopeBefore,
+				SlopeAfter:  slopeAfter,
+				DpsJump:     y[k+1] - y[k] - dx*(slopeBefore+slopeAfter)/2,
+				ZScore:      math.Min(math.Abs(z[k]), math.Abs(z[k+1])),
+			})
+			k++
+			continue
+		}
+
+		// Only report the strongest of several adjacent slope changes of the same sign, which
+		// happens when a soft cap lies between two points.
+		if (k > 1 && math.Abs(z[k-1]) > math.Abs(z[k]) && math.Signbit(z[k-1]) == math.Signbit(z[k])) ||
+			(k+1 < n-1 && math.Abs(z[k+1]) > math.Abs(z[k]) && math.Signbit(z[k+1]) == math.Signbit(z[k])) {
+			continue
+		}
+		breakpoints = append(breakpoints, &proto.StatScalingBreakpoint{
+			Axis:        int32(axisIdx),
+			CapType:     proto.StatCapType_TypeSoftCap,
+			Lower:       x[k],
+			Upper:       x[k],
+			SlopeBefore: slope(k - 1),
+			SlopeAfter:  slope(k),
+			ZScore:      math.Abs(z[k]),
+		})
+	}
+	return breakpoints
+}
+
+func square(x float64) float64 {
+	return x * x
+}
+
+// Groups the breakpoints of each axis and type into stat cap configs. Breakpoints found along
+// several lines of a two axis sweep are merged.
+func statScalingStatCaps(axes []*proto.StatScalingAxis, breakpoints []*proto.StatScalingBreakpoint) []*proto.StatCapConfig {
+	var statCaps []*proto.StatCapConfig
+	for axisIdx, axis := range axes {
+		for _, capType := range []proto.StatCapType{proto.StatCapType_TypeSoftCap, proto.StatCapType_TypeThreshold} {
+			slopeAfter := map[float64]float64{}
+			for _, breakpoint := range breakpoints {
+				if int(breakpoint.Axis) != axisIdx || breakpoint.CapType != capType {
+					continue
+				}
+				if _, ok := slopeAfter[breakpoint.Upper]; !ok {
+					slopeAfter[breakpoint.Upper] = breakpoint.SlopeAfter
+				}
+			}
+			if len(slopeAfter) == 0 {
+				continue
+			}
+
+			statCap := &proto.StatCapConfig{
+				UnitStat: axis.UnitStat,
+				CapType:  capType,
+			}
+			for value := range slopeAfter {
+				statCap.Breakpoints = append(statCap.Breakpoints, value)
+			}
+			slices.Sort(statCap.Breakpoints)
+			if capType == proto.StatCapType_TypeSoftCap {
+				for _, value := range statCap.Breakpoints {
+					statCap.PostCap_EPs = append(statCap.PostCap_EPs, slopeAfter[value])
+				}
+			}
+			statCaps = append(statCaps, statCap)
+		}
+	}
+	return statCaps
+}
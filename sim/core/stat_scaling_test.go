@@ -0,0 +1,77 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func makeStatScalingLine(dps func(x float64) float64) []*proto.StatScalingPoint {
+	var line []*proto.StatScalingPoint
+	for x := 0.0; x <= 1000; x += 100 {
+		line = append(line, &proto.StatScalingPoint{
+			StatValues:       []float64{x},
+			Dps:              &proto.DistributionMetrics{Avg: dps(x)},
+			DpsStandardError: 1,
+		})
+	}
+	return line
+}
+
+func TestStatScalingFindsSoftCap(t *testing.T) {
+	line := makeStatScalingLine(func(x float64) float64 {
+		return 10000 + 2*min(x, 500) + 0.5*max(0, x-500)
+	})
+
+	breakpoints := findStatScalingBreakpoints(line, 0, 3)
+	if len(breakpoints) != 1 {
+		t.Fatalf("Expected 1 breakpoint, got %d: %v", len(breakpoints), breakpoints)
+	}
+	breakpoint := breakpoints[0]
+	if breakpoint.CapType != proto.StatCapType_TypeSoftCap || breakpoint.Lower != 500 || breakpoint.Upper != 500 {
+		t.Errorf("Expected a soft cap at 500, got %v", breakpoint)
+	}
+	if !WithinToleranceFloat64(2, breakpoint.SlopeBefore, 1e-9) || !WithinToleranceFloat64(0.5, breakpoint.SlopeAfter, 1e-9) {
+		t.Errorf("Expected slopes 2 and 0.5, got %f and %f", breakpoint.SlopeBefore, breakpoint.SlopeAfter)
+	}
+}
+
+func TestStatScalingFindsJump(t *testing.T) {
+	line := makeStatScalingLine(func(x float64) float64 {
+		if x > 450 {
+			return 10000 + x + 300
+		}
+		return 10000 + x
+	})
+
+	breakpoints := findStatScalingBreakpoints(line, 0, 3)
+	if len(breakpoints) != 1 {
+		t.Fatalf("Expected 1 breakpoint, got %d: %v", len(breakpoints), breakpoints)
+	}
+	breakpoint := breakpoints[0]
+	if breakpoint.CapType != proto.StatCapType_TypeThreshold || breakpoint.Lower != 400 || breakpoint.Upper != 500 {
+		t.Errorf("Expected a threshold between 400 and 500, got %v", breakpoint)
+	}
+	if !WithinToleranceFloat64(300, breakpoint.DpsJump, 1e-9) {
+		t.Errorf("Expected a jump of 300 DPS, got %f", breakpoint.DpsJump)
+	}
+
+	axes := []*proto.StatScalingAxis{{UnitStat: &proto.UIStat{UnitStat: &proto.UIStat_Stat{Stat: proto.Stat_StatHasteRating}}}}
+	statCaps := statScalingStatCaps(axes, breakpoints)
+	if len(statCaps) != 1 || statCaps[0].CapType != proto.StatCapType_TypeThreshold || len(statCaps[0].Breakpoints) != 1 || statCaps[0].Breakpoints[0] != 500 {
+		t.Errorf("Expected a single threshold stat cap at 500, got %v", statCaps)
+	}
+}
+
+func TestStatScalingIgnoresNoise(t *testing.T) {
+	noise := []float64{0.5, -0.8, 1.2, -0.3, 0.9, -1.1, 0.2, 0.7, -0.6, 1.0, -0.4}
+	i := 0
+	line := makeStatScalingLine(func(x float64) float64 {
+		i++
+		return 10000 + x + noise[i-1]
+	})
+
+	if breakpoints := findStatScalingBreakpoints(line, 0, 3); len(breakpoints) != 0 {
+		t.Errorf("Expected no breakpoints, got %v", breakpoints)
+	}
+}
@@ -25,6 +25,7 @@ var safeDepsOrder = []Stat{
 	MP5,
 	HasteRating,
 	CritRating,
+	MasteryRating,
 	SpellCritPercent,
 	PhysicalCritPercent,
 	BlockPercent,
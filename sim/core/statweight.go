@@ -66,6 +66,10 @@ type StatWeightValues struct {
 	WeightsStdev  UnitStats
 	EpValues      UnitStats
 	EpValuesStdev UnitStats
+
+	// Only set by the regression method.
+	WeightsStandardError UnitStats
+	Interactions         []*proto.StatWeightInteraction
 }
 
 func NewStatWeightValues() StatWeightValues {
@@ -78,12 +82,17 @@ func NewStatWeightValues() StatWeightValues {
 }
 
 func (swv *StatWeightValues) ToProto() *proto.StatWeightValues {
-	return &proto.StatWeightValues{
+	values := &proto.StatWeightValues{
 		Weights:       swv.Weights.ExportWeights(),
 		WeightsStdev:  swv.WeightsStdev.ExportWeights(),
 		EpValues:      swv.EpValues.ExportWeights(),
 		EpValuesStdev: swv.EpValuesStdev.ExportWeights(),
+		Interactions:  swv.Interactions,
+	}
+	if swv.WeightsStandardError.PseudoStats != nil {
+		values.WeightsStandardError = swv.WeightsStandardError.ToProto()
 	}
+	return values
 }
 
 type StatWeightsResult struct {
@@ -117,6 +126,28 @@ func (swr *StatWeightsResult) ToProto() *proto.StatWeightsResult {
 	}
 }
 
+// Converts the weights of the given stats to EP values relative to the reference stats.
+func (swr *StatWeightsResult) computeEpValues(weighedStats []stats.UnitStat, referenceStat stats.Stat) {
+	for _, stat := range weighedStats {
+		calcEpResults := func(weightResults *StatWeightValues, refStat stats.Stat) {
+			if weightResults.Weights.Stats[refStat] == 0 {
+				return
+			}
+			mean := weightResults.Weights.Get(stat) / weightResults.Weights.Stats[refStat]
+			stdev := weightResults.WeightsStdev.Get(stat) / math.Abs(weightResults.Weights.Stats[refStat])
+			weightResults.EpValues.AddStat(stat, mean)
+			weightResults.EpValuesStdev.AddStat(stat, stdev)
+		}
+
+		calcEpResults(&swr.Dps, referenceStat)
+		calcEpResults(&swr.Hps, referenceStat)
+		calcEpResults(&swr.Tps, referenceStat)
+		calcEpResults(&swr.Dtps, DTPSReferenceStat)
+		calcEpResults(&swr.Tmi, DTPSReferenceStat)
+		calcEpResults(&swr.PDeath, DTPSReferenceStat)
+	}
+}
+
 func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightRequestsData {
 	if swr.Player.BonusStats == nil {
 		swr.Player.BonusStats = &proto.UnitStats{}
@@ -133,10 +164,6 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 
 	swr.SimOptions.SaveAllValues = true
 
-	// Cut in half since we're doing above and below separately.
-	// This number needs to be the same for the baseline sim too, so that RNG lines up perfectly.
-	swr.SimOptions.Iterations /= 2
-
 	// Make sure an RNG seed is always set because it gives more consistent results.
 	// When there is no user-supplied seed it needs to be a randomly-selected seed
 	// though, so that run-run differences still exist.
@@ -147,6 +174,14 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 	// Reduce variance even more by using test-level RNG controls.
 	swr.SimOptions.UseLabeledRands = true
 
+	if swr.Method == proto.StatWeightsMethod_StatWeightsMethodRegression {
+		return buildRegressionStatWeightRequests(swr, raidProto)
+	}
+
+	// Cut in half since we're doing above and below separately.
+	// This number needs to be the same for the baseline sim too, so that RNG lines up perfectly.
+	swr.SimOptions.Iterations /= 2
+
 	swBaseResponse := &proto.StatWeightRequestsData{
 		BaseRequest: &proto.RaidSimRequest{
 			Raid:       raidProto,
@@ -158,13 +193,46 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 	}
 
 	// Do half the iterations with a positive, and half with a negative value for better accuracy.
-	const defaultStatMod = 320.0 // match to the impact of a single gem for secondaries
+	statModsHigh := statWeightMods(swr)
 	statModsLow := make([]float64, stats.UnitStatsLen)
-	statModsHigh := make([]float64, stats.UnitStatsLen)
+	for i, statMod := range statModsHigh {
+		statModsLow[i] = -statMod
+	}
+
+	for i := range statModsLow {
+		stat := stats.UnitStatFromIdx(i)
+		if statModsLow[stat] == 0 {
+			continue
+		}
+
+		lowSimRequest := googleProto.Clone(swBaseResponse.BaseRequest).(*proto.RaidSimRequest)
+		stat.AddToStatsProto(lowSimRequest.Raid.Parties[0].Players[0].BonusStats, statModsLow[stat])
+
+		highSimRequest := googleProto.Clone(swBaseResponse.BaseRequest).(*proto.RaidSimRequest)
+		stat.AddToStatsProto(highSimRequest.Raid.Parties[0].Players[0].BonusStats, statModsHigh[stat])
+
+		swBaseResponse.StatSimRequests = append(swBaseResponse.StatSimRequests, &proto.StatWeightsStatRequestData{
+			StatData: &proto.StatWeightsStatData{
+				UnitStat: int32(stat),
+				ModLow:   statModsLow[stat],
+				ModHigh:  statModsHigh[stat],
+			},
+			RequestLow:  lowSimRequest,
+			RequestHigh: highSimRequest,
+		})
+	}
+
+	return swBaseResponse
+}
+
+// Returns the amount of bonus stats to add or remove for each weighed stat, indexed by UnitStat.
+// Stats which are not weighed are 0.
+func statWeightMods(swr *proto.StatWeightsRequest) []float64 {
+	const defaultStatMod = 320.0 // match to the impact of a single gem for secondaries
+	statMods := make([]float64, stats.UnitStatsLen)
 
 	// Make sure reference stat is included.
-	statModsLow[swr.EpReferenceStat] = -defaultStatMod
-	statModsHigh[swr.EpReferenceStat] = defaultStatMod
+	statMods[swr.EpReferenceStat] = defaultStatMod
 
 	statsToWeigh := stats.ProtoArrayToStatsList(swr.StatsToWeigh)
 	for _, s := range statsToWeigh {
@@ -176,8 +244,7 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 		} else if stat.EqualsStat(stats.Armor) || stat.EqualsStat(stats.BonusArmor) {
 			statMod = defaultStatMod * 10
 		}
-		statModsHigh[stat] = statMod
-		statModsLow[stat] = -statMod
+		statMods[stat] = statMod
 	}
 	for _, s := range swr.PseudoStatsToWeigh {
 		stat := stats.UnitStatFromPseudoStat(s)
@@ -197,8 +264,7 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 			panic(fmt.Sprintf("Unsupported PseudoStat in stat weights request: %s", statName))
 		}
 
-		statModsHigh[stat] = statMod
-		statModsLow[stat] = -statMod
+		statMods[stat] = statMod
 
 		// If a school-specific Hit/Crit percentage stat is being
 		// weighed, then remove the base Rating stat from the request to
@@ -206,42 +272,21 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 		// reconstructed from the PseudoStat EPs when writing the final
 		// results.
 		if strings.Contains(statName, "Hit") {
-			statModsLow[stats.HitRating] = 0
-			statModsHigh[stats.HitRating] = 0
+			statMods[stats.HitRating] = 0
 		} else if strings.Contains(statName, "Crit") {
-			statModsLow[stats.CritRating] = 0
-			statModsHigh[stats.CritRating] = 0
+			statMods[stats.CritRating] = 0
 		}
 
 	}
 
-	for i := range statModsLow {
-		stat := stats.UnitStatFromIdx(i)
-		if statModsLow[stat] == 0 {
-			continue
-		}
-
-		lowSimRequest := googleProto.Clone(swBaseResponse.BaseRequest).(*proto.RaidSimRequest)
-		stat.AddToStatsProto(lowSimRequest.Raid.Parties[0].Players[0].BonusStats, statModsLow[stat])
-
-		highSimRequest := googleProto.Clone(swBaseResponse.BaseRequest).(*proto.RaidSimRequest)
-		stat.AddToStatsProto(highSimRequest.Raid.Parties[0].Players[0].BonusStats, statModsHigh[stat])
-
-		swBaseResponse.StatSimRequests = append(swBaseResponse.StatSimRequests, &proto.StatWeightsStatRequestData{
-			StatData: &proto.StatWeightsStatData{
-				UnitStat: int32(stat),
-				ModLow:   statModsLow[stat],
-				ModHigh:  statModsHigh[stat],
-			},
-			RequestLow:  lowSimRequest,
-			RequestHigh: highSimRequest,
-		})
-	}
-
-	return swBaseResponse
+	return statMods
 }
 
 func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsResult {
+	if len(swcr.RegressionResults) > 0 {
+		return computeRegressionStatWeights(swcr)
+	}
+
 	haveRefStat := false
 	for _, statResult := range swcr.StatSimResults {
 		if statResult.StatData.UnitStat == int32(swcr.EpReferenceStat) {
@@ -294,35 +339,17 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 		result.PDeath.WeightsStdev.AddStat(stat, 0)
 	}
 
-	referenceStat := stats.Stat(swcr.EpReferenceStat)
-
-	// Compute EP results.
+	weighedStats := make([]stats.UnitStat, 0, len(swcr.StatSimResults))
 	for _, statData := range swcr.StatSimResults {
-		stat := stats.UnitStatFromIdx(int(statData.StatData.UnitStat))
-
-		calcEpResults := func(weightResults *StatWeightValues, refStat stats.Stat) {
-			if weightResults.Weights.Stats[refStat] == 0 {
-				return
-			}
-			mean := weightResults.Weights.Get(stat) / weightResults.Weights.Stats[refStat]
-			stdev := weightResults.WeightsStdev.Get(stat) / math.Abs(weightResults.Weights.Stats[refStat])
-			weightResults.EpValues.AddStat(stat, mean)
-			weightResults.EpValuesStdev.AddStat(stat, stdev)
-		}
-
-		calcEpResults(&result.Dps, referenceStat)
-		calcEpResults(&result.Hps, referenceStat)
-		calcEpResults(&result.Tps, referenceStat)
-		calcEpResults(&result.Dtps, DTPSReferenceStat)
-		calcEpResults(&result.Tmi, DTPSReferenceStat)
-		calcEpResults(&result.PDeath, DTPSReferenceStat)
+		weighedStats = append(weighedStats, stats.UnitStatFromIdx(int(statData.StatData.UnitStat)))
 	}
+	result.computeEpValues(weighedStats, stats.Stat(swcr.EpReferenceStat))
 
 	return result.ToProto()
 }
 
 // Run stat weight sims and compute weights.
-func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.StatWeightsResult {
+func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals, simFunc RaidSimRunner) *proto.StatWeightsResult {
 	requestData := buildStatWeightRequests(request)
 
 	var iterationsTotal int32 = requestData.BaseRequest.SimOptions.Iterations
@@ -335,6 +362,10 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 		iterationsTotal += reqData.RequestHigh.SimOptions.Iterations
 		simsTotal += 2
 	}
+	for _, sample := range requestData.RegressionSamples {
+		iterationsTotal += sample.Request.SimOptions.Iterations
+		simsTotal++
+	}
 
 	waitForResult := func(srcProgressChannel chan *proto.ProgressMetrics) *proto.RaidSimResult {
 		var lastCompleted int32 = 0
@@ -359,10 +390,12 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 		return nil
 	}
 
-	simFunc := runSimConcurrent
-	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
-	if IsRunningInWasm() {
-		simFunc = RunSim
+	if simFunc == nil {
+		simFunc = runSimConcurrent
+		// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+		if IsRunningInWasm() {
+			simFunc = RunSim
+		}
 	}
 
 	baseProgress := make(chan *proto.ProgressMetrics, 100)
@@ -396,9 +429,27 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 		})
 	}
 
+	regressionResults := []*proto.StatWeightsRegressionSampleResult{}
+
+	for _, sample := range requestData.RegressionSamples {
+		sampleProgress := make(chan *proto.ProgressMetrics, 100)
+		go simFunc(sample.Request, sampleProgress, signals)
+		sampleRes := waitForResult(sampleProgress)
+		if sampleRes.Error != nil {
+			return &proto.StatWeightsResult{Error: sampleRes.Error}
+		}
+
+		regressionResults = append(regressionResults, &proto.StatWeightsRegressionSampleResult{
+			StatMods: sample.StatMods,
+			Result:   sampleRes,
+		})
+	}
+
 	return computeStatWeights(&proto.StatWeightsCalcRequest{
-		BaseResult:      baselineResult,
-		EpReferenceStat: requestData.EpReferenceStat,
-		StatSimResults:  statResults,
+		BaseResult:        baselineResult,
+		EpReferenceStat:   requestData.EpReferenceStat,
+		StatSimResults:    statResults,
+		RegressionResults: regressionResults,
+		RegressionOptions: requestData.RegressionOptions,
 	})
 }
@@ -0,0 +1,316 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Stat weights via regression: instead of a low and a high sim per stat, every sample sim jitters
+// all weighed stats at once, and a (weighted) least squares fit over all samples recovers the
+// weights. This needs far fewer sims when many stats are weighed, and the optional quadratic terms
+// capture curvature and interactions between stats, e.g. around haste breakpoints.
+
+var errSingularRegression = errors.New("stat weight regression is singular, try more samples")
+
+// Returns the number of coefficients of the regression model, including the intercept.
+func numRegressionCoefficients(numStats int, quadratic bool) int {
+	numCoefficients := 1 + numStats
+	if quadratic {
+		numCoefficients += numStats * (numStats + 1) / 2
+	}
+	return numCoefficients
+}
+
+func buildRegressionStatWeightRequests(swr *proto.StatWeightsRequest, raidProto *proto.Raid) *proto.StatWeightRequestsData {
+	statMods := statWeightMods(swr)
+	var weighedStats []stats.UnitStat
+	for i, statMod := range statMods {
+		if statMod != 0 {
+			weighedStats = append(weighedStats, stats.UnitStatFromIdx(i))
+		}
+	}
+
+	options := &proto.StatWeightsRegressionOptions{}
+	if swr.RegressionOptions != nil {
+		options = googleProto.Clone(swr.RegressionOptions).(*proto.StatWeightsRegressionOptions)
+	}
+	numCoefficients := numRegressionCoefficients(len(weighedStats), options.Quadratic)
+	if options.NumSamples == 0 {
+		options.NumSamples = 2 * int32(numCoefficients)
+	}
+	// Together with the baseline, this leaves at least one degree of freedom for the standard errors.
+	options.NumSamples = max(options.NumSamples, int32(numCoefficients))
+	// Samples are drawn in mirrored pairs.
+	options.NumSamples += options.NumSamples % 2
+	if options.IterationsPerSample == 0 {
+		options.IterationsPerSample = max(1, swr.SimOptions.Iterations/4)
+	}
+
+	requestsData := &proto.StatWeightRequestsData{
+		BaseRequest: &proto.RaidSimRequest{
+			Raid:       raidProto,
+			Encounter:  swr.Encounter,
+			SimOptions: swr.SimOptions,
+		},
+		EpReferenceStat:   swr.EpReferenceStat,
+		StatSimRequests:   []*proto.StatWeightsStatRequestData{},
+		RegressionOptions: options,
+	}
+
+	// Jitter from the sim seed so that the same request always produces the same samples.
+	rand := NewSplitMix(uint64(swr.SimOptions.RandomSeed))
+	for i := int32(0); i < options.NumSamples/2; i++ {
+		jitter := make([]float64, stats.UnitStatsLen)
+		for _, stat := range weighedStats {
+			jitter[stat] = statMods[stat] * (2*rand.NextFloat64() - 1)
+		}
+
+		// Mirrored samples cancel out the curvature when estimating the linear terms.
+		for _, sign := range []float64{1, -1} {
+			sampleMods := make([]float64, stats.UnitStatsLen)
+			request := googleProto.Clone(requestsData.BaseRequest).(*proto.RaidSimRequest)
+			request.SimOptions.Iterations = options.IterationsPerSample
+			for _, stat := range weighedStats {
+				sampleMods[stat] = sign * jitter[stat]
+				stat.AddToStatsProto(request.Raid.Parties[0].Players[0].BonusStats, sampleMods[stat])
+			}
+
+			requestsData.RegressionSamples = append(requestsData.RegressionSamples, &proto.StatWeightsRegressionSample{
+				StatMods: sampleMods,
+				Request:  request,
+			})
+		}
+	}
+
+	return requestsData
+}
+
+func computeRegressionStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsResult {
+	options := swcr.RegressionOptions
+	if options == nil {
+		options = &proto.StatWeightsRegressionOptions{}
+	}
+
+	// Weighed stats are those which were jittered in any sample. Each stat is normalized by its
+	// largest jitter to keep the normal equations well conditioned.
+	scales := make([]float64, stats.UnitStatsLen)
+	for _, sample := range swcr.RegressionResults {
+		if len(sample.StatMods) != int(stats.UnitStatsLen) {
+			return &proto.StatWeightsResult{Error: &proto.ErrorOutcome{Message: fmt.Sprintf("Regression sample has %d stat mods, expected %d", len(sample.StatMods), stats.UnitStatsLen)}}
+		}
+		for i, statMod := range sample.StatMods {
+			scales[i] = max(scales[i], math.Abs(statMod))
+		}
+	}
+	var weighedStats []stats.UnitStat
+	for i, scale := range scales {
+		if scale > 0 {
+			weighedStats = append(weighedStats, stats.UnitStatFromIdx(i))
+		}
+	}
+	if scales[stats.UnitStatFromStat(stats.Stat(swcr.EpReferenceStat))] == 0 {
+		return &proto.StatWeightsResult{Error: &proto.ErrorOutcome{Message: "No result for reference stat exists!"}}
+	}
+
+	type observation struct {
+		statMods []float64
+		player   *proto.UnitMetrics
+		weight   float64
+	}
+	observations := []observation{{
+		statMods: make([]float64, stats.UnitStatsLen),
+		player:   swcr.BaseResult.RaidMetrics.Parties[0].Players[0],
+		weight:   float64(swcr.BaseResult.IterationsDone),
+	}}
+	for _, sample := range swcr.RegressionResults {
+		observations = append(observations, observation{
+			statMods: sample.StatMods,
+			player:   sample.Result.RaidMetrics.Parties[0].Players[0],
+			weight:   float64(sample.Result.IterationsDone),
+		})
+	}
+
+	// Design matrix: intercept, linear terms, then squared and interaction terms.
+	design := make([][]float64, len(observations))
+	weights := make([]float64, len(observations))
+	for i, obs := range observations {
+		row := []float64{1}
+		for _, stat := range weighedStats {
+			row = append(row, obs.statMods[stat]/scales[stat])
+		}
+		if options.Quadratic {
+			for a, statA := range weighedStats {
+				for _, statB := range weighedStats[a:] {
+					row = append(row, obs.statMods[statA]/scales[statA]*obs.statMods[statB]/scales[statB])
+				}
+			}
+		}
+		design[i] = row
+		weights[i] = max(1, obs.weight)
+	}
+
+	// Scales standard errors into per-iteration deviations, see StatWeightValues.weights_stdev.
+	stdevScale := math.Sqrt(max(1, float64(swcr.BaseResult.IterationsDone)))
+
+	result := NewStatWeightsResult()
+	fitMetric := func(getValue func(*proto.UnitMetrics) float64, weightResults *StatWeightValues) error {
+		values := make([]float64, len(observations))
+		for i, obs := range observations {
+			values[i] = getValue(obs.player)
+		}
+
+		coefficients, standardErrors, err := fitWeightedLeastSquares(design, values, weights)
+		if err != nil {
+			return err
+		}
+
+		weightResults.WeightsStandardError = NewUnitStats()
+		for k, stat := range weighedStats {
+			weightResults.Weights.AddStat(stat, coefficients[1+k]/scales[stat])
+			weightResults.WeightsStdev.AddStat(stat, standardErrors[1+k]/scales[stat]*stdevScale)
+			weightResults.WeightsStandardError.AddStat(stat, standardErrors[1+k]/scales[stat])
+		}
+
+		if options.Quadratic {
+			idx := 1 + len(weighedStats)
+			for a, statA := range weighedStats {
+				for _, statB := range weighedStats[a:] {
+					scale := scales[statA] * scales[statB]
+					weightResults.Interactions = append(weightResults.Interactions, &proto.StatWeightInteraction{
+						UnitStatA:     int32(statA),
+						UnitStatB:     int32(statB),
+						Value:         coefficients[idx] / scale,
+						StandardError: standardErrors[idx] / scale,
+					})
+					idx++
+				}
+			}
+		}
+		return nil
+	}
+
+	metrics := []struct {
+		getValue      func(*proto.UnitMetrics) float64
+		weightResults *StatWeightValues
+	}{
+		{func(m *proto.UnitMetrics) float64 { return m.GetDps().GetAvg() }, &result.Dps},
+		{func(m *proto.UnitMetrics) float64 { return m.GetHps().GetAvg() }, &result.Hps},
+		{func(m *proto.UnitMetrics) float64 { return m.GetThreat().GetAvg() }, &result.Tps},
+		{func(m *proto.UnitMetrics) float64 { return m.GetDtps().GetAvg() }, &result.Dtps},
+		{func(m *proto.UnitMetrics) float64 { return m.GetTmi().GetAvg() }, &result.Tmi},
+		{func(m *proto.UnitMetrics) float64 { return m.GetChanceOfDeath() }, &result.PDeath},
+	}
+	for _, metric := range metrics {
+		if err := fitMetric(metric.getValue, metric.weightResults); err != nil {
+			return &proto.StatWeightsResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+		}
+	}
+
+	result.computeEpValues(weighedStats, stats.Stat(swcr.EpReferenceStat))
+	return result.ToProto()
+}
+
+// Solves the weighted least squares problem for the given design matrix, returning the
+// coefficients and their standard errors. Standard errors are 0 if there are no residual degrees
+// of freedom.
+func fitWeightedLeastSquares(design [][]float64, values []float64, weights []float64) ([]float64, []float64, error) {
+	numObs := len(design)
+	if numObs == 0 {
+		return nil, nil, errSingularRegression
+	}
+	numCoefficients := len(design[0])
+
+	// Normal equations: (XᵀWX) β = XᵀWy.
+	normal := make([][]float64, numCoefficients)
+	rhs := make([]float64, numCoefficients)
+	for a := range normal {
+		normal[a] = make([]float64, numCoefficients)
+	}
+	for i, row := range design {
+		for a := 0; a < numCoefficients; a++ {
+			rhs[a] += weights[i] * row[a] * values[i]
+			for b := 0; b < numCoefficients; b++ {
+				normal[a][b] += weights[i] * row[a] * row[b]
+			}
+		}
+	}
+
+	inverse, err := invertMatrix(normal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	coefficients := make([]float64, numCoefficients)
+	for a := range coefficients {
+		for b := range rhs {
+			coefficients[a] += inverse[a][b] * rhs[b]
+		}
+	}
+
+	standardErrors := make([]float64, numCoefficients)
+	if numObs > numCoefficients {
+		weightedRss := 0.0
+		for i, row := range design {
+			residual := values[i]
+			for a, x := range row {
+				residual -= coefficients[a] * x
+			}
+			weightedRss += weights[i] * residual * residual
+		}
+		variance := weightedRss / float64(numObs-numCoefficients)
+		for a := range standardErrors {
+			standardErrors[a] = math.Sqrt(max(0, variance*inverse[a][a]))
+		}
+	}
+
+	return coefficients, standardErrors, nil
+}
+
+// Inverts a square matrix with Gauss-Jordan elimination and partial pivoting.
+func invertMatrix(matrix [][]float64) ([][]float64, error) {
+	n := len(matrix)
+	augmented := make([][]float64, n)
+	for i, row := range matrix {
+		augmented[i] = make([]float64, 2*n)
+		copy(augmented[i], row)
+		augmented[i][n+i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivotRow := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(augmented[r][col]) > math.Abs(augmented[pivotRow][col]) {
+				pivotRow = r
+			}
+		}
+		if math.Abs(augmented[pivotRow][col]) < 1e-12 {
+			return nil, errSingularRegression
+		}
+		augmented[col], augmented[pivotRow] = augmented[pivotRow], augmented[col]
+
+		scale := 1 / augmented[col][col]
+		for j := range augmented[col] {
+			augmented[col][j] *= scale
+		}
+		for r := 0; r < n; r++ {
+			if r == col || augmented[r][col] == 0 {
+				continue
+			}
+			factor := augmented[r][col]
+			for j := range augmented[r] {
+				augmented[r][j] -= factor * augmented[col][j]
+			}
+		}
+	}
+
+	inverse := make([][]float64, n)
+	for i := range augmented {
+		inverse[i] = augmented[i][n:]
+	}
+	return inverse, nil
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func TestRegressionStatWeightsQuadratic(t *testing.T) {
+	swr := &proto.StatWeightsRequest{
+		Player:          &proto.Player{},
+		Encounter:       &proto.Encounter{},
+		SimOptions:      &proto.SimOptions{Iterations: 1000, RandomSeed: 5},
+		StatsToWeigh:    []proto.Stat{proto.Stat_StatStrength, proto.Stat_StatHasteRating},
+		EpReferenceStat: proto.Stat_StatStrength,
+		Method:          proto.StatWeightsMethod_StatWeightsMethodRegression,
+		RegressionOptions: &proto.StatWeightsRegressionOptions{
+			Quadratic: true,
+		},
+	}
+	requestsData := buildStatWeightRequests(swr)
+
+	// Intercept, 2 linear, 2 squared and 1 interaction term.
+	if numSamples := len(requestsData.RegressionSamples); numSamples != 12 {
+		t.Fatalf("Expected 12 regression samples, got %d", numSamples)
+	}
+	if len(requestsData.StatSimRequests) != 0 {
+		t.Fatalf("Expected no finite difference requests, got %d", len(requestsData.StatSimRequests))
+	}
+
+	str := stats.UnitStatFromStat(stats.Strength)
+	haste := stats.UnitStatFromStat(stats.HasteRating)
+	dps := func(statMods []float64) float64 {
+		s, h := statMods[str], statMods[haste]
+		return 10000 + 2*s + 0.5*h - 0.001*h*h + 0.0005*s*h
+	}
+	fakeResult := func(statMods []float64, iterations int32) *proto.RaidSimResult {
+		return &proto.RaidSimResult{
+			IterationsDone: iterations,
+			RaidMetrics: &proto.RaidMetrics{Parties: []*proto.PartyMetrics{{Players: []*proto.UnitMetrics{{
+				Dps: &proto.DistributionMetrics{Avg: dps(statMods)},
+			}}}}},
+		}
+	}
+
+	calcRequest := &proto.StatWeightsCalcRequest{
+		BaseResult:        fakeResult(make([]float64, stats.UnitStatsLen), requestsData.BaseRequest.SimOptions.Iterations),
+		EpReferenceStat:   requestsData.EpReferenceStat,
+		RegressionOptions: requestsData.RegressionOptions,
+	}
+	for _, sample := range requestsData.RegressionSamples {
+		calcRequest.RegressionResults = append(calcRequest.RegressionResults, &proto.StatWeightsRegressionSampleResult{
+			StatMods: sample.StatMods,
+			Result:   fakeResult(sample.StatMods, sample.Request.SimOptions.Iterations),
+		})
+	}
+
+	result := computeStatWeights(calcRequest)
+	if result.Error != nil {
+		t.Fatalf("computeStatWeights() returned error: %s", result.Error.Message)
+	}
+
+	weights := result.Dps.Weights.Stats
+	if !WithinToleranceFloat64(2, weights[stats.Strength], 1e-6) || !WithinToleranceFloat64(0.5, weights[stats.HasteRating], 1e-6) {
+		t.Errorf("Expected weights Str=2 Haste=0.5, got Str=%f Haste=%f", weights[stats.Strength], weights[stats.HasteRating])
+	}
+	if ep := result.Dps.EpValues.Stats[stats.HasteRating]; !WithinToleranceFloat64(0.25, ep, 1e-6) {
+		t.Errorf("Expected Haste EP of 0.25, got %f", ep)
+	}
+
+	expectedInteractions := map[[2]stats.UnitStat]float64{
+		{str, str}:     0,
+		{str, haste}:   0.0005,
+		{haste, haste}: -0.001,
+	}
+	if len(result.Dps.Interactions) != len(expectedInteractions) {
+		t.Fatalf("Expected %d interactions, got %d", len(expectedInteractions), len(result.Dps.Interactions))
+	}
+	for _, interaction := range result.Dps.Interactions {
+		key := [2]stats.UnitStat{stats.UnitStat(interaction.UnitStatA), stats.UnitStat(interaction.UnitStatB)}
+		if !WithinToleranceFloat64(expectedInteractions[key], interaction.Value, 1e-9) {
+			t.Errorf("Interaction %v: expected %g, got %g", key, expectedInteractions[key], interaction.Value)
+		}
+	}
+}
+
+func TestFitWeightedLeastSquaresStandardErrors(t *testing.T) {
+	// y = 1 + 2x with residuals of +-1 that are orthogonal to x.
+	design := [][]float64{{1, -1}, {1, 0}, {1, 1}, {1, 2}}
+	values := []float64{-1 + 1, 1 - 1, 3 - 1, 5 + 1}
+	weights := []float64{1, 1, 1, 1}
+
+	coefficients, standardErrors, err := fitWeightedLeastSquares(design, values, weights)
+	if err != nil {
+		t.Fatalf("fitWeightedLeastSquares() returned error: %v", err)
+	}
+
+	if !WithinToleranceFloat64(1, coefficients[0], 1e-9) || !WithinToleranceFloat64(2, coefficients[1], 1e-9) {
+		t.Fatalf("Expected coefficients [1 2], got %v", coefficients)
+	}
+	// RSS = 4 over 2 degrees of freedom, and sum((x-mean)^2) = 5, so the slope variance is 2/5.
+	if !WithinToleranceFloat64(0.632455532033676, standardErrors[1], 1e-9) {
+		t.Fatalf("Expected slope standard error of 0.6325, got %f", standardErrors[1])
+	}
+}
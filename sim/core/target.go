@@ -79,6 +79,14 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		panic("At least one target must be active at the start of the simulation!")
 	}
 
+	// The script replaces any preset AI of the boss.
+	if options.Script != nil && len(options.Script.Phases) > 0 {
+		if err := validateEncounterScript(options.Script, len(encounter.AllTargets)); err != nil {
+			panic(err.Error())
+		}
+		encounter.AllTargets[0].AI = newEncounterScriptAI(options.Script)
+	}
+
 	// If UseHealth is set, we use the sum of targets health. After creating the targets to make sure stat modifications are done
 	if options.UseHealth {
 		for _, t := range options.Targets {
@@ -136,6 +144,24 @@ func (encounter *Encounter) removeInactiveTarget(target *Target) {
 	encounter.updateAOECapMultiplier()
 }
 
+// Restores the set of active targets to the state at the start of the pull,
+// since target AIs may enable and disable targets during an iteration.
+func (encounter *Encounter) resetActiveTargets() {
+	encounter.ActiveTargets = encounter.ActiveTargets[:0]
+	encounter.ActiveTargetUnits = encounter.ActiveTargetUnits[:0]
+
+	for _, target := range encounter.AllTargets {
+		target.enabled = !target.disabledAtStart
+
+		if target.enabled {
+			encounter.ActiveTargets = append(encounter.ActiveTargets, target)
+			encounter.ActiveTargetUnits = append(encounter.ActiveTargetUnits, &target.Unit)
+		}
+	}
+
+	encounter.updateAOECapMultiplier()
+}
+
 func (encounter *Encounter) doneIteration(sim *Simulation) {
 	for _, target := range encounter.AllTargets {
 		target.doneIteration(sim)
@@ -159,6 +185,8 @@ type Target struct {
 	Unit
 
 	AI TargetAI
+
+	disabledAtStart bool
 }
 
 func NewTarget(options *proto.Target, targetIndex int32) *Target {
@@ -183,6 +211,8 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 			ReactionTime:          time.Millisecond * 1620,
 			enabled:               !options.DisabledAtStart,
 		},
+
+		disabledAtStart: options.DisabledAtStart,
 	}
 	defaultRaidBossLevel := int32(CharacterLevel + 3)
 	target.GCD = target.NewTimer()
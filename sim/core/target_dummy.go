@@ -42,6 +42,17 @@ func NewTargetDummy(dummyIndex int, party *Party, partyIndex int) *TargetDummy {
 	return td
 }
 
+// Gives the dummy a health bar, so it can take damage from the encounter's
+// raid damage profile and report overhealing.
+func (td *TargetDummy) enableHealthBar(maxHealth float64) {
+	if maxHealth > 0 {
+		td.baseStats[stats.Health] = maxHealth
+	}
+	td.AddStats(stats.Stats{stats.Health: td.baseStats[stats.Health]})
+	td.EnableHealthBar()
+	td.trackChanceOfDeath(nil)
+}
+
 func (td *TargetDummy) GetCharacter() *Character {
 	return &td.Character
 }
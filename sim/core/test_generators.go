@@ -713,3 +713,38 @@ func FullCharacterTestSuiteGenerator(configs []CharacterSuiteConfig) []TestGener
 		return generator
 	})
 }
+
+// Sims the raid against every preset encounter whose path starts with
+// pathPrefix, which exercises the encounter AIs and their target inputs.
+func PresetEncountersTestGenerator(pathPrefix string, raid *proto.Raid) TestGenerator {
+	generator := &CombinedTestGenerator{}
+	for _, presetEncounter := range PresetEncounters {
+		if !strings.HasPrefix(presetEncounter.Path, pathPrefix) {
+			continue
+		}
+
+		encounter := MakeSingleTargetEncounter(0)
+		encounter.Duration = ShortDuration
+		encounter.Targets = nil
+		for _, presetTarget := range presetEncounter.Targets {
+			encounter.Targets = append(encounter.Targets, presetTarget.Target)
+		}
+
+		generator.subgenerators = append(generator.subgenerators, SubGenerator{
+			name: presetEncounter.Path[strings.LastIndex(presetEncounter.Path, "/")+1:],
+			generator: &SingleDpsTestGenerator{
+				Name: "Default",
+				Request: &proto.RaidSimRequest{
+					Raid:       raid,
+					Encounter:  encounter,
+					SimOptions: DefaultSimTestOptions,
+				},
+			},
+		})
+	}
+
+	if len(generator.subgenerators) == 0 {
+		panic("No preset encounters with path prefix: " + pathPrefix)
+	}
+	return generator
+}
@@ -797,6 +797,10 @@ func (unit *Unit) doneIteration(sim *Simulation) {
 	for _, spell := range unit.Spellbook {
 		spell.doneIteration()
 	}
+
+	if unit.Rotation != nil {
+		unit.Rotation.doneIteration()
+	}
 }
 
 func (unit *Unit) GetSpellsMatchingSchool(school SpellSchool) []*Spell {
@@ -119,6 +119,7 @@ const (
 	DruidSpellSwiftmend
 	DruidSpellWildGrowth
 	DruidSpellCenarionWard
+	DruidSpellEfflorescence
 
 	DruidSpellLast
 	DruidSpellsAll               = DruidSpellLast<<1 - 1
@@ -131,7 +132,7 @@ const (
 	DruidArcaneSpells            = DruidSpellMoonfire | DruidSpellMoonfireDoT | DruidSpellStarfire | DruidSpellStarsurge | DruidSpellStarfall
 	DruidNatureSpells            = DruidSpellWrath | DruidSpellStarsurge | DruidSpellSunfire | DruidSpellSunfireDoT | DruidSpellHurricane
 	DruidHealingNonInstantSpells = DruidSpellHealingTouch | DruidSpellRegrowth | DruidSpellNourish
-	DruidHealingSpells           = DruidHealingNonInstantSpells | DruidSpellRejuvenation | DruidSpellLifebloom | DruidSpellSwiftmend
+	DruidHealingSpells           = DruidHealingNonInstantSpells | DruidSpellRejuvenation | DruidSpellLifebloom | DruidSpellSwiftmend | DruidSpellWildGrowth | DruidSpellEfflorescence
 	DruidDamagingSpells          = DruidArcaneSpells | DruidNatureSpells
 )
 
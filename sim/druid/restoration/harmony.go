@@ -0,0 +1,56 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+// Mastery: Harmony
+// Your direct healing spells increase your periodic healing by a further
+// amount for 20 sec.
+func (resto *RestorationDruid) registerHarmony() {
+	directHeals := druid.DruidSpellHealingTouch | druid.DruidSpellRegrowth | druid.DruidSpellNourish | druid.DruidSpellSwiftmend
+
+	harmonyMultiplier := 1.0
+	resto.HarmonyAura = resto.RegisterAura(core.Aura{
+		Label:    "Harmony",
+		ActionID: core.ActionID{SpellID: 100977},
+		Duration: time.Second * 20,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			harmonyMultiplier = 1 + resto.getMasteryPercent()
+			aura.Unit.PseudoStats.PeriodicHealingDealtMultiplier *= harmonyMultiplier
+		},
+
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.PeriodicHealingDealtMultiplier /= harmonyMultiplier
+		},
+	})
+
+	resto.AddOnMasteryStatChanged(func(sim *core.Simulation, _ float64, _ float64) {
+		if !resto.HarmonyAura.IsActive() {
+			return
+		}
+
+		resto.PseudoStats.PeriodicHealingDealtMultiplier /= harmonyMultiplier
+		harmonyMultiplier = 1 + resto.getMasteryPercent()
+		resto.PseudoStats.PeriodicHealingDealtMultiplier *= harmonyMultiplier
+	})
+
+	core.MakePermanent(resto.RegisterAura(core.Aura{
+		Label:    "Mastery: Harmony",
+		ActionID: core.ActionID{SpellID: 77495},
+
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell.Matches(directHeals) {
+				resto.HarmonyAura.Activate(sim)
+			}
+		},
+	}))
+}
+
+func (resto *RestorationDruid) getMasteryPercent() float64 {
+	return (8.0 + resto.GetMasteryPoints()) * 0.0125
+}
@@ -0,0 +1,122 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+const (
+	LifebloomTickBonusCoeff  = 0.02339999937
+	LifebloomTickCoeff       = 0.23399999738
+	LifebloomBloomBonusCoeff = 0.75199997425
+	LifebloomBloomCoeff      = 7.5739998817
+)
+
+// Lifebloom stacks up to 3 times and can only be active on a single target.
+// When it expires naturally the target blooms for a direct heal that scales
+// with the number of stacks.
+func (resto *RestorationDruid) registerLifebloomSpell() {
+	baseTickHealing := LifebloomTickCoeff * resto.ClassSpellScaling
+	baseBloomHealing := LifebloomBloomCoeff * resto.ClassSpellScaling
+
+	bloomSpell := resto.RegisterSpell(druid.Any, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 33778},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellLifebloom,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagPassiveSpell | core.SpellFlagNoOnCastComplete,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		BonusCoefficient: LifebloomBloomBonusCoeff,
+	})
+
+	resto.Lifebloom = resto.RegisterSpell(druid.Humanoid|druid.Tree, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 33763},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellLifebloom,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 5.9,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label:     "Lifebloom",
+				MaxStacks: 3,
+
+				OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+					// Moving Lifebloom to another target doesn't bloom.
+					if aura.Unit != resto.lifebloomTarget {
+						return
+					}
+					resto.lifebloomTarget = nil
+
+					stacks := float64(max(aura.GetStacks(), 1))
+					bloomSpell.CalcAndDealHealing(sim, aura.Unit, baseBloomHealing*stacks, bloomSpell.OutcomeHealingCrit)
+				},
+			},
+
+			NumberOfTicks:       15,
+			TickLength:          time.Second,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    LifebloomTickBonusCoeff,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, baseTickHealing*float64(max(dot.Aura.GetStacks(), 1)))
+			},
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = resto.healTarget(sim, target)
+
+			if previousTarget := resto.lifebloomTarget; previousTarget != target {
+				resto.lifebloomTarget = target
+				if previousTarget != nil {
+					spell.Hot(previousTarget).Deactivate(sim)
+				}
+			}
+
+			hot := spell.Hot(target)
+			if !hot.IsActive() {
+				hot.Apply(sim)
+			} else {
+				hot.ApplyRollover(sim)
+			}
+			hot.AddStack(sim)
+			hot.TakeSnapshot(sim, false)
+		},
+	})
+
+	// Healing Touch, Nourish and Regrowth refresh the duration of Lifebloom.
+	core.MakeProcTriggerAura(&resto.Unit, core.ProcTrigger{
+		Name:           "Lifebloom Refresh",
+		Callback:       core.CallbackOnHealDealt,
+		ClassSpellMask: druid.DruidHealingNonInstantSpells,
+		ExtraCondition: func(_ *core.Simulation, _ *core.Spell, result *core.SpellResult) bool {
+			return result.Target == resto.lifebloomTarget
+		},
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			if hot := resto.Lifebloom.Hot(result.Target); hot.IsActive() {
+				hot.ApplyRollover(sim)
+			}
+		},
+	})
+}
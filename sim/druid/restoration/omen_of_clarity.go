@@ -0,0 +1,41 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+// Omen of Clarity: Lifebloom periodic healing has a 4% chance to make your
+// next Healing Touch or Regrowth free.
+func (resto *RestorationDruid) registerOmenOfClarity() {
+	affectedSpells := druid.DruidSpellHealingTouch | druid.DruidSpellRegrowth | druid.DruidSpellNourish
+
+	resto.ClearcastingAura = resto.RegisterAura(core.Aura{
+		Label:    "Clearcasting",
+		ActionID: core.ActionID{SpellID: 16870},
+		Duration: time.Second * 15,
+
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(affectedSpells) {
+				aura.Deactivate(sim)
+			}
+		},
+	}).AttachSpellMod(core.SpellModConfig{
+		ClassMask:  affectedSpells,
+		Kind:       core.SpellMod_PowerCost_Pct,
+		FloatValue: -2,
+	})
+
+	core.MakeProcTriggerAura(&resto.Unit, core.ProcTrigger{
+		Name:           "Omen of Clarity",
+		ActionID:       core.ActionID{SpellID: 113043},
+		Callback:       core.CallbackOnPeriodicHealDealt,
+		ClassSpellMask: druid.DruidSpellLifebloom,
+		ProcChance:     0.04,
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			resto.ClearcastingAura.Activate(sim)
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+const (
+	RegrowthBonusCoeff    = 0.95800000429
+	RegrowthCoeff         = 9.81299972534
+	RegrowthVariance      = 0.16599999368
+	RegrowthHotBonusCoeff = 0.07280000299
+	RegrowthHotCoeff      = 0.74599999189
+)
+
+func (resto *RestorationDruid) registerRegrowthSpell() {
+	hasGlyph := resto.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfRegrowth)
+	baseTickHealing := RegrowthHotCoeff * resto.ClassSpellScaling
+
+	config := core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 8936},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellRegrowth,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		BonusCoefficient: RegrowthBonusCoeff,
+
+		// Regrowth has a 60% increased chance to critically heal.
+		BonusCritPercent: 60 + core.TernaryFloat64(hasGlyph, 40, 0),
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 29.7,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = resto.healTarget(sim, target)
+
+			baseHealing := resto.CalcAndRollDamageRange(sim, RegrowthCoeff, RegrowthVariance)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+
+			if !hasGlyph {
+				spell.Hot(target).Apply(sim)
+			}
+		},
+	}
+
+	// Glyph of Regrowth removes the periodic component in exchange for the
+	// additional critical strike chance.
+	if !hasGlyph {
+		config.Hot = core.DotConfig{
+			Aura: core.Aura{
+				Label: "Regrowth",
+			},
+
+			NumberOfTicks:       3,
+			TickLength:          time.Second * 2,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    RegrowthHotBonusCoeff,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, baseTickHealing)
+			},
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		}
+	}
+
+	resto.Regrowth = resto.RegisterSpell(druid.Humanoid|druid.Tree, config)
+}
@@ -41,18 +41,75 @@ func NewRestorationDruid(character *core.Character, options *proto.Player) *Rest
 
 type RestorationDruid struct {
 	*druid.Druid
+
+	Efflorescence *druid.DruidSpell
+	Lifebloom     *druid.DruidSpell
+	Regrowth      *druid.DruidSpell
+	Swiftmend     *druid.DruidSpell
+	WildGrowth    *druid.DruidSpell
+
+	ClearcastingAura    *core.Aura
+	HarmonyAura         *core.Aura
+	SoulOfTheForestAura *core.Aura
+
+	lifebloomTarget *core.Unit
 }
 
 func (resto *RestorationDruid) GetDruid() *druid.Druid {
 	return resto.Druid
 }
 
+func (resto *RestorationDruid) GetMainTarget() *core.Unit {
+	target := resto.Env.Raid.GetFirstTargetDummy()
+	if target == nil {
+		return &resto.Unit
+	} else {
+		return &target.Unit
+	}
+}
+
 func (resto *RestorationDruid) Initialize() {
+	resto.CurrentTarget = resto.GetMainTarget()
 	resto.Druid.Initialize()
+
+	resto.RegisterRestorationSpells()
+	resto.registerHarmony() // Mastery
+	resto.registerOmenOfClarity()
+}
+
+func (resto *RestorationDruid) RegisterRestorationSpells() {
+	resto.registerLifebloomSpell()
+	resto.registerRegrowthSpell()
+	resto.registerWildGrowthSpell()
+	resto.registerEfflorescenceSpell()
+	resto.registerSwiftmendSpell()
 }
 
-func (resto *RestorationDruid) ApplyTalents() {}
+func (resto *RestorationDruid) ApplyTalents() {
+	resto.Druid.ApplyTalents()
+
+	resto.ApplyRestorationTalents()
+}
 
 func (resto *RestorationDruid) Reset(sim *core.Simulation) {
+	resto.lifebloomTarget = nil
 	resto.Druid.Reset(sim)
 }
+
+// Heals cast on an enemy, e.g. from a plain Cast APL action, land on the
+// lowest health ally instead.
+func (resto *RestorationDruid) healTarget(sim *core.Simulation, target *core.Unit) *core.Unit {
+	if target != nil && !target.IsOpponent(&resto.Unit) {
+		return target
+	}
+	if lowest := sim.Raid.GetLowestHealthAllyUnit(); lowest != nil {
+		return lowest
+	}
+	return &resto.Unit
+}
+
+// Returns up to maxTargets allies for a smart heal, starting with the primary
+// target and followed by the lowest health allies.
+func (resto *RestorationDruid) smartHealTargets(sim *core.Simulation, primary *core.Unit, maxTargets int) []*core.Unit {
+	return sim.Raid.GetSmartHealTargets(resto.healTarget(sim, primary), maxTargets)
+}
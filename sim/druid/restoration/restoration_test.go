@@ -0,0 +1,77 @@
+package restoration
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/common" // imported to get item effects included.
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func init() {
+	RegisterRestorationDruid()
+	common.RegisterAllEffects()
+}
+
+func TestRestoration(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassDruid,
+			Race:       proto.Race_RaceTauren,
+			OtherRaces: []proto.Race{proto.Race_RaceNightElf},
+			IsHealer:   true,
+
+			GearSet: core.GetGearSet("../../../ui/druid/balance/gear_sets", "preraid"),
+			Talents: StandardTalents,
+			OtherTalentSets: []core.TalentsCombo{
+				{Label: "GlyphOfRegrowth", Talents: StandardTalents, Glyphs: RegrowthGlyphs},
+			},
+			Glyphs:      StandardGlyphs,
+			Consumables: FullConsumesSpec,
+			SpecOptions: core.SpecOptionsCombo{Label: "Standard", SpecOptions: PlayerOptionsStandard},
+			Rotation:    core.GetAplRotation("../../../ui/druid/restoration/apls", "default"),
+
+			ItemFilter: core.ItemFilter{
+				WeaponTypes: []proto.WeaponType{
+					proto.WeaponType_WeaponTypeDagger,
+					proto.WeaponType_WeaponTypeMace,
+					proto.WeaponType_WeaponTypeOffHand,
+					proto.WeaponType_WeaponTypeStaff,
+					proto.WeaponType_WeaponTypePolearm,
+				},
+				ArmorType: proto.ArmorType_ArmorTypeLeather,
+			},
+		},
+	}))
+}
+
+var StandardTalents = "113123"
+
+var StandardGlyphs = &proto.Glyphs{
+	Major1: int32(proto.DruidMajorGlyph_GlyphOfWildGrowth),
+	Major2: int32(proto.DruidMajorGlyph_GlyphOfRejuvenation),
+	Major3: int32(proto.DruidMajorGlyph_GlyphOfHealingTouch),
+}
+
+var RegrowthGlyphs = &proto.Glyphs{
+	Major1: int32(proto.DruidMajorGlyph_GlyphOfRegrowth),
+	Major2: int32(proto.DruidMajorGlyph_GlyphOfEfflorescence),
+	Major3: int32(proto.DruidMajorGlyph_GlyphOfHealingTouch),
+}
+
+var FullConsumesSpec = &proto.ConsumesSpec{
+	FlaskId:  76085, // Flask of the Warm Sun
+	FoodId:   74650, // Mogu Fish Stew
+	PotId:    76093, // Potion of the Jade Serpent
+	PrepotId: 76093, // Potion of the Jade Serpent
+}
+
+var PlayerOptionsStandard = &proto.Player_RestorationDruid{
+	RestorationDruid: &proto.RestorationDruid{
+		Options: &proto.RestorationDruid_Options{
+			ClassOptions: &proto.DruidOptions{
+				InnervateTarget: &proto.UnitReference{Type: proto.UnitReference_Player, Index: 0}, // self innervate
+			},
+		},
+	},
+}
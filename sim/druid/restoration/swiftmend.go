@@ -0,0 +1,143 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+const (
+	SwiftmendBonusCoeff = 1.29100000858
+	SwiftmendCoeff      = 13.2340002060
+
+	EfflorescenceBonusCoeff = 0.30990001559
+	EfflorescenceCoeff      = 3.17499995232
+	EfflorescenceMaxTargets = 3
+)
+
+// Swiftmend requires a Rejuvenation or Regrowth on the target and creates
+// Efflorescence at the target's feet.
+func (resto *RestorationDruid) registerSwiftmendSpell() {
+	resto.Swiftmend = resto.RegisterSpell(druid.Humanoid|druid.Tree, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 18562},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellSwiftmend,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		BonusCoefficient: SwiftmendBonusCoeff,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 8.5,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return resto.swiftmendTarget(sim, target) != nil
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = resto.swiftmendTarget(sim, target)
+
+			baseHealing := SwiftmendCoeff * resto.ClassSpellScaling
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+
+			if resto.Efflorescence != nil {
+				resto.Efflorescence.Cast(sim, target)
+			}
+		},
+	})
+}
+
+// Returns the Swiftmend target, preferring the given target and otherwise
+// falling back to any ally with a Rejuvenation or Regrowth.
+func (resto *RestorationDruid) swiftmendTarget(sim *core.Simulation, target *core.Unit) *core.Unit {
+	hasHot := func(unit *core.Unit) bool {
+		if resto.Rejuvenation.Hot(unit).IsActive() {
+			return true
+		}
+		regrowth := resto.Regrowth.Hot(unit)
+		return regrowth != nil && regrowth.IsActive()
+	}
+
+	target = resto.healTarget(sim, target)
+	if hasHot(target) {
+		return target
+	}
+	for _, unit := range resto.smartHealTargets(sim, target, len(sim.Raid.AllUnits)) {
+		if hasHot(unit) {
+			return unit
+		}
+	}
+	return nil
+}
+
+// Efflorescence heals up to 3 allies around the Swiftmend target every
+// second. With the glyph it is created by Wild Mushroom instead, which
+// isn't modelled, so the glyph only moves it off of Swiftmend.
+func (resto *RestorationDruid) registerEfflorescenceSpell() {
+	if resto.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfEfflorescence) {
+		return
+	}
+
+	baseHealing := EfflorescenceCoeff * resto.ClassSpellScaling
+	var efflorescenceTarget *core.Unit
+
+	tickSpell := resto.RegisterSpell(druid.Any, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 81269},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellEfflorescence,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagPassiveSpell | core.SpellFlagNoOnCastComplete,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		BonusCoefficient: EfflorescenceBonusCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+
+	resto.Efflorescence = resto.RegisterSpell(druid.Any, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 145205},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskEmpty,
+		ClassSpellMask: druid.DruidSpellEfflorescence,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagPassiveSpell | core.SpellFlagNoOnCastComplete,
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Efflorescence",
+			},
+
+			NumberOfTicks: 7,
+			TickLength:    time.Second,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, _ *core.Dot) {
+				for _, healTarget := range resto.smartHealTargets(sim, efflorescenceTarget, EfflorescenceMaxTargets) {
+					tickSpell.Cast(sim, healTarget)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			efflorescenceTarget = target
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
@@ -0,0 +1,53 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+func (resto *RestorationDruid) ApplyRestorationTalents() {
+	resto.registerSoulOfTheForest()
+}
+
+// Soul of the Forest: Swiftmend empowers your next Healing Touch, Regrowth,
+// Rejuvenation or Wild Growth within 15 sec.
+func (resto *RestorationDruid) registerSoulOfTheForest() {
+	if !resto.Talents.SoulOfTheForest {
+		return
+	}
+
+	empoweredSpells := druid.DruidHealingNonInstantSpells | druid.DruidSpellRejuvenation | druid.DruidSpellWildGrowth
+
+	resto.SoulOfTheForestAura = resto.RegisterAura(core.Aura{
+		Label:    "Soul of the Forest",
+		ActionID: core.ActionID{SpellID: 114108},
+		Duration: time.Second * 15,
+
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(empoweredSpells) {
+				aura.Deactivate(sim)
+			}
+		},
+	}).AttachSpellMod(core.SpellModConfig{
+		// 100% haste on the next cast.
+		ClassMask:  druid.DruidHealingNonInstantSpells,
+		Kind:       core.SpellMod_CastTime_Pct,
+		FloatValue: -0.5,
+	}).AttachSpellMod(core.SpellModConfig{
+		ClassMask:  druid.DruidSpellRejuvenation,
+		Kind:       core.SpellMod_DamageDone_Pct,
+		FloatValue: 1,
+	})
+	// Wild Growth's 3 additional targets are handled by the spell itself.
+
+	core.MakeProcTriggerAura(&resto.Unit, core.ProcTrigger{
+		Name:           "Soul of the Forest Trigger",
+		Callback:       core.CallbackOnCastComplete,
+		ClassSpellMask: druid.DruidSpellSwiftmend,
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			resto.SoulOfTheForestAura.Activate(sim)
+		},
+	})
+}
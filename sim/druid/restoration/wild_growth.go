@@ -0,0 +1,76 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+const (
+	WildGrowthBonusCoeff = 0.13099999726
+	WildGrowthCoeff      = 1.34000003338
+)
+
+// Wild Growth heals the target and the lowest health allies over 7 sec.
+func (resto *RestorationDruid) registerWildGrowthSpell() {
+	hasGlyph := resto.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfWildGrowth)
+	numTargets := 6 + core.TernaryInt(hasGlyph, 1, 0)
+	baseTickHealing := WildGrowthCoeff * resto.ClassSpellScaling
+
+	resto.WildGrowth = resto.RegisterSpell(druid.Humanoid|druid.Tree, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 48438},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellWildGrowth,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 22.9,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: time.Second*8 + core.TernaryDuration(hasGlyph, time.Second*2, 0),
+			},
+		},
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Wild Growth",
+			},
+
+			NumberOfTicks:       7,
+			TickLength:          time.Second,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    WildGrowthBonusCoeff,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, baseTickHealing)
+			},
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			maxTargets := numTargets
+			if resto.SoulOfTheForestAura != nil && resto.SoulOfTheForestAura.IsActive() {
+				maxTargets += 3
+			}
+
+			for _, healTarget := range resto.smartHealTargets(sim, target, maxTargets) {
+				spell.Hot(healTarget).Apply(sim)
+			}
+		},
+	})
+}
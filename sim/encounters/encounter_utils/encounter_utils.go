@@ -0,0 +1,168 @@
+// Package encounter_utils contains helpers shared by the raid encounter AIs.
+package encounter_utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+// Raid difficulties covered by encounter presets, in scaling index order.
+var Difficulties = []struct {
+	RaidSize int32
+	IsHeroic bool
+}{
+	{10, false},
+	{25, false},
+	{10, true},
+	{25, true},
+}
+
+// 0 - 10N, 1 - 25N, 2 - 10H, 3 - 25H
+func ScalingIndex(raidSize int32, isHeroic bool) int {
+	return core.TernaryInt(raidSize == 10, core.TernaryInt(isHeroic, 2, 0), core.TernaryInt(isHeroic, 3, 1))
+}
+
+// Preset AIs are looked up by target ID, so each difficulty needs its own ID.
+func PresetID(npcID int32, raidSize int32, isHeroic bool) int32 {
+	return npcID*10 + int32(ScalingIndex(raidSize, isHeroic))
+}
+
+func PresetName(name string, raidSize int32, isHeroic bool) string {
+	name = fmt.Sprintf("%s %d", name, raidSize)
+	if isHeroic {
+		name += " H"
+	}
+	return name
+}
+
+// Returns the unit targeted by boss abilities. For individual non tank sims we
+// still want abilities to work, so untanked bosses fall back to the first raid
+// member, if there is one.
+func SpellTarget(target *core.Target) *core.Unit {
+	players := target.Env.Raid.AllPlayerUnits
+	if target.CurrentTarget != nil || len(players) == 0 {
+		return target.CurrentTarget
+	}
+	return players[0]
+}
+
+// Boss health phases are approximated by fight progress. In health based fights
+// this is the actual boss health, otherwise it assumes constant raid DPS.
+func BossHealthBelow(sim *core.Simulation, healthPercent float64) bool {
+	return sim.GetRemainingDurationPercent()*100 <= healthPercent
+}
+
+// Makes every raid member move for the given duration, letting hard casts in
+// progress finish first.
+func MoveRaid(sim *core.Simulation, duration time.Duration) {
+	for _, player := range sim.Raid.AllPlayerUnits {
+		MovePlayer(sim, player, duration)
+	}
+}
+
+func MovePlayer(sim *core.Simulation, player *core.Unit, duration time.Duration) {
+	afterHardcast(sim, player, func(sim *core.Simulation) {
+		player.MoveDuration(duration, sim)
+	})
+}
+
+// Knocks a raid member back by the given distance (in yards), after which they
+// walk back to where they were standing.
+func KnockBack(sim *core.Simulation, player *core.Unit, distance float64) {
+	afterHardcast(sim, player, func(sim *core.Simulation) {
+		player.FinalizeMovement(sim)
+		position := player.DistanceFromTarget
+		player.DistanceFromTarget += distance
+		player.MoveTo(position, sim)
+	})
+}
+
+func afterHardcast(sim *core.Simulation, player *core.Unit, onAction func(*core.Simulation)) {
+	if player.Hardcast.Expires <= sim.CurrentTime || player.Hardcast.CanMove {
+		onAction(sim)
+		return
+	}
+
+	pa := sim.GetConsumedPendingActionFromPool()
+	pa.NextActionAt = player.Hardcast.Expires
+	pa.Priority = core.ActionPriorityPrePull + 1
+	pa.OnAction = onAction
+	sim.AddPendingAction(pa)
+}
+
+// Picks a random raid member.
+func RandomPlayer(sim *core.Simulation, label string) *core.Unit {
+	players := sim.Raid.AllPlayerUnits
+	return players[int(sim.RandomFloat(label)*float64(len(players)))]
+}
+
+// Deals a roll of (base + variance * rand) damage to every raid member.
+func DealRaidDamage(sim *core.Simulation, spell *core.Spell, base float64, variance float64) {
+	for _, player := range sim.Raid.AllPlayerUnits {
+		damageRoll := base + variance*sim.RandomFloat("Raid Damage")
+		spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+	}
+}
+
+type RaidChannelConfig struct {
+	ActionID    core.ActionID
+	SpellSchool core.SpellSchool
+	Cooldown    time.Duration
+	ChannelTime time.Duration
+
+	// Damage dealt to every raid member on each 1 second pulse of the channel.
+	PulseBase     float64
+	PulseVariance float64
+
+	// How long the raid spends moving at the start of the channel.
+	MoveDuration time.Duration
+}
+
+// Registers a raid-wide boss channel. The boss hardcasts the channel, so it
+// shows up in APLValueBossSpellIsCasting, and stops meleeing while pulsing
+// damage to the raid every second.
+func RegisterRaidChannel(unit *core.Unit, config RaidChannelConfig) *core.Spell {
+	const pulseInterval = time.Second
+
+	return unit.RegisterSpell(core.SpellConfig{
+		ActionID:         config.ActionID,
+		SpellSchool:      config.SpellSchool,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      config.ChannelTime + core.BossGCD,
+				CastTime: config.ChannelTime,
+			},
+
+			CD: core.Cooldown{
+				Timer:    unit.NewTimer(),
+				Duration: config.Cooldown,
+			},
+
+			IgnoreHaste: true,
+
+			ModifyCast: func(sim *core.Simulation, spell *core.Spell, curCast *core.Cast) {
+				spell.Unit.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+curCast.CastTime)
+
+				if config.MoveDuration > 0 {
+					MoveRaid(sim, config.MoveDuration)
+				}
+
+				core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+					Period:   pulseInterval,
+					NumTicks: int(curCast.CastTime / pulseInterval),
+					Priority: core.ActionPriorityDOT,
+
+					OnAction: func(sim *core.Simulation) {
+						DealRaidDamage(sim, spell, config.PulseBase, config.PulseVariance)
+					},
+				})
+			},
+		},
+	})
+}
@@ -0,0 +1,7 @@
+package hof
+
+func Register() {
+	addMeljarak("Heart of Fear")
+	addUnsok("Heart of Fear")
+	addShekzeer("Heart of Fear")
+}
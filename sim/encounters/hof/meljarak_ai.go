@@ -0,0 +1,332 @@
+package hof
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const meljarakMeleeDamageSpread = 0.4
+const meljarakBossID int32 = 62397
+
+// Each pack of Mel'jarak's adds is modeled as a single target.
+var meljarakAddPacks = []struct {
+	name  string
+	npcID int32
+}{
+	{"Kor'thik Elite Blademaster", 62402},
+	{"Sra'thik Amber-Trapper", 62405},
+	{"Zar'thik Battle-Mender", 62408},
+}
+
+func addMeljarak(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{72_000_000, 200_000_000, 108_000_000, 300_000_000}
+	bossMinBaseDamage := []float64{110_000, 165_000, 160_000, 245_000}
+	addPackHealth := []float64{8_000_000, 22_000_000, 12_000_000, 33_000_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createMeljarakPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], addPackHealth[idx])
+	}
+}
+
+func createMeljarakPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, addPackHealth float64) {
+	bossName := encounter_utils.PresetName("Wind Lord Mel'jarak", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(meljarakBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    1.5,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  meljarakMeleeDamageSpread,
+			TargetInputs:  meljarakTargetInputs(),
+		},
+
+		AI: makeMeljarakAI(raidSize, isHeroic, true),
+	})
+
+	targetPathNames := []string{raidPrefix + "/" + bossName}
+
+	for _, addPack := range meljarakAddPacks {
+		addName := encounter_utils.PresetName(addPack.name, raidSize, isHeroic)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        encounter_utils.PresetID(addPack.npcID, raidSize, isHeroic),
+				Name:      addName,
+				Level:     92,
+				MobType:   proto.MobType_MobTypeHumanoid,
+				TankIndex: 1,
+
+				Stats: stats.Stats{
+					stats.Health: addPackHealth,
+					stats.Armor:  24835, // TODO: verify add armor
+				}.ToProtoArray(),
+
+				SpellSchool:     proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:      2.0,
+				MinBaseDamage:   bossMinBaseDamage * 0.3,
+				DamageSpread:    meljarakMeleeDamageSpread,
+				TargetInputs:    []*proto.TargetInput{},
+				DisabledAtStart: true,
+			},
+
+			AI: makeMeljarakAI(raidSize, isHeroic, false),
+		})
+
+		targetPathNames = append(targetPathNames, raidPrefix+"/"+addName)
+	}
+
+	core.AddPresetEncounter(bossName, targetPathNames)
+}
+
+func meljarakTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Add packs killed",
+			Tooltip:     "Number of add packs (0-3) that are stacked with the boss and killed, rather than crowd controlled for the whole fight",
+			InputType:   proto.InputType_Number,
+			NumberValue: 1,
+		},
+		{
+			Label:       "Add pack kill time",
+			Tooltip:     "Time (in seconds) that it takes to kill each add pack. Packs are killed one after another, starting on pull.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 50,
+		},
+	}
+}
+
+func makeMeljarakAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &MeljarakAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type MeljarakAI struct {
+	// Unit references
+	Target   *core.Target
+	BossUnit *core.Unit
+	AddUnits []*core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	numAddPacksKilled int
+	addPackKillTime   time.Duration
+
+	// Spell + aura references
+	RainOfBlades     *core.Spell
+	WhirlingBlade    *core.Spell
+	WindBomb         *core.Spell
+	RecklessnessAura *core.Aura
+}
+
+func (ai *MeljarakAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+	ai.AddUnits = target.Env.Encounter.AllTargetUnits[1:]
+
+	if !ai.isBoss {
+		return
+	}
+
+	// Save user input parameters
+	ai.numAddPacksKilled = min(max(int(config.TargetInputs[0].NumberValue), 0), len(ai.AddUnits))
+	ai.addPackKillTime = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+	// Register relevant spells and auras
+	ai.registerRainOfBlades()
+	ai.registerWhirlingBlade()
+	ai.registerWindBomb()
+	ai.registerAddPacks()
+}
+
+func (ai *MeljarakAI) registerRainOfBlades() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	rainOfBladesPulse := []float64{22_000, 26_000, 32_000, 38_000}[idx]
+
+	ai.RainOfBlades = encounter_utils.RegisterRaidChannel(ai.BossUnit, encounter_utils.RaidChannelConfig{
+		ActionID:      core.ActionID{SpellID: 122406},
+		SpellSchool:   core.SpellSchoolPhysical,
+		Cooldown:      time.Second * 60,
+		ChannelTime:   time.Second * 6,
+		PulseBase:     rainOfBladesPulse,
+		PulseVariance: rainOfBladesPulse * 0.1,
+	})
+}
+
+func (ai *MeljarakAI) registerWhirlingBlade() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	whirlingBladeBase := []float64{70_000, 80_000, 100_000, 115_000}[idx]
+	numTargets := core.TernaryInt(ai.raidSize == 10, 2, 4)
+
+	ai.WhirlingBlade = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 121896},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond*1500 + core.BossGCD,
+				CastTime: time.Millisecond * 1500,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 45,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		// The blade travels out and back, hitting players in its path twice.
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			for i := 0; i < min(numTargets, len(players)); i++ {
+				player := players[int(sim.RandomFloat("Whirling Blade Target")*float64(len(players)))]
+				damageRoll := whirlingBladeBase * (0.9 + 0.2*sim.RandomFloat("Whirling Blade"))
+				spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+// Below 75% health, Mel'jarak starts dropping Wind Bombs that players have to
+// move away from.
+func (ai *MeljarakAI) registerWindBomb() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	windBombBase := []float64{60_000, 70_000, 85_000, 100_000}[idx]
+
+	ai.WindBomb = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 131813},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 20,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			player := players[int(sim.RandomFloat("Wind Bomb Target")*float64(len(players)))]
+			damageRoll := windBombBase * (0.9 + 0.2*sim.RandomFloat("Wind Bomb"))
+			spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+			encounter_utils.MovePlayer(sim, player, time.Second*2)
+		},
+	})
+}
+
+// Killed add packs are cleaved down one after another from the pull. Every
+// pack that dies gives Mel'jarak a stack of Recklessness.
+func (ai *MeljarakAI) registerAddPacks() {
+	ai.RecklessnessAura = ai.BossUnit.RegisterAura(core.Aura{
+		Label:     "Recklessness",
+		ActionID:  core.ActionID{SpellID: 125873},
+		Duration:  core.NeverExpires,
+		MaxStacks: int32(len(meljarakAddPacks)),
+
+		OnStacksChange: func(aura *core.Aura, _ *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= (1 + 0.2*float64(newStacks)) / (1 + 0.2*float64(oldStacks))
+		},
+	})
+
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		for packIdx, addUnit := range ai.AddUnits[:ai.numAddPacksKilled] {
+			pa := sim.GetConsumedPendingActionFromPool()
+			pa.NextActionAt = ai.addPackKillTime * time.Duration(packIdx)
+			pa.Priority = core.ActionPriorityDOT
+
+			pa.OnAction = func(sim *core.Simulation) {
+				sim.EnableTargetUnit(addUnit)
+			}
+
+			sim.AddPendingAction(pa)
+
+			killPA := sim.GetConsumedPendingActionFromPool()
+			killPA.NextActionAt = ai.addPackKillTime * time.Duration(packIdx+1)
+			killPA.Priority = core.ActionPriorityDOT
+
+			killPA.OnAction = func(sim *core.Simulation) {
+				sim.DisableTargetUnit(addUnit, true)
+				ai.RecklessnessAura.Activate(sim)
+				ai.RecklessnessAura.AddStack(sim)
+			}
+
+			sim.AddPendingAction(killPA)
+		}
+	})
+}
+
+func (ai *MeljarakAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	ai.RainOfBlades.CD.Set(time.Second * 60)
+	ai.WhirlingBlade.CD.Set(core.DurationFromSeconds(30 + 10*sim.RandomFloat("Whirling Blade Timing")))
+}
+
+func (ai *MeljarakAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if ai.RainOfBlades.IsReady(sim) {
+		ai.RainOfBlades.Cast(sim, target)
+		return
+	}
+
+	if ai.WhirlingBlade.IsReady(sim) {
+		ai.WhirlingBlade.Cast(sim, target)
+		return
+	}
+
+	if encounter_utils.BossHealthBelow(sim, 75) && ai.WindBomb.IsReady(sim) {
+		ai.WindBomb.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,388 @@
+package hof
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const shekzeerMeleeDamageSpread = 0.4
+const shekzeerBossID int32 = 62837
+
+// Shek'zeer stops retreating and enters the final phase below this boss
+// health percentage.
+const shekzeerPhase3Health = 30
+
+var shekzeerAdds = []struct {
+	name  string
+	npcID int32
+}{
+	{"Set'thik Windblade", 63589},
+	{"Kor'thik Reaver", 63591},
+}
+
+func addShekzeer(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{80_000_000, 225_000_000, 120_000_000, 335_000_000}
+	bossMinBaseDamage := []float64{115_000, 170_000, 165_000, 250_000}
+	addHealth := []float64{6_000_000, 17_000_000, 9_000_000, 25_000_000}
+	addMinBaseDamage := []float64{50_000, 70_000, 70_000, 100_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createShekzeerPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], addHealth[idx], addMinBaseDamage[idx])
+	}
+}
+
+func createShekzeerPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, addHealth float64, addMinBaseDamage float64) {
+	bossName := encounter_utils.PresetName("Grand Empress Shek'zeer", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(shekzeerBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  shekzeerMeleeDamageSpread,
+			TargetInputs:  shekzeerTargetInputs(),
+		},
+
+		AI: makeShekzeerAI(raidSize, isHeroic, true),
+	})
+
+	targetPathNames := []string{raidPrefix + "/" + bossName}
+
+	for addIdx, add := range shekzeerAdds {
+		addName := encounter_utils.PresetName(add.name, raidSize, isHeroic)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        encounter_utils.PresetID(add.npcID, raidSize, isHeroic),
+				Name:      addName,
+				Level:     92,
+				MobType:   proto.MobType_MobTypeHumanoid,
+				TankIndex: int32(addIdx),
+
+				Stats: stats.Stats{
+					stats.Health: addHealth,
+					stats.Armor:  24835, // TODO: verify add armor
+				}.ToProtoArray(),
+
+				SpellSchool:     proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:      2.0,
+				MinBaseDamage:   addMinBaseDamage,
+				DamageSpread:    shekzeerMeleeDamageSpread,
+				TargetInputs:    []*proto.TargetInput{},
+				DisabledAtStart: true,
+			},
+
+			AI: makeShekzeerAI(raidSize, isHeroic, false),
+		})
+
+		targetPathNames = append(targetPathNames, raidPrefix+"/"+addName)
+	}
+
+	core.AddPresetEncounter(bossName, targetPathNames)
+}
+
+func shekzeerTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Phase 1 duration",
+			Tooltip:     "Time (in seconds) that Shek'zeer stays engaged before retreating and sending in her Royal Guard",
+			InputType:   proto.InputType_Number,
+			NumberValue: 150,
+		},
+		{
+			Label:       "Phase 2 duration",
+			Tooltip:     "Time (in seconds) that Shek'zeer stays out of combat while the raid fights her adds",
+			InputType:   proto.InputType_Number,
+			NumberValue: 150,
+		},
+	}
+}
+
+func makeShekzeerAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &ShekzeerAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type ShekzeerAI struct {
+	// Unit references
+	Target   *core.Target
+	BossUnit *core.Unit
+	AddUnits []*core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	phase1Duration time.Duration
+	phase2Duration time.Duration
+
+	// Spell references
+	DreadScreech *core.Spell
+	CryOfTerror  *core.Spell
+	ShaEnergy    *core.Spell
+	Calamity     *core.Spell
+}
+
+func (ai *ShekzeerAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+	ai.AddUnits = target.Env.Encounter.AllTargetUnits[1:]
+
+	if !ai.isBoss {
+		return
+	}
+
+	// Save user input parameters
+	ai.phase1Duration = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.phase2Duration = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+	// Register relevant spells
+	ai.registerPhase1Spells()
+	ai.registerPhase3Spells()
+	ai.registerRetreats()
+}
+
+func (ai *ShekzeerAI) registerPhase1Spells() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	dreadScreechBase := []float64{45_000, 50_000, 65_000, 72_000}[idx]
+	cryOfTerrorBase := []float64{20_000, 24_000, 30_000, 35_000}[idx]
+	numScreechTargets := core.TernaryInt(ai.raidSize == 10, 2, 5)
+
+	ai.DreadScreech = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 123735},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			for i := 0; i < min(numScreechTargets, len(players)); i++ {
+				player := players[int(sim.RandomFloat("Dread Screech Target")*float64(len(players)))]
+				damageRoll := dreadScreechBase * (0.9 + 0.2*sim.RandomFloat("Dread Screech"))
+				spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	ai.CryOfTerror = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 123788},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 25,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			player := players[int(sim.RandomFloat("Cry of Terror Target")*float64(len(players)))]
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				NumTicks: 10,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					damageRoll := cryOfTerrorBase * (0.9 + 0.2*sim.RandomFloat("Cry of Terror"))
+					spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+				},
+			})
+		},
+	})
+}
+
+func (ai *ShekzeerAI) registerPhase3Spells() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	shaEnergyBase := []float64{18_000, 20_000, 26_000, 30_000}[idx]
+	calamityBase := []float64{0, 0, 120_000, 135_000}[idx]
+	numShaEnergyTargets := core.TernaryInt(ai.raidSize == 10, 2, 5)
+
+	ai.ShaEnergy = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 125464},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			for i := 0; i < min(numShaEnergyTargets, len(players)); i++ {
+				player := players[int(sim.RandomFloat("Sha Energy Target")*float64(len(players)))]
+				damageRoll := shaEnergyBase * (0.9 + 0.2*sim.RandomFloat("Sha Energy"))
+				spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   time.Second,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				if ai.BossUnit.IsEnabled() && encounter_utils.BossHealthBelow(sim, shekzeerPhase3Health) {
+					ai.ShaEnergy.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+				}
+			},
+		})
+	})
+
+	// Calamity is a heroic only raid wide nuke.
+	if !ai.isHeroic {
+		return
+	}
+
+	ai.Calamity = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 124845},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second + core.BossGCD,
+				CastTime: time.Second,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 45,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, calamityBase, calamityBase*0.1)
+		},
+	})
+}
+
+// Shek'zeer alternates between fighting the raid and retreating while her
+// Royal Guard attacks, until she is pushed into the final phase.
+func (ai *ShekzeerAI) registerRetreats() {
+	var startRetreat, endRetreat func(sim *core.Simulation)
+
+	schedule := func(sim *core.Simulation, delay time.Duration, action func(sim *core.Simulation)) {
+		pa := sim.GetConsumedPendingActionFromPool()
+		pa.NextActionAt = sim.CurrentTime + delay
+		pa.Priority = core.ActionPriorityDOT
+		pa.OnAction = action
+		sim.AddPendingAction(pa)
+	}
+
+	startRetreat = func(sim *core.Simulation) {
+		if encounter_utils.BossHealthBelow(sim, shekzeerPhase3Health) {
+			return
+		}
+
+		for _, addUnit := range ai.AddUnits {
+			sim.EnableTargetUnit(addUnit)
+		}
+
+		sim.DisableTargetUnit(ai.BossUnit, false)
+		schedule(sim, ai.phase2Duration, endRetreat)
+	}
+
+	endRetreat = func(sim *core.Simulation) {
+		sim.EnableTargetUnit(ai.BossUnit)
+
+		for _, addUnit := range ai.AddUnits {
+			sim.DisableTargetUnit(addUnit, true)
+		}
+
+		schedule(sim, ai.phase1Duration, startRetreat)
+	}
+
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		schedule(sim, ai.phase1Duration, startRetreat)
+	})
+}
+
+func (ai *ShekzeerAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	ai.DreadScreech.CD.Set(core.DurationFromSeconds(6 * sim.RandomFloat("Dread Screech Timing")))
+	ai.CryOfTerror.CD.Set(core.DurationFromSeconds(20 + 5*sim.RandomFloat("Cry of Terror Timing")))
+}
+
+func (ai *ShekzeerAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if encounter_utils.BossHealthBelow(sim, shekzeerPhase3Health) {
+		if (ai.Calamity != nil) && ai.Calamity.IsReady(sim) {
+			ai.Calamity.Cast(sim, target)
+			return
+		}
+	} else {
+		if ai.DreadScreech.IsReady(sim) {
+			ai.DreadScreech.Cast(sim, target)
+			return
+		}
+
+		if ai.CryOfTerror.IsReady(sim) {
+			ai.CryOfTerror.Cast(sim, target)
+			return
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,403 @@
+package hof
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const unsokMeleeDamageSpread = 0.4
+const unsokBossID int32 = 62511
+const amberMonstrosityID int32 = 62711
+
+// Un'sok enters the final phase once the boss reaches this health percentage.
+const unsokPhase3Health = 30
+
+func addUnsok(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{75_000_000, 210_000_000, 112_000_000, 315_000_000}
+	bossMinBaseDamage := []float64{105_000, 155_000, 150_000, 230_000}
+	monstrosityHealth := []float64{30_000_000, 85_000_000, 45_000_000, 125_000_000}
+	monstrosityMinBaseDamage := []float64{125_000, 180_000, 175_000, 270_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createUnsokPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], monstrosityHealth[idx], monstrosityMinBaseDamage[idx])
+	}
+}
+
+func createUnsokPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, monstrosityHealth float64, monstrosityMinBaseDamage float64) {
+	bossName := encounter_utils.PresetName("Amber-Shaper Un'sok", raidSize, isHeroic)
+	monstrosityName := encounter_utils.PresetName("Amber Monstrosity", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(unsokBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  unsokMeleeDamageSpread,
+			TargetInputs:  unsokTargetInputs(),
+		},
+
+		AI: makeUnsokAI(raidSize, isHeroic, true),
+	})
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(amberMonstrosityID, raidSize, isHeroic),
+			Name:      monstrosityName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health: monstrosityHealth,
+				stats.Armor:  24835,
+			}.ToProtoArray(),
+
+			SpellSchool:     proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:      2.0,
+			MinBaseDamage:   monstrosityMinBaseDamage,
+			DamageSpread:    unsokMeleeDamageSpread,
+			TargetInputs:    []*proto.TargetInput{},
+			DisabledAtStart: true,
+		},
+
+		AI: makeUnsokAI(raidSize, isHeroic, false),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + monstrosityName,
+	})
+}
+
+func unsokTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Phase 2 health",
+			Tooltip:     "Boss health percentage at which the Amber Monstrosity spawns",
+			InputType:   proto.InputType_Number,
+			NumberValue: 70,
+		},
+		{
+			Label:       "Monstrosity kill time",
+			Tooltip:     "Time (in seconds) after spawning that the Amber Monstrosity dies. The Monstrosity is always killed before the final phase.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 75,
+		},
+	}
+}
+
+func makeUnsokAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &UnsokAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type UnsokAI struct {
+	// Unit references
+	Target          *core.Target
+	BossUnit        *core.Unit
+	MonstrosityUnit *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	phase2Health        float64
+	monstrosityKillTime time.Duration
+
+	// Spell references
+	AmberScalpel         *core.Spell
+	ParasiticGrowth      *core.Spell
+	ReshapeLife          *core.Spell
+	MassiveStomp         *core.Spell
+	AmberExplosion       *core.Spell
+	ConcentratedMutation *core.Spell
+
+	// Encounter state
+	monstrositySpawned bool
+}
+
+func (ai *UnsokAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+	ai.MonstrosityUnit = target.Env.Encounter.AllTargetUnits[1]
+
+	if !ai.isBoss {
+		ai.registerMonstrositySpells()
+		return
+	}
+
+	// Save user input parameters
+	ai.phase2Health = config.TargetInputs[0].NumberValue
+	ai.monstrosityKillTime = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+	// Register relevant spells
+	ai.registerBossSpells()
+	ai.registerConcentratedMutation()
+}
+
+func (ai *UnsokAI) registerBossSpells() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	amberScalpelPulse := []float64{15_000, 18_000, 22_000, 26_000}[idx]
+	parasiticGrowthBase := []float64{50_000, 60_000, 75_000, 90_000}[idx]
+
+	ai.AmberScalpel = encounter_utils.RegisterRaidChannel(ai.BossUnit, encounter_utils.RaidChannelConfig{
+		ActionID:      core.ActionID{SpellID: 121994},
+		SpellSchool:   core.SpellSchoolNature,
+		Cooldown:      time.Second * 50,
+		ChannelTime:   time.Second * 10,
+		PulseBase:     amberScalpelPulse,
+		PulseVariance: amberScalpelPulse * 0.1,
+		MoveDuration:  time.Second * 4,
+	})
+
+	ai.ParasiticGrowth = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 121949},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 35,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			player := players[int(sim.RandomFloat("Parasitic Growth Target")*float64(len(players)))]
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				NumTicks: 15,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					damageRoll := parasiticGrowthBase * (0.9 + 0.2*sim.RandomFloat("Parasitic Growth"))
+					spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+				},
+			})
+		},
+	})
+
+	// Reshape Life has no direct damage of its own. It is still cast so that
+	// APLs can react to it with boss_spell_is_casting.
+	ai.ReshapeLife = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 122784},
+		ProcMask: core.ProcMaskEmpty,
+		Flags:    core.SpellFlagNoMetrics,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 50,
+			},
+
+			IgnoreHaste: true,
+		},
+	})
+}
+
+func (ai *UnsokAI) registerMonstrositySpells() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	massiveStompBase := []float64{45_000, 52_000, 65_000, 75_000}[idx]
+	amberExplosionBase := []float64{60_000, 70_000, 85_000, 100_000}[idx]
+
+	ai.MassiveStomp = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 122408},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 18,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, massiveStompBase, massiveStompBase*0.1)
+		},
+	})
+
+	ai.AmberExplosion = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 122398},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond*2500 + core.BossGCD,
+				CastTime: time.Millisecond * 2500,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 46,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, amberExplosionBase, amberExplosionBase*0.1)
+		},
+	})
+}
+
+// In the final phase Un'sok pulses the raid with Concentrated Mutation, which
+// grows stronger the longer the phase lasts.
+func (ai *UnsokAI) registerConcentratedMutation() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	mutationBase := []float64{8_000, 9_500, 12_000, 14_000}[idx]
+	var phase3Start time.Duration
+
+	ai.ConcentratedMutation = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 122556},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			if phase3Start == 0 {
+				phase3Start = sim.CurrentTime
+			}
+
+			// 10% stronger every 5 seconds into the phase.
+			rampMultiplier := 1 + 0.1*float64((sim.CurrentTime-phase3Start)/(time.Second*5))
+			damageRoll := mutationBase * rampMultiplier
+			encounter_utils.DealRaidDamage(sim, spell, damageRoll, damageRoll*0.1)
+		},
+	})
+
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		phase3Start = 0
+
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   time.Second * 2,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				if encounter_utils.BossHealthBelow(sim, unsokPhase3Health) {
+					ai.ConcentratedMutation.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+				}
+			},
+		})
+	})
+}
+
+func (ai *UnsokAI) spawnMonstrosity(sim *core.Simulation) {
+	ai.monstrositySpawned = true
+	sim.EnableTargetUnit(ai.MonstrosityUnit)
+
+	pa := sim.GetConsumedPendingActionFromPool()
+	pa.NextActionAt = sim.CurrentTime + ai.monstrosityKillTime
+	pa.Priority = core.ActionPriorityDOT
+
+	pa.OnAction = func(sim *core.Simulation) {
+		sim.DisableTargetUnit(ai.MonstrosityUnit, true)
+	}
+
+	sim.AddPendingAction(pa)
+}
+
+func (ai *UnsokAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	ai.monstrositySpawned = false
+	ai.AmberScalpel.CD.Set(core.DurationFromSeconds(20 + 5*sim.RandomFloat("Amber Scalpel Timing")))
+	ai.ParasiticGrowth.CD.Set(core.DurationFromSeconds(20 + 5*sim.RandomFloat("Parasitic Growth Timing")))
+	ai.ReshapeLife.CD.Set(time.Second * 15)
+}
+
+func (ai *UnsokAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if !ai.isBoss {
+		if ai.AmberExplosion.IsReady(sim) {
+			ai.AmberExplosion.Cast(sim, target)
+		} else if ai.MassiveStomp.IsReady(sim) {
+			ai.MassiveStomp.Cast(sim, target)
+		} else {
+			ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+		}
+
+		return
+	}
+
+	if !ai.monstrositySpawned && encounter_utils.BossHealthBelow(sim, ai.phase2Health) {
+		ai.spawnMonstrosity(sim)
+	}
+
+	if encounter_utils.BossHealthBelow(sim, unsokPhase3Health) && ai.MonstrosityUnit.IsEnabled() {
+		sim.DisableTargetUnit(ai.MonstrosityUnit, true)
+	}
+
+	if ai.ReshapeLife.IsReady(sim) {
+		ai.ReshapeLife.Cast(sim, target)
+		return
+	}
+
+	if ai.AmberScalpel.IsReady(sim) {
+		ai.AmberScalpel.Cast(sim, target)
+		return
+	}
+
+	if ai.ParasiticGrowth.IsReady(sim) {
+		ai.ParasiticGrowth.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,375 @@
+package msv
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const elegonMeleeDamageSpread = 0.4
+const elegonBossID int32 = 60410
+const elegonProtectorID int32 = 60793
+const elegonEnergyChargeID int32 = 60913
+
+// Boss health percentages at which Elegon casts Draw Power.
+var elegonDrawPowerThresholds = []float64{85, 50}
+
+func addElegon(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{57_000_000, 160_000_000, 85_000_000, 240_000_000}
+	bossMinBaseDamage := []float64{105_000, 155_000, 150_000, 235_000}
+	protectorHealth := []float64{9_000_000, 25_000_000, 13_000_000, 38_000_000}
+	energyChargeHealth := []float64{400_000, 1_100_000, 600_000, 1_700_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createElegonPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], protectorHealth[idx], energyChargeHealth[idx])
+	}
+}
+
+func createElegonPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, protectorHealth float64, energyChargeHealth float64) {
+	bossName := encounter_utils.PresetName("Elegon", raidSize, isHeroic)
+	protectorName := encounter_utils.PresetName("Celestial Protector", raidSize, isHeroic)
+	energyChargeName := encounter_utils.PresetName("Energy Charge", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(elegonBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    1.5,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  elegonMeleeDamageSpread,
+			TargetInputs:  elegonTargetInputs(),
+		},
+
+		AI: makeElegonAI(raidSize, isHeroic, true),
+	})
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(elegonProtectorID, raidSize, isHeroic),
+			Name:      protectorName,
+			Level:     92,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health: protectorHealth,
+				stats.Armor:  24835, // TODO: verify add armor
+			}.ToProtoArray(),
+
+			SpellSchool:     proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:      2.0,
+			MinBaseDamage:   bossMinBaseDamage * 0.5,
+			DamageSpread:    elegonMeleeDamageSpread,
+			TargetInputs:    []*proto.TargetInput{},
+			DisabledAtStart: true,
+		},
+
+		AI: makeElegonAI(raidSize, isHeroic, false),
+	})
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:      encounter_utils.PresetID(elegonEnergyChargeID, raidSize, isHeroic),
+			Name:    energyChargeName,
+			Level:   92,
+			MobType: proto.MobType_MobTypeElemental,
+
+			Stats: stats.Stats{
+				stats.Health: energyChargeHealth,
+				stats.Armor:  24835, // TODO: verify add armor
+			}.ToProtoArray(),
+
+			TargetInputs:    []*proto.TargetInput{},
+			DisabledAtStart: true,
+		},
+
+		AI: makeElegonAI(raidSize, isHeroic, false),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + protectorName,
+		raidPrefix + "/" + energyChargeName,
+	})
+}
+
+func elegonTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Draw Power duration",
+			Tooltip:     "Time (in seconds) that Elegon spends untargetable during each Draw Power phase, while the raid kills Energy Charges",
+			InputType:   proto.InputType_Number,
+			NumberValue: 30,
+		},
+		{
+			Label:       "Celestial Protector lifetime",
+			Tooltip:     "Time (in seconds) that each Celestial Protector stays up before being killed",
+			InputType:   proto.InputType_Number,
+			NumberValue: 25,
+		},
+	}
+}
+
+func makeElegonAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &ElegonAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type ElegonAI struct {
+	// Unit references
+	Target           *core.Target
+	BossUnit         *core.Unit
+	ProtectorUnit    *core.Unit
+	EnergyChargeUnit *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	drawPowerDuration time.Duration
+	protectorLifetime time.Duration
+
+	// Spell + aura references
+	CelestialBreath *core.Spell
+	DrawPower       *core.Spell
+	Overcharged     *core.Spell
+	OverchargedAura *core.Aura
+
+	// Encounter state
+	numDrawPowers      int
+	protectorSpawnedAt time.Duration
+}
+
+func (ai *ElegonAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+
+	if !ai.isBoss {
+		return
+	}
+
+	ai.ProtectorUnit = target.Env.Encounter.AllTargetUnits[1]
+	ai.EnergyChargeUnit = target.Env.Encounter.AllTargetUnits[2]
+
+	// Save user input parameters
+	ai.drawPowerDuration = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.protectorLifetime = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+	// Register relevant spells and auras
+	ai.registerCelestialBreath()
+	ai.registerOvercharged()
+	ai.registerDrawPower()
+}
+
+func (ai *ElegonAI) registerCelestialBreath() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	celestialBreathBase := []float64{90_000, 130_000, 125_000, 180_000}[idx]
+
+	ai.CelestialBreath = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 117960},
+		SpellSchool:      core.SpellSchoolArcane,
+		ProcMask:         core.ProcMaskSpellDamage,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond*1500 + core.BossGCD,
+				CastTime: time.Millisecond * 1500,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 18,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageRoll := celestialBreathBase * (0.9 + 0.2*sim.RandomFloat("Celestial Breath"))
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+// Every Energy Charge that reaches Elegon adds a stack of Overcharged, which
+// makes his raid wide pulses hit harder until the next Draw Power ends. The sim
+// assumes a fixed number of charges get through per phase.
+func (ai *ElegonAI) registerOvercharged() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	overchargedBase := []float64{2_500, 3_000, 3_500, 4_200}[idx]
+
+	ai.OverchargedAura = ai.BossUnit.RegisterAura(core.Aura{
+		Label:     "Overcharged",
+		ActionID:  core.ActionID{SpellID: 117878},
+		Duration:  core.NeverExpires,
+		MaxStacks: 100,
+	})
+
+	ai.Overcharged = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 117877},
+		SpellSchool:      core.SpellSchoolArcane,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			stacks := float64(ai.OverchargedAura.GetStacks())
+			encounter_utils.DealRaidDamage(sim, spell, overchargedBase*stacks, overchargedBase*stacks*0.1)
+		},
+	})
+
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   time.Second * 2,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				if ai.OverchargedAura.IsActive() {
+					ai.Overcharged.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+				}
+			},
+		})
+	})
+}
+
+// Draw Power makes Elegon untargetable while the raid kills Energy Charges,
+// after which a new Celestial Protector spawns.
+func (ai *ElegonAI) registerDrawPower() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	drawPowerPulse := []float64{12_000, 14_000, 18_000, 21_000}[idx]
+	chargesPerPhase := int32(core.TernaryInt(ai.isHeroic, 6, 3))
+
+	ai.DrawPower = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 119360},
+		SpellSchool:      core.SpellSchoolArcane,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			ai.numDrawPowers++
+			ai.OverchargedAura.Deactivate(sim)
+
+			sim.EnableTargetUnit(ai.EnergyChargeUnit)
+			sim.DisableTargetUnit(ai.ProtectorUnit, true)
+			sim.DisableTargetUnit(ai.BossUnit, false)
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second,
+				NumTicks: int(ai.drawPowerDuration / time.Second),
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					encounter_utils.DealRaidDamage(sim, spell, drawPowerPulse, drawPowerPulse*0.1)
+				},
+			})
+
+			pa := sim.GetConsumedPendingActionFromPool()
+			pa.NextActionAt = sim.CurrentTime + ai.drawPowerDuration
+			pa.Priority = core.ActionPriorityDOT
+
+			pa.OnAction = func(sim *core.Simulation) {
+				sim.EnableTargetUnit(ai.BossUnit)
+				sim.DisableTargetUnit(ai.EnergyChargeUnit, true)
+				ai.OverchargedAura.Activate(sim)
+				ai.OverchargedAura.AddStacks(sim, chargesPerPhase)
+				ai.spawnProtector(sim)
+			}
+
+			sim.AddPendingAction(pa)
+		},
+	})
+}
+
+func (ai *ElegonAI) spawnProtector(sim *core.Simulation) {
+	sim.EnableTargetUnit(ai.ProtectorUnit)
+	ai.protectorSpawnedAt = sim.CurrentTime
+	spawnedAt := sim.CurrentTime
+
+	pa := sim.GetConsumedPendingActionFromPool()
+	pa.NextActionAt = sim.CurrentTime + ai.protectorLifetime
+	pa.Priority = core.ActionPriorityDOT
+
+	pa.OnAction = func(sim *core.Simulation) {
+		// Skip if this Protector already despawned and a new one took its place.
+		if ai.ProtectorUnit.IsEnabled() && (ai.protectorSpawnedAt == spawnedAt) {
+			sim.DisableTargetUnit(ai.ProtectorUnit, true)
+		}
+	}
+
+	sim.AddPendingAction(pa)
+}
+
+func (ai *ElegonAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	ai.numDrawPowers = 0
+	ai.CelestialBreath.CD.Set(core.DurationFromSeconds(5 + 5*sim.RandomFloat("Celestial Breath Timing")))
+
+	// The first Celestial Protector spawns shortly after the pull.
+	pa := sim.GetConsumedPendingActionFromPool()
+	pa.NextActionAt = time.Second * 10
+	pa.Priority = core.ActionPriorityDOT
+	pa.OnAction = ai.spawnProtector
+	sim.AddPendingAction(pa)
+}
+
+func (ai *ElegonAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	if (ai.numDrawPowers < len(elegonDrawPowerThresholds)) && encounter_utils.BossHealthBelow(sim, elegonDrawPowerThresholds[ai.numDrawPowers]) {
+		ai.DrawPower.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+		return
+	}
+
+	if ai.CelestialBreath.IsReady(sim) {
+		ai.CelestialBreath.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,396 @@
+package msv
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const fengMeleeDamageSpread = 0.4
+const fengBossID int32 = 60009
+const fengAddID int32 = 60781
+
+func addFeng(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{59_000_000, 165_000_000, 88_000_000, 250_000_000}
+	bossMinBaseDamage := []float64{110_000, 160_000, 155_000, 245_000}
+	addHealth := []float64{0, 0, 1_300_000, 3_600_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createFengPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], addHealth[idx])
+	}
+}
+
+func createFengPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, addHealth float64) {
+	bossName := encounter_utils.PresetName("Feng the Accursed", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(fengBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    1.5,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  fengMeleeDamageSpread,
+			TargetInputs:  fengTargetInputs(isHeroic),
+		},
+
+		AI: makeFengAI(raidSize, isHeroic, true),
+	})
+
+	targetPathNames := []string{raidPrefix + "/" + bossName}
+
+	// Soul Fragments from Siphoning Shield only exist on heroic.
+	if isHeroic {
+		addName := encounter_utils.PresetName("Soul Fragment", raidSize, isHeroic)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:      encounter_utils.PresetID(fengAddID, raidSize, isHeroic),
+				Name:    addName,
+				Level:   92,
+				MobType: proto.MobType_MobTypeUndead,
+
+				Stats: stats.Stats{
+					stats.Health: addHealth,
+					stats.Armor:  24835, // TODO: verify add armor
+				}.ToProtoArray(),
+
+				TargetInputs:    []*proto.TargetInput{},
+				DisabledAtStart: true,
+			},
+
+			AI: makeFengAI(raidSize, isHeroic, false),
+		})
+
+		targetPathNames = append(targetPathNames, raidPrefix+"/"+addName)
+	}
+
+	core.AddPresetEncounter(bossName, targetPathNames)
+}
+
+func fengTargetInputs(isHeroic bool) []*proto.TargetInput {
+	inputs := []*proto.TargetInput{
+		{
+			Label:       "Phase 2 health",
+			Tooltip:     "Boss health percentage at which Feng switches to the Spirit of the Flame",
+			InputType:   proto.InputType_Number,
+			NumberValue: 66,
+		},
+		{
+			Label:       "Phase 3 health",
+			Tooltip:     "Boss health percentage at which Feng switches to the Spirit of the Shield",
+			InputType:   proto.InputType_Number,
+			NumberValue: 33,
+		},
+	}
+
+	if isHeroic {
+		inputs = append(inputs, &proto.TargetInput{
+			Label:       "Soul Fragment lifetime",
+			Tooltip:     "Time (in seconds) that Soul Fragments stay targetable after each Siphoning Shield",
+			InputType:   proto.InputType_Number,
+			NumberValue: 15,
+		})
+	}
+
+	return inputs
+}
+
+func makeFengAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &FengAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type FengAI struct {
+	// Unit references
+	Target   *core.Target
+	BossUnit *core.Unit
+	AddUnits []*core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	phase2Health     float64
+	phase3Health     float64
+	fragmentLifetime time.Duration
+
+	// Spell references
+	LightningLash   *core.Spell
+	Epicenter       *core.Spell
+	WildfireSpark   *core.Spell
+	DrawFlame       *core.Spell
+	ArcaneResonance *core.Spell
+	ArcaneVelocity  *core.Spell
+	SiphoningShield *core.Spell
+}
+
+func (ai *FengAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+	ai.AddUnits = target.Env.Encounter.AllTargetUnits[1:]
+
+	if !ai.isBoss {
+		return
+	}
+
+	// Save user input parameters
+	ai.phase2Health = config.TargetInputs[0].NumberValue
+	ai.phase3Health = config.TargetInputs[1].NumberValue
+
+	if ai.isHeroic {
+		ai.fragmentLifetime = core.DurationFromSeconds(config.TargetInputs[2].NumberValue)
+	}
+
+	// Register relevant spells
+	ai.registerFistsOfThunder()
+	ai.registerSpiritOfTheFlame()
+	ai.registerSpiritOfTheShield()
+	ai.registerSiphoningShield()
+}
+
+// Spirit of the Fist, until the first phase transition.
+func (ai *FengAI) registerFistsOfThunder() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	lightningLashBase := []float64{80_000, 110_000, 105_000, 150_000}[idx]
+	epicenterPulse := []float64{20_000, 24_000, 28_000, 33_000}[idx]
+
+	ai.LightningLash = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 131788},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageRoll := lightningLashBase * (0.9 + 0.2*sim.RandomFloat("Lightning Lash"))
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeAlwaysHit)
+		},
+	})
+
+	ai.Epicenter = encounter_utils.RegisterRaidChannel(ai.BossUnit, encounter_utils.RaidChannelConfig{
+		ActionID:      core.ActionID{SpellID: 116018},
+		SpellSchool:   core.SpellSchoolNature,
+		Cooldown:      time.Second * 30,
+		ChannelTime:   time.Second * 10,
+		PulseBase:     epicenterPulse,
+		PulseVariance: epicenterPulse * 0.1,
+		MoveDuration:  time.Second * 3,
+	})
+}
+
+// Spirit of the Flame, between the two phase transitions.
+func (ai *FengAI) registerSpiritOfTheFlame() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	wildfireBase := []float64{45_000, 50_000, 65_000, 72_000}[idx]
+	drawFlamePulse := []float64{22_000, 26_000, 30_000, 36_000}[idx]
+
+	ai.WildfireSpark = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 116784},
+		SpellSchool:      core.SpellSchoolFire,
+		ProcMask:         core.ProcMaskSpellDamage,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 14,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			player := players[int(sim.RandomFloat("Wildfire Spark Target")*float64(len(players)))]
+			damageRoll := wildfireBase * (0.9 + 0.2*sim.RandomFloat("Wildfire Spark"))
+			spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+			encounter_utils.MovePlayer(sim, player, time.Second*2)
+		},
+	})
+
+	ai.DrawFlame = encounter_utils.RegisterRaidChannel(ai.BossUnit, encounter_utils.RaidChannelConfig{
+		ActionID:      core.ActionID{SpellID: 116711},
+		SpellSchool:   core.SpellSchoolFire,
+		Cooldown:      time.Second * 35,
+		ChannelTime:   time.Second * 6,
+		PulseBase:     drawFlamePulse,
+		PulseVariance: drawFlamePulse * 0.1,
+	})
+}
+
+// Spirit of the Shield, for the rest of the fight.
+func (ai *FengAI) registerSpiritOfTheShield() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	resonanceBase := []float64{40_000, 45_000, 58_000, 65_000}[idx]
+	velocityPulse := []float64{30_000, 34_000, 42_000, 48_000}[idx]
+
+	ai.ArcaneResonance = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 116417},
+		SpellSchool:      core.SpellSchoolArcane,
+		ProcMask:         core.ProcMaskSpellDamage,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			numTargets := core.TernaryInt(ai.raidSize == 10, 2, 5)
+			for i := 0; i < min(numTargets, len(players)); i++ {
+				player := players[int(sim.RandomFloat("Arcane Resonance Target")*float64(len(players)))]
+				damageRoll := resonanceBase * (0.9 + 0.2*sim.RandomFloat("Arcane Resonance"))
+				spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	ai.ArcaneVelocity = encounter_utils.RegisterRaidChannel(ai.BossUnit, encounter_utils.RaidChannelConfig{
+		ActionID:      core.ActionID{SpellID: 116364},
+		SpellSchool:   core.SpellSchoolArcane,
+		Cooldown:      time.Second * 30,
+		ChannelTime:   time.Second * 8,
+		PulseBase:     velocityPulse,
+		PulseVariance: velocityPulse * 0.1,
+		MoveDuration:  time.Second * 2,
+	})
+}
+
+// On heroic, Siphoning Shield spawns Soul Fragments that the raid kills on the
+// side. These are modeled as a single add that becomes targetable for a while.
+func (ai *FengAI) registerSiphoningShield() {
+	if !ai.isHeroic || (len(ai.AddUnits) == 0) {
+		return
+	}
+
+	ai.SiphoningShield = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 117203},
+		ProcMask: core.ProcMaskEmpty,
+		Flags:    core.SpellFlagNoMetrics,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second + core.BossGCD,
+				CastTime: time.Second,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 45,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for _, addUnit := range ai.AddUnits {
+				sim.EnableTargetUnit(addUnit)
+			}
+
+			pa := sim.GetConsumedPendingActionFromPool()
+			pa.NextActionAt = sim.CurrentTime + ai.fragmentLifetime
+			pa.Priority = core.ActionPriorityDOT
+
+			pa.OnAction = func(sim *core.Simulation) {
+				for _, addUnit := range ai.AddUnits {
+					sim.DisableTargetUnit(addUnit, true)
+				}
+			}
+
+			sim.AddPendingAction(pa)
+		},
+	})
+}
+
+func (ai *FengAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	// Stagger the first casts of each phase's abilities.
+	ai.Epicenter.CD.Set(time.Second * 15)
+	ai.LightningLash.CD.Set(core.DurationFromSeconds(5 + 5*sim.RandomFloat("Lightning Lash Timing")))
+
+	if ai.SiphoningShield != nil {
+		ai.SiphoningShield.CD.Set(time.Second * 40)
+	}
+}
+
+func (ai *FengAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	if (ai.SiphoningShield != nil) && ai.SiphoningShield.IsReady(sim) && !encounter_utils.BossHealthBelow(sim, ai.phase3Health) {
+		ai.SiphoningShield.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+		return
+	}
+
+	var channel, special *core.Spell
+
+	switch {
+	case encounter_utils.BossHealthBelow(sim, ai.phase3Health):
+		channel, special = ai.ArcaneVelocity, ai.ArcaneResonance
+	case encounter_utils.BossHealthBelow(sim, ai.phase2Health):
+		channel, special = ai.DrawFlame, ai.WildfireSpark
+	default:
+		channel, special = ai.Epicenter, ai.LightningLash
+	}
+
+	if channel.IsReady(sim) {
+		channel.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+		return
+	}
+
+	if special.IsReady(sim) {
+		special.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
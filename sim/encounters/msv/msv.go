@@ -1,5 +1,14 @@
 package msv
 
 func Register() {
-	addGarajal("Mogu'shan Vaults")
+	raidPrefix := "Mogu'shan Vaults"
+	// Presets whose boss values are approximations that haven't been fit
+	// against logs yet.
+	experimentalPrefix := raidPrefix + " (Experimental)"
+
+	addStoneGuard(experimentalPrefix)
+	addFeng(experimentalPrefix)
+	addGarajal(raidPrefix)
+	addElegon(experimentalPrefix)
+	addWillOfTheEmperor(experimentalPrefix)
 }
@@ -0,0 +1,39 @@
+package msv
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/druid/guardian"
+)
+
+func init() {
+	guardian.RegisterGuardianDruid()
+	Register()
+}
+
+func TestMSV(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), []core.TestGenerator{
+		core.PresetEncountersTestGenerator("Mogu'shan Vaults", tankRaid()),
+	})
+}
+
+// A lone ungeared tank, so the bosses have someone to attack.
+func tankRaid() *proto.Raid {
+	raid := core.SinglePlayerRaidProto(&proto.Player{
+		Class:         proto.Class_ClassDruid,
+		Race:          proto.Race_RaceWorgen,
+		Equipment:     &proto.EquipmentSpec{},
+		TalentsString: "010101",
+		Spec: &proto.Player_GuardianDruid{
+			GuardianDruid: &proto.GuardianDruid{
+				Options: &proto.GuardianDruid_Options{},
+			},
+		},
+		Rotation:        core.GetAplRotation("../../../ui/druid/guardian/apls", "default").Rotation,
+		InFrontOfTarget: true,
+	}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+	raid.Tanks = []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 0}}
+	return raid
+}
@@ -0,0 +1,246 @@
+package msv
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const stoneGuardMeleeDamageSpread = 0.4
+
+type stoneGuardian struct {
+	name            string
+	npcID           int32
+	overloadSpellID int32
+	school          core.SpellSchool
+}
+
+// 10 player raids face three of the four guardians. The sim always uses the
+// first three so that presets are deterministic.
+var stoneGuardians = []stoneGuardian{
+	{"Jasper Guardian", 59915, 115843, core.SpellSchoolFire},
+	{"Jade Guardian", 60043, 115842, core.SpellSchoolNature},
+	{"Amethyst Guardian", 60047, 115844, core.SpellSchoolShadow},
+	{"Cobalt Guardian", 60051, 115840, core.SpellSchoolArcane},
+}
+
+func addStoneGuard(raidPrefix string) {
+	// Health and melee values are approximations scaled from the 25H fit of
+	// Gara'jal, and should be refit against logs.
+	guardianHealth := []float64{43_000_000, 122_000_000, 64_000_000, 190_000_000}
+	guardianMinBaseDamage := []float64{95_000, 140_000, 135_000, 215_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createStoneGuardPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, guardianHealth[idx], guardianMinBaseDamage[idx])
+	}
+}
+
+func createStoneGuardPreset(raidPrefix string, raidSize int32, isHeroic bool, guardianHealth float64, guardianMinBaseDamage float64) {
+	numGuardians := core.TernaryInt(raidSize == 10, 3, 4)
+	var targetPathNames []string
+
+	for guardianIdx, guardian := range stoneGuardians[:numGuardians] {
+		targetName := encounter_utils.PresetName(guardian.name, raidSize, isHeroic)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        encounter_utils.PresetID(guardian.npcID, raidSize, isHeroic),
+				Name:      targetName,
+				Level:     93,
+				MobType:   proto.MobType_MobTypeElemental,
+				TankIndex: int32(guardianIdx % 2),
+
+				Stats: stats.Stats{
+					stats.Health:      guardianHealth,
+					stats.Armor:       24835,
+					stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+				}.ToProtoArray(),
+
+				SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:    2.0,
+				MinBaseDamage: guardianMinBaseDamage,
+				DamageSpread:  stoneGuardMeleeDamageSpread,
+				TargetInputs:  stoneGuardTargetInputs(),
+			},
+
+			AI: makeStoneGuardAI(raidSize, isHeroic, guardianIdx),
+		})
+
+		targetPathNames = append(targetPathNames, raidPrefix+"/"+targetName)
+	}
+
+	core.AddPresetEncounter(encounter_utils.PresetName("The Stone Guard", raidSize, isHeroic), targetPathNames)
+}
+
+func stoneGuardTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Overload interval",
+			Tooltip:     "Time (in seconds) between guardian Overloads. The guardians Overload in turn, so each one Overloads once per full rotation.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 65,
+		},
+	}
+}
+
+func makeStoneGuardAI(raidSize int32, isHeroic bool, guardianIdx int) core.AIFactory {
+	return func() core.TargetAI {
+		return &StoneGuardAI{
+			raidSize:    raidSize,
+			isHeroic:    isHeroic,
+			guardianIdx: guardianIdx,
+			guardian:    stoneGuardians[guardianIdx],
+		}
+	}
+}
+
+type StoneGuardAI struct {
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize    int32
+	isHeroic    bool
+	guardianIdx int
+	guardian    stoneGuardian
+
+	// Dynamic parameters taken from user inputs
+	overloadInterval time.Duration
+
+	// Spell references
+	Overload        *core.Spell
+	GuardianSpecial *core.Spell
+}
+
+func (ai *StoneGuardAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.overloadInterval = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+
+	ai.registerOverload()
+	ai.registerGuardianSpecial()
+}
+
+func (ai *StoneGuardAI) registerOverload() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	overloadBase := []float64{85_000, 95_000, 125_000, 140_000}[idx]
+	overloadVariance := overloadBase * 0.1
+
+	ai.Overload = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: ai.guardian.overloadSpellID},
+		SpellSchool:      ai.guardian.school,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, overloadBase, overloadVariance)
+		},
+	})
+
+	// Guardians build Energy in parallel and Overload in turn, so each one
+	// Overloads at its own offset within the rotation.
+	ai.Target.RegisterResetEffect(func(sim *core.Simulation) {
+		rotationPeriod := ai.overloadInterval * time.Duration(len(sim.Encounter.AllTargets))
+
+		pa := &core.PendingAction{
+			NextActionAt: ai.overloadInterval * time.Duration(ai.guardianIdx+1),
+			Priority:     core.ActionPriorityDOT,
+		}
+
+		pa.OnAction = func(sim *core.Simulation) {
+			if ai.Target.IsEnabled() {
+				ai.Overload.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+			}
+
+			pa.NextActionAt = sim.CurrentTime + rotationPeriod
+			sim.AddPendingAction(pa)
+		}
+
+		sim.AddPendingAction(pa)
+	})
+}
+
+// Each guardian has its own special ability:
+//   - Jasper Chains link two players, who take Fire damage until the chains break.
+//   - Jade Shards is a short cast that hits the whole raid for Nature damage.
+//   - Amethyst Pool drops Shadow damage on a player, who has to move out of it.
+//   - Cobalt Mine is a short cast that forces the raid to reposition.
+func (ai *StoneGuardAI) registerGuardianSpecial() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	specialBase := []float64{30_000, 34_000, 45_000, 50_000}[idx]
+	specialVariance := specialBase * 0.1
+
+	config := core.SpellConfig{
+		SpellSchool:      ai.guardian.school,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 12,
+			},
+
+			IgnoreHaste: true,
+		},
+	}
+
+	switch ai.guardian.npcID {
+	case 59915:
+		config.ActionID = core.ActionID{SpellID: 130395}
+		config.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			for i := 0; i < min(2, len(players)); i++ {
+				player := players[int(sim.RandomFloat("Jasper Chains")*float64(len(players)))]
+				spell.CalcAndDealDamage(sim, player, specialBase+specialVariance*sim.RandomFloat("Jasper Chains Damage"), spell.OutcomeAlwaysHit)
+			}
+		}
+	case 60043:
+		config.ActionID = core.ActionID{SpellID: 116223}
+		config.Cast.DefaultCast.CastTime = time.Second * 2
+		config.Cast.DefaultCast.GCD = time.Second*2 + core.BossGCD
+		config.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, specialBase*0.5, specialVariance*0.5)
+		}
+	case 60047:
+		config.ActionID = core.ActionID{SpellID: 116235}
+		config.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			player := players[int(sim.RandomFloat("Amethyst Pool")*float64(len(players)))]
+			spell.CalcAndDealDamage(sim, player, specialBase+specialVariance*sim.RandomFloat("Amethyst Pool Damage"), spell.OutcomeAlwaysHit)
+			encounter_utils.MovePlayer(sim, player, time.Millisecond*1500)
+		}
+	case 60051:
+		config.ActionID = core.ActionID{SpellID: 129424}
+		config.Cast.DefaultCast.CastTime = time.Second
+		config.Cast.DefaultCast.GCD = time.Second + core.BossGCD
+		config.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			encounter_utils.MoveRaid(sim, time.Second)
+		}
+	}
+
+	ai.GuardianSpecial = ai.Target.RegisterSpell(config)
+}
+
+func (ai *StoneGuardAI) Reset(sim *core.Simulation) {
+	ai.GuardianSpecial.CD.Set(core.DurationFromSeconds(sim.RandomFloat("Guardian Special Timing") * ai.GuardianSpecial.CD.Duration.Seconds()))
+}
+
+func (ai *StoneGuardAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if ai.GuardianSpecial.IsReady(sim) {
+		ai.GuardianSpecial.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
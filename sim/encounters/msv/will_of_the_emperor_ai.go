@@ -0,0 +1,337 @@
+package msv
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const willMeleeDamageSpread = 0.4
+const qinxiID int32 = 60399
+const janxiID int32 = 60400
+
+type emperorAdd struct {
+	name    string
+	npcID   int32
+	mobType proto.MobType
+
+	// Spawn intervals by scaling index, as well as the default lifetime.
+	spawnIntervals  []float64
+	defaultLifetime float64
+}
+
+var emperorAdds = []emperorAdd{
+	{"Emperor's Rage", 60396, proto.MobType_MobTypeElemental, []float64{25, 25, 20, 20}, 8},
+	{"Emperor's Strength", 60397, proto.MobType_MobTypeElemental, []float64{70, 70, 55, 55}, 20},
+	{"Emperor's Courage", 60398, proto.MobType_MobTypeElemental, []float64{70, 70, 55, 55}, 15},
+}
+
+func addWillOfTheEmperor(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{68_000_000, 190_000_000, 100_000_000, 290_000_000}
+	bossMinBaseDamage := []float64{120_000, 175_000, 170_000, 260_000}
+	addHealth := []float64{1_200_000, 3_300_000, 1_800_000, 5_000_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createWillOfTheEmperorPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], addHealth[idx])
+	}
+}
+
+func createWillOfTheEmperorPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, addHealth float64) {
+	var targetPathNames []string
+
+	// Qin-xi and Jan-xi are tanked separately and stacked for cleave.
+	for bossIdx, boss := range []struct {
+		name  string
+		npcID int32
+	}{{"Qin-xi", qinxiID}, {"Jan-xi", janxiID}} {
+		bossName := encounter_utils.PresetName(boss.name, raidSize, isHeroic)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        encounter_utils.PresetID(boss.npcID, raidSize, isHeroic),
+				Name:      bossName,
+				Level:     93,
+				MobType:   proto.MobType_MobTypeMechanical,
+				TankIndex: int32(bossIdx),
+
+				Stats: stats.Stats{
+					stats.Health:      bossHealth,
+					stats.Armor:       24835,
+					stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+				}.ToProtoArray(),
+
+				SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:    2.0,
+				MinBaseDamage: bossMinBaseDamage,
+				DamageSpread:  willMeleeDamageSpread,
+				TargetInputs:  core.Ternary(bossIdx == 0, willOfTheEmperorTargetInputs(), []*proto.TargetInput{}),
+			},
+
+			AI: makeWillOfTheEmperorAI(raidSize, isHeroic, boss.npcID),
+		})
+
+		targetPathNames = append(targetPathNames, raidPrefix+"/"+bossName)
+	}
+
+	for _, add := range emperorAdds {
+		addName := encounter_utils.PresetName(add.name, raidSize, isHeroic)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:      encounter_utils.PresetID(add.npcID, raidSize, isHeroic),
+				Name:    addName,
+				Level:   92,
+				MobType: add.mobType,
+
+				Stats: stats.Stats{
+					stats.Health: addHealth,
+					stats.Armor:  24835, // TODO: verify add armor
+				}.ToProtoArray(),
+
+				TargetInputs:    []*proto.TargetInput{},
+				DisabledAtStart: true,
+			},
+
+			AI: makeWillOfTheEmperorAI(raidSize, isHeroic, add.npcID),
+		})
+
+		targetPathNames = append(targetPathNames, raidPrefix+"/"+addName)
+	}
+
+	core.AddPresetEncounter(encounter_utils.PresetName("Will of the Emperor", raidSize, isHeroic), targetPathNames)
+}
+
+func willOfTheEmperorTargetInputs() []*proto.TargetInput {
+	inputs := make([]*proto.TargetInput, len(emperorAdds))
+
+	for idx, add := range emperorAdds {
+		inputs[idx] = &proto.TargetInput{
+			Label:       add.name + " lifetime",
+			Tooltip:     "Time (in seconds) that each " + add.name + " stays up before being killed",
+			InputType:   proto.InputType_Number,
+			NumberValue: add.defaultLifetime,
+		}
+	}
+
+	return inputs
+}
+
+func makeWillOfTheEmperorAI(raidSize int32, isHeroic bool, npcID int32) core.AIFactory {
+	return func() core.TargetAI {
+		return &WillOfTheEmperorAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			npcID:    npcID,
+		}
+	}
+}
+
+type WillOfTheEmperorAI struct {
+	// Unit references
+	Target   *core.Target
+	AddUnits []*core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	npcID    int32
+
+	// Dynamic parameters taken from user inputs
+	addLifetimes []time.Duration
+
+	// Spell references
+	DevastatingCombo *core.Spell
+	EnergizingSmash  *core.Spell
+	TitanGas         *core.Spell
+}
+
+func (ai *WillOfTheEmperorAI) isBoss() bool {
+	return (ai.npcID == qinxiID) || (ai.npcID == janxiID)
+}
+
+func (ai *WillOfTheEmperorAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	switch ai.npcID {
+	case qinxiID:
+		// Qin-xi drives the add waves for the whole encounter.
+		ai.AddUnits = target.Env.Encounter.AllTargetUnits[2:]
+		ai.addLifetimes = make([]time.Duration, len(emperorAdds))
+
+		for idx := range emperorAdds {
+			ai.addLifetimes[idx] = core.DurationFromSeconds(config.TargetInputs[idx].NumberValue)
+		}
+
+		ai.registerAddWaves()
+		ai.registerTitanGas()
+		ai.registerDevastatingCombo()
+	case janxiID:
+		ai.registerDevastatingCombo()
+	case emperorAdds[1].npcID:
+		ai.registerEnergizingSmash()
+	}
+}
+
+func (ai *WillOfTheEmperorAI) registerDevastatingCombo() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	comboBase := []float64{150_000, 210_000, 200_000, 290_000}[idx]
+
+	ai.DevastatingCombo = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 116835},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*3 + core.BossGCD,
+				CastTime: time.Second * 3,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 20,
+			},
+
+			IgnoreHaste: true,
+
+			ModifyCast: func(sim *core.Simulation, spell *core.Spell, curCast *core.Cast) {
+				spell.Unit.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+curCast.CastTime)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageRoll := comboBase * (0.9 + 0.2*sim.RandomFloat("Devastating Combo"))
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+// Emperor's Strength periodically smashes the ground for raid wide damage.
+func (ai *WillOfTheEmperorAI) registerEnergizingSmash() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	smashBase := []float64{25_000, 28_000, 36_000, 40_000}[idx]
+
+	ai.EnergizingSmash = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 116550},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond*1500 + core.BossGCD,
+				CastTime: time.Millisecond * 1500,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: core.TernaryDuration(ai.isHeroic, time.Second*6, time.Second*8),
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, smashBase, smashBase*0.1)
+		},
+	})
+}
+
+// On heroic, Titan Gas is permanently active and pulses the raid for Arcane
+// damage.
+func (ai *WillOfTheEmperorAI) registerTitanGas() {
+	if !ai.isHeroic {
+		return
+	}
+
+	titanGasBase := []float64{0, 0, 6_000, 7_000}[encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)]
+
+	ai.TitanGas = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 116779},
+		SpellSchool:      core.SpellSchoolArcane,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, titanGasBase, titanGasBase*0.1)
+		},
+	})
+
+	ai.Target.RegisterResetEffect(func(sim *core.Simulation) {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   time.Second,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				ai.TitanGas.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+			},
+		})
+	})
+}
+
+// Each type of add spawns on its own timer, and is enabled as a target until
+// the raid kills it.
+func (ai *WillOfTheEmperorAI) registerAddWaves() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+
+	ai.Target.RegisterResetEffect(func(sim *core.Simulation) {
+		for addIdx, addUnit := range ai.AddUnits {
+			lifetime := ai.addLifetimes[addIdx]
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   core.DurationFromSeconds(emperorAdds[addIdx].spawnIntervals[idx]),
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					sim.EnableTargetUnit(addUnit)
+
+					pa := sim.GetConsumedPendingActionFromPool()
+					pa.NextActionAt = sim.CurrentTime + lifetime
+					pa.Priority = core.ActionPriorityDOT
+
+					pa.OnAction = func(sim *core.Simulation) {
+						sim.DisableTargetUnit(addUnit, true)
+					}
+
+					sim.AddPendingAction(pa)
+				},
+			})
+		}
+	})
+}
+
+func (ai *WillOfTheEmperorAI) Reset(sim *core.Simulation) {
+	if ai.DevastatingCombo != nil {
+		ai.DevastatingCombo.CD.Set(core.DurationFromSeconds(10 + 10*sim.RandomFloat("Devastating Combo Timing")))
+	}
+}
+
+func (ai *WillOfTheEmperorAI) ExecuteCustomRotation(sim *core.Simulation) {
+	var spell *core.Spell
+
+	if ai.isBoss() {
+		spell = ai.DevastatingCombo
+	} else {
+		spell = ai.EnergizingSmash
+	}
+
+	if (spell != nil) && spell.IsReady(sim) {
+		spell.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
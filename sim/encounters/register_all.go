@@ -7,7 +7,10 @@ import (
 	"github.com/wowsims/mop/sim/encounters/bwd"
 	"github.com/wowsims/mop/sim/encounters/dragonsoul"
 	"github.com/wowsims/mop/sim/encounters/firelands"
+	"github.com/wowsims/mop/sim/encounters/hof"
 	"github.com/wowsims/mop/sim/encounters/msv"
+	"github.com/wowsims/mop/sim/encounters/toes"
+	"github.com/wowsims/mop/sim/encounters/tot"
 )
 
 func init() {
@@ -17,6 +20,9 @@ func init() {
 	firelands.Register()
 	dragonsoul.Register()
 	msv.Register()
+	hof.Register()
+	toes.Register()
+	tot.Register()
 }
 
 func AddSingleTargetBossEncounter(presetTarget *core.PresetTarget) {
@@ -0,0 +1,288 @@
+package toes
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const leiShiMeleeDamageSpread = 0.4
+const leiShiBossID int32 = 62983
+const animatedProtectorID int32 = 62995
+
+// Boss health percentages at which Lei Shi summons Animated Protectors.
+var leiShiProtectorThresholds = []float64{80, 60, 40, 20}
+
+func addLeiShi(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{60_000_000, 170_000_000, 90_000_000, 255_000_000}
+	bossMinBaseDamage := []float64{90_000, 130_000, 125_000, 190_000}
+	protectorHealth := []float64{7_000_000, 20_000_000, 10_500_000, 30_000_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createLeiShiPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], protectorHealth[idx])
+	}
+}
+
+func createLeiShiPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, protectorHealth float64) {
+	bossName := encounter_utils.PresetName("Lei Shi", raidSize, isHeroic)
+	protectorName := encounter_utils.PresetName("Animated Protector", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(leiShiBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    1.5,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  leiShiMeleeDamageSpread,
+			TargetInputs:  leiShiTargetInputs(),
+		},
+
+		AI: makeLeiShiAI(raidSize, isHeroic, true),
+	})
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(animatedProtectorID, raidSize, isHeroic),
+			Name:      protectorName,
+			Level:     92,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health: protectorHealth,
+				stats.Armor:  24835, // TODO: verify add armor
+			}.ToProtoArray(),
+
+			SpellSchool:     proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:      2.0,
+			MinBaseDamage:   bossMinBaseDamage * 0.5,
+			DamageSpread:    leiShiMeleeDamageSpread,
+			TargetInputs:    []*proto.TargetInput{},
+			DisabledAtStart: true,
+		},
+
+		AI: makeLeiShiAI(raidSize, isHeroic, false),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + protectorName,
+	})
+}
+
+func leiShiTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Protector kill time",
+			Tooltip:     "Time (in seconds) after spawning that each wave of Animated Protectors dies",
+			InputType:   proto.InputType_Number,
+			NumberValue: 20,
+		},
+		{
+			Label:       "Hide duration",
+			Tooltip:     "Time (in seconds) that Lei Shi stays hidden before the raid finds her again",
+			InputType:   proto.InputType_Number,
+			NumberValue: 10,
+		},
+	}
+}
+
+func makeLeiShiAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &LeiShiAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type LeiShiAI struct {
+	// Unit references
+	Target        *core.Target
+	BossUnit      *core.Unit
+	ProtectorUnit *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	protectorKillTime time.Duration
+	hideDuration      time.Duration
+
+	// Spell references
+	Spray   *core.Spell
+	GetAway *core.Spell
+	Hide    *core.Spell
+
+	// Encounter state
+	numProtectorWaves int
+}
+
+func (ai *LeiShiAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+	ai.ProtectorUnit = target.Env.Encounter.AllTargetUnits[1]
+
+	if !ai.isBoss {
+		return
+	}
+
+	// Save user input parameters
+	ai.protectorKillTime = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.hideDuration = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+	// Register relevant spells
+	ai.registerSpray()
+	ai.registerGetAway()
+	ai.registerHide()
+}
+
+func (ai *LeiShiAI) registerSpray() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	sprayBase := []float64{25_000, 35_000, 35_000, 50_000}[idx]
+
+	ai.Spray = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 123121},
+		SpellSchool:      core.SpellSchoolFrost,
+		ProcMask:         core.ProcMaskSpellDamage,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 3,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageRoll := sprayBase * (0.9 + 0.2*sim.RandomFloat("Spray"))
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *LeiShiAI) registerGetAway() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	getAwayPulse := []float64{14_000, 16_000, 20_000, 23_000}[idx]
+
+	ai.GetAway = encounter_utils.RegisterRaidChannel(ai.BossUnit, encounter_utils.RaidChannelConfig{
+		ActionID:      core.ActionID{SpellID: 123461},
+		SpellSchool:   core.SpellSchoolFrost,
+		Cooldown:      time.Second * 60,
+		ChannelTime:   time.Second * 12,
+		PulseBase:     getAwayPulse,
+		PulseVariance: getAwayPulse * 0.1,
+		MoveDuration:  time.Second * 4,
+	})
+}
+
+// Hide makes Lei Shi vanish and stop attacking until the raid finds her with
+// area damage, which costs everyone some time running around.
+func (ai *LeiShiAI) registerHide() {
+	ai.Hide = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 123244},
+		ProcMask: core.ProcMaskEmpty,
+		Flags:    core.SpellFlagNoMetrics,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second + ai.hideDuration,
+				CastTime: time.Second,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 60,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.Unit.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+ai.hideDuration)
+			encounter_utils.MoveRaid(sim, min(ai.hideDuration, time.Second*3))
+		},
+	})
+}
+
+func (ai *LeiShiAI) spawnProtectors(sim *core.Simulation) {
+	ai.numProtectorWaves++
+	sim.EnableTargetUnit(ai.ProtectorUnit)
+
+	pa := sim.GetConsumedPendingActionFromPool()
+	pa.NextActionAt = sim.CurrentTime + ai.protectorKillTime
+	pa.Priority = core.ActionPriorityDOT
+
+	pa.OnAction = func(sim *core.Simulation) {
+		sim.DisableTargetUnit(ai.ProtectorUnit, true)
+	}
+
+	sim.AddPendingAction(pa)
+}
+
+func (ai *LeiShiAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	ai.numProtectorWaves = 0
+	ai.GetAway.CD.Set(time.Second * 30)
+	ai.Hide.CD.Set(time.Second * 45)
+}
+
+func (ai *LeiShiAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	if (ai.numProtectorWaves < len(leiShiProtectorThresholds)) && encounter_utils.BossHealthBelow(sim, leiShiProtectorThresholds[ai.numProtectorWaves]) {
+		ai.spawnProtectors(sim)
+	}
+
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if ai.GetAway.IsReady(sim) {
+		ai.GetAway.Cast(sim, target)
+		return
+	}
+
+	if ai.Hide.IsReady(sim) {
+		ai.Hide.Cast(sim, target)
+		return
+	}
+
+	if ai.Spray.IsReady(sim) {
+		ai.Spray.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,6 @@
+package toes
+
+func Register() {
+	addTsulong("Terrace of Endless Spring")
+	addLeiShi("Terrace of Endless Spring")
+}
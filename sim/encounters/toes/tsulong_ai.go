@@ -0,0 +1,360 @@
+package toes
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const tsulongMeleeDamageSpread = 0.4
+const tsulongBossID int32 = 62442
+const embodiedTerrorID int32 = 62969
+
+func addTsulong(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{62_000_000, 175_000_000, 93_000_000, 260_000_000}
+	bossMinBaseDamage := []float64{110_000, 160_000, 155_000, 240_000}
+	terrorHealth := []float64{5_000_000, 14_000_000, 7_500_000, 21_000_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createTsulongPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], terrorHealth[idx])
+	}
+}
+
+func createTsulongPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, terrorHealth float64) {
+	bossName := encounter_utils.PresetName("Tsulong", raidSize, isHeroic)
+	terrorName := encounter_utils.PresetName("Embodied Terror", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(tsulongBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeDragonkin,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  tsulongMeleeDamageSpread,
+			TargetInputs:  tsulongTargetInputs(),
+		},
+
+		AI: makeTsulongAI(raidSize, isHeroic, true),
+	})
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(embodiedTerrorID, raidSize, isHeroic),
+			Name:      terrorName,
+			Level:     92,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health: terrorHealth,
+				stats.Armor:  24835, // TODO: verify add armor
+			}.ToProtoArray(),
+
+			SpellSchool:     proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:      2.0,
+			MinBaseDamage:   bossMinBaseDamage * 0.4,
+			DamageSpread:    tsulongMeleeDamageSpread,
+			TargetInputs:    []*proto.TargetInput{},
+			DisabledAtStart: true,
+		},
+
+		AI: makeTsulongAI(raidSize, isHeroic, false),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + terrorName,
+	})
+}
+
+func tsulongTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Night phase duration",
+			Tooltip:     "Time (in seconds) that each Night phase lasts. Tsulong is only attackable during the Night.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 121,
+		},
+		{
+			Label:       "Day phase duration",
+			Tooltip:     "Time (in seconds) that each Day phase lasts. DPS is spent on the Embodied Terror during the Day.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 121,
+		},
+	}
+}
+
+func makeTsulongAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &TsulongAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type TsulongAI struct {
+	// Unit references
+	Target     *core.Target
+	BossUnit   *core.Unit
+	TerrorUnit *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	nightDuration time.Duration
+	dayDuration   time.Duration
+
+	// Spell + aura references
+	ShadowBreath    *core.Spell
+	Nightmares      *core.Spell
+	DreadShadows    *core.Spell
+	Terrorize       *core.Spell
+	DreadShadowAura *core.Aura
+}
+
+func (ai *TsulongAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+	ai.TerrorUnit = target.Env.Encounter.AllTargetUnits[1]
+
+	if !ai.isBoss {
+		ai.registerTerrorize()
+		return
+	}
+
+	// Save user input parameters
+	ai.nightDuration = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.dayDuration = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+	// Register relevant spells and auras
+	ai.registerNightSpells()
+	ai.registerDreadShadows()
+	ai.registerDayNightCycle()
+}
+
+func (ai *TsulongAI) registerNightSpells() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	shadowBreathBase := []float64{200_000, 290_000, 280_000, 400_000}[idx]
+	nightmaresBase := []float64{55_000, 65_000, 80_000, 95_000}[idx]
+	numNightmaresTargets := core.TernaryInt(ai.raidSize == 10, 2, 4)
+
+	ai.ShadowBreath = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 122752},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 28,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageRoll := shadowBreathBase * (0.9 + 0.2*sim.RandomFloat("Shadow Breath"))
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeAlwaysHit)
+		},
+	})
+
+	ai.Nightmares = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 122770},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			for i := 0; i < min(numNightmaresTargets, len(players)); i++ {
+				player := players[int(sim.RandomFloat("Nightmares Target")*float64(len(players)))]
+				damageRoll := nightmaresBase * (0.9 + 0.2*sim.RandomFloat("Nightmares"))
+				spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+				encounter_utils.MovePlayer(sim, player, time.Second)
+			}
+		},
+	})
+}
+
+// During the Night, Dread Shadows pulses the raid with steadily stacking
+// Shadow damage. The stacks fade when the Day begins.
+func (ai *TsulongAI) registerDreadShadows() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	dreadShadowsBase := []float64{1_500, 1_800, 2_200, 2_600}[idx]
+
+	ai.DreadShadowAura = ai.BossUnit.RegisterAura(core.Aura{
+		Label:     "Dread Shadows",
+		ActionID:  core.ActionID{SpellID: 122767},
+		Duration:  core.NeverExpires,
+		MaxStacks: 100,
+	})
+
+	ai.DreadShadows = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 122768},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			ai.DreadShadowAura.Activate(sim)
+			ai.DreadShadowAura.AddStack(sim)
+			damageRoll := dreadShadowsBase * float64(ai.DreadShadowAura.GetStacks())
+			encounter_utils.DealRaidDamage(sim, spell, damageRoll, damageRoll*0.1)
+		},
+	})
+
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   time.Second * 3,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				if ai.BossUnit.IsEnabled() {
+					ai.DreadShadows.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+				}
+			},
+		})
+	})
+}
+
+func (ai *TsulongAI) registerTerrorize() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	terrorizeBase := []float64{40_000, 45_000, 55_000, 62_000}[idx]
+
+	ai.Terrorize = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 123012},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second + core.BossGCD,
+				CastTime: time.Second,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 14,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			players := sim.Raid.AllPlayerUnits
+			player := players[int(sim.RandomFloat("Terrorize Target")*float64(len(players)))]
+			damageRoll := terrorizeBase * (0.9 + 0.2*sim.RandomFloat("Terrorize"))
+			spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+// Tsulong turns friendly during the Day, so the boss is swapped out for the
+// Embodied Terror until Night falls again.
+func (ai *TsulongAI) registerDayNightCycle() {
+	var startDay, startNight func(sim *core.Simulation)
+
+	schedule := func(sim *core.Simulation, delay time.Duration, action func(sim *core.Simulation)) {
+		pa := sim.GetConsumedPendingActionFromPool()
+		pa.NextActionAt = sim.CurrentTime + delay
+		pa.Priority = core.ActionPriorityDOT
+		pa.OnAction = action
+		sim.AddPendingAction(pa)
+	}
+
+	startDay = func(sim *core.Simulation) {
+		sim.EnableTargetUnit(ai.TerrorUnit)
+		sim.DisableTargetUnit(ai.BossUnit, false)
+		ai.DreadShadowAura.Deactivate(sim)
+		schedule(sim, ai.dayDuration, startNight)
+	}
+
+	startNight = func(sim *core.Simulation) {
+		sim.EnableTargetUnit(ai.BossUnit)
+		sim.DisableTargetUnit(ai.TerrorUnit, true)
+		schedule(sim, ai.nightDuration, startDay)
+	}
+
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		schedule(sim, ai.nightDuration, startDay)
+	})
+}
+
+func (ai *TsulongAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	ai.ShadowBreath.CD.Set(core.DurationFromSeconds(20 + 5*sim.RandomFloat("Shadow Breath Timing")))
+	ai.Nightmares.CD.Set(core.DurationFromSeconds(10 + 5*sim.RandomFloat("Nightmares Timing")))
+}
+
+func (ai *TsulongAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if !ai.isBoss {
+		if ai.Terrorize.IsReady(sim) {
+			ai.Terrorize.Cast(sim, target)
+			return
+		}
+	} else {
+		if ai.ShadowBreath.IsReady(sim) {
+			ai.ShadowBreath.Cast(sim, target)
+			return
+		}
+
+		if ai.Nightmares.IsReady(sim) {
+			ai.Nightmares.Cast(sim, target)
+			return
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
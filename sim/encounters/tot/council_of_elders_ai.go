@@ -0,0 +1,322 @@
+package tot
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const councilMeleeDamageSpread = 0.4
+
+type councillor struct {
+	name      string
+	npcID     int32
+	tankIndex int32
+
+	// Cast on a random player regardless of possession.
+	spellID int32
+	school  core.SpellSchool
+
+	// Cast on the whole raid while possessed by Gara'jal's spirit.
+	empoweredSpellID int32
+	empoweredSchool  core.SpellSchool
+
+	// How long the raid spends moving after the empowered ability.
+	empoweredMoveDuration time.Duration
+}
+
+// The Loa spirit possesses the councillors in this order.
+var councillors = []councillor{
+	{"Frost King Malakk", 69131, 0, 136917, core.SpellSchoolFrost, 136990, core.SpellSchoolFrost, 0},
+	{"Kazra'jin", 69134, 1, 137122, core.SpellSchoolPhysical, 137149, core.SpellSchoolNature, time.Second * 2},
+	{"Sul the Sandcrawler", 69078, 1, 136189, core.SpellSchoolNature, 136894, core.SpellSchoolNature, time.Second * 3},
+	{"High Priestess Mar'li", 69132, 0, 137344, core.SpellSchoolShadow, 137350, core.SpellSchoolShadow, 0},
+}
+
+func addCouncilOfElders(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	councillorHealth := []float64{32_000_000, 96_000_000, 48_000_000, 144_000_000}
+	councillorMinBaseDamage := []float64{95_000, 140_000, 135_000, 205_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createCouncilOfEldersPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, councillorHealth[idx], councillorMinBaseDamage[idx])
+	}
+}
+
+func createCouncilOfEldersPreset(raidPrefix string, raidSize int32, isHeroic bool, councillorHealth float64, councillorMinBaseDamage float64) {
+	var targetPathNames []string
+
+	for councillorIdx, councillor := range councillors {
+		targetName := encounter_utils.PresetName(councillor.name, raidSize, isHeroic)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        encounter_utils.PresetID(councillor.npcID, raidSize, isHeroic),
+				Name:      targetName,
+				Level:     93,
+				MobType:   proto.MobType_MobTypeHumanoid,
+				TankIndex: councillor.tankIndex,
+
+				Stats: stats.Stats{
+					stats.Health:      councillorHealth,
+					stats.Armor:       24835,
+					stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+				}.ToProtoArray(),
+
+				SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:    2.0,
+				MinBaseDamage: councillorMinBaseDamage,
+				DamageSpread:  councilMeleeDamageSpread,
+				TargetInputs:  councilOfEldersTargetInputs(councillorIdx),
+			},
+
+			AI: makeCouncilOfEldersAI(raidSize, isHeroic, councillorIdx),
+		})
+
+		targetPathNames = append(targetPathNames, raidPrefix+"/"+targetName)
+	}
+
+	core.AddPresetEncounter(encounter_utils.PresetName("Council of Elders", raidSize, isHeroic), targetPathNames)
+}
+
+func councilOfEldersTargetInputs(councillorIdx int) []*proto.TargetInput {
+	if councillorIdx > 0 {
+		return []*proto.TargetInput{}
+	}
+
+	return []*proto.TargetInput{
+		{
+			Label:       "Possession duration",
+			Tooltip:     "Time (in seconds) that Gara'jal's spirit possesses each councillor before the raid drives it out and it moves on to the next one",
+			InputType:   proto.InputType_Number,
+			NumberValue: 40,
+		},
+	}
+}
+
+func makeCouncilOfEldersAI(raidSize int32, isHeroic bool, councillorIdx int) core.AIFactory {
+	return func() core.TargetAI {
+		return &CouncilOfEldersAI{
+			raidSize:      raidSize,
+			isHeroic:      isHeroic,
+			councillorIdx: councillorIdx,
+			councillor:    councillors[councillorIdx],
+		}
+	}
+}
+
+type CouncilOfEldersAI struct {
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize      int32
+	isHeroic      bool
+	councillorIdx int
+	councillor    councillor
+
+	// Dynamic parameters taken from user inputs. Only the first councillor
+	// drives the possession cycle.
+	possessionDuration time.Duration
+
+	// Spell + aura references
+	CouncillorSpell       *core.Spell
+	EmpoweredSpell        *core.Spell
+	DarkPower             *core.Spell
+	PossessedAura         *core.Aura
+	LingeringPresenceAura *core.Aura
+}
+
+func (ai *CouncilOfEldersAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	if ai.councillorIdx == 0 {
+		ai.possessionDuration = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	}
+
+	ai.registerCouncillorSpell()
+	ai.registerEmpoweredSpell()
+	ai.registerLingeringPresence()
+	ai.registerPossession()
+}
+
+func (ai *CouncilOfEldersAI) registerCouncillorSpell() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	councillorSpellBase := []float64{45_000, 55_000, 65_000, 80_000}[idx]
+
+	ai.CouncillorSpell = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: ai.councillor.spellID},
+		SpellSchool:      ai.councillor.school,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond*1500 + core.BossGCD,
+				CastTime: time.Millisecond * 1500,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 12,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			player := encounter_utils.RandomPlayer(sim, ai.councillor.name)
+			damageRoll := councillorSpellBase * (0.9 + 0.2*sim.RandomFloat(ai.councillor.name+" Damage"))
+			spell.CalcAndDealDamage(sim, player, damageRoll, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *CouncilOfEldersAI) registerEmpoweredSpell() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	empoweredSpellBase := []float64{35_000, 40_000, 50_000, 58_000}[idx]
+
+	ai.EmpoweredSpell = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: ai.councillor.empoweredSpellID},
+		SpellSchool:      ai.councillor.empoweredSchool,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 20,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, empoweredSpellBase, empoweredSpellBase*0.1)
+
+			if ai.councillor.empoweredMoveDuration > 0 {
+				encounter_utils.MoveRaid(sim, ai.councillor.empoweredMoveDuration)
+			}
+		},
+	})
+}
+
+// Every possession leaves behind a stack of Lingering Presence, so each
+// councillor hits harder the more often it has been possessed.
+func (ai *CouncilOfEldersAI) registerLingeringPresence() {
+	const damagePerStack = 0.1
+
+	ai.LingeringPresenceAura = ai.Target.RegisterAura(core.Aura{
+		Label:     "Lingering Presence",
+		ActionID:  core.ActionID{SpellID: 136467},
+		Duration:  core.NeverExpires,
+		MaxStacks: 10,
+
+		OnStacksChange: func(aura *core.Aura, _ *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= (1 + damagePerStack*float64(newStacks)) / (1 + damagePerStack*float64(oldStacks))
+		},
+	})
+}
+
+// While possessed, a councillor builds Dark Power, pulsing increasingly heavy
+// damage on the raid, and unlocks its empowered ability.
+func (ai *CouncilOfEldersAI) registerPossession() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	darkPowerBase := []float64{5_000, 6_000, 7_000, 8_500}[idx]
+
+	ai.DarkPower = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136507},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+	})
+
+	var darkPowerAction *core.PendingAction
+
+	ai.PossessedAura = ai.Target.RegisterAura(core.Aura{
+		Label:    "Possessed",
+		ActionID: core.ActionID{SpellID: 136442},
+		Duration: core.NeverExpires,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			ai.EmpoweredSpell.CD.Set(sim.CurrentTime + time.Second*10)
+			numPulses := 0
+
+			darkPowerAction = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					numPulses++
+					damage := darkPowerBase * (1 + 0.1*float64(numPulses))
+					encounter_utils.DealRaidDamage(sim, ai.DarkPower, damage, damage*0.1)
+				},
+			})
+		},
+
+		OnExpire: func(_ *core.Aura, sim *core.Simulation) {
+			darkPowerAction.Cancel(sim)
+			ai.LingeringPresenceAura.Activate(sim)
+			ai.LingeringPresenceAura.AddStack(sim)
+		},
+	})
+}
+
+// Moves the Loa spirit on to the next councillor every possessionDuration.
+func (ai *CouncilOfEldersAI) startPossessionCycle(sim *core.Simulation) {
+	possessedAuras := make([]*core.Aura, len(councillors))
+	for i, target := range sim.Encounter.AllTargets[:len(councillors)] {
+		possessedAuras[i] = target.AI.(*CouncilOfEldersAI).PossessedAura
+	}
+
+	possessedIdx := 0
+	possessedAuras[possessedIdx].Activate(sim)
+
+	core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+		Period:   ai.possessionDuration,
+		Priority: core.ActionPriorityDOT,
+
+		OnAction: func(sim *core.Simulation) {
+			possessedAuras[possessedIdx].Deactivate(sim)
+			possessedIdx = (possessedIdx + 1) % len(possessedAuras)
+			possessedAuras[possessedIdx].Activate(sim)
+		},
+	})
+}
+
+func (ai *CouncilOfEldersAI) Reset(sim *core.Simulation) {
+	ai.CouncillorSpell.CD.Set(core.DurationFromSeconds(3 + 9*sim.RandomFloat("Councillor Spell Timing")))
+
+	if ai.councillorIdx == 0 {
+		ai.startPossessionCycle(sim)
+	}
+}
+
+func (ai *CouncilOfEldersAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if ai.PossessedAura.IsActive() && ai.EmpoweredSpell.IsReady(sim) {
+		ai.EmpoweredSpell.Cast(sim, target)
+		return
+	}
+
+	if ai.CouncillorSpell.IsReady(sim) {
+		ai.CouncillorSpell.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,381 @@
+package tot
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const durumuMeleeDamageSpread = 0.4
+const durumuBossID int32 = 68905
+const crimsonFogID int32 = 69050
+
+// Durumu alternates between a Light Spectrum phase, where the raid hunts down
+// the revealed fogs, and a Disintegration Beam maze, on a fixed cycle.
+const durumuCycleDuration = time.Second * 190
+const durumuFirstLightSpectrum = time.Second * 40
+const durumuFirstDisintegrationBeam = time.Second * 130
+
+func addDurumu(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{104_000_000, 312_000_000, 156_000_000, 468_000_000}
+	bossMinBaseDamage := []float64{115_000, 170_000, 165_000, 245_000}
+	fogHealth := []float64{3_500_000, 10_500_000, 5_200_000, 15_500_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createDurumuPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], fogHealth[idx])
+	}
+}
+
+func createDurumuPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, fogHealth float64) {
+	bossName := encounter_utils.PresetName("Durumu the Forgotten", raidSize, isHeroic)
+	fogName := encounter_utils.PresetName("Crimson Fog", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(durumuBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeUnknown,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  durumuMeleeDamageSpread,
+			TargetInputs:  durumuTargetInputs(),
+		},
+
+		AI: makeDurumuAI(raidSize, isHeroic, true),
+	})
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(crimsonFogID, raidSize, isHeroic),
+			Name:      fogName,
+			Level:     92,
+			MobType:   proto.MobType_MobTypeUnknown,
+			TankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health: fogHealth,
+				stats.Armor:  24835, // TODO: verify add armor
+			}.ToProtoArray(),
+
+			TargetInputs:    []*proto.TargetInput{},
+			DisabledAtStart: true,
+		},
+
+		AI: makeDurumuAI(raidSize, isHeroic, false),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + fogName,
+	})
+}
+
+func durumuTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Fog kill time",
+			Tooltip:     "Time (in seconds) after each Light Spectrum that the raid spends killing the revealed fogs",
+			InputType:   proto.InputType_Number,
+			NumberValue: 30,
+		},
+		{
+			Label:       "Disintegration Beam movement",
+			Tooltip:     "Time (in seconds) that each player spends moving during a Disintegration Beam",
+			InputType:   proto.InputType_Number,
+			NumberValue: 20,
+		},
+	}
+}
+
+func makeDurumuAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &DurumuAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type DurumuAI struct {
+	// Unit references
+	Target   *core.Target
+	BossUnit *core.Unit
+	FogUnit  *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	fogKillTime  time.Duration
+	beamMovement time.Duration
+
+	// Spell + aura references
+	HardStare          *core.Spell
+	SeriousWoundAuras  core.AuraArray
+	ForceOfWill        *core.Spell
+	LingeringGaze      *core.Spell
+	LightSpectrum      *core.Spell
+	DisintegrationBeam *core.Spell
+}
+
+func (ai *DurumuAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+	ai.FogUnit = target.Env.Encounter.AllTargetUnits[1]
+
+	if !ai.isBoss {
+		return
+	}
+
+	// Save user input parameters
+	ai.fogKillTime = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.beamMovement = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+	// Register relevant spells and auras
+	ai.registerHardStare()
+	ai.registerForceOfWill()
+	ai.registerLingeringGaze()
+	ai.registerLightSpectrum()
+	ai.registerDisintegrationBeam()
+}
+
+// Hard Stare leaves a stacking Serious Wound on the tank that reduces all
+// healing received.
+func (ai *DurumuAI) registerHardStare() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	hardStareBase := []float64{55_000, 80_000, 75_000, 115_000}[idx]
+
+	const healingReductionPerStack = 0.1
+
+	ai.SeriousWoundAuras = ai.BossUnit.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:     "Serious Wound",
+			ActionID:  core.ActionID{SpellID: 133767},
+			Duration:  time.Second * 60,
+			MaxStacks: 9,
+
+			OnStacksChange: func(aura *core.Aura, _ *core.Simulation, oldStacks int32, newStacks int32) {
+				aura.Unit.PseudoStats.HealingTakenMultiplier *= (1 - healingReductionPerStack*float64(newStacks)) / (1 - healingReductionPerStack*float64(oldStacks))
+			},
+		})
+	})
+
+	ai.HardStare = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 133765},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 12,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageRoll := hardStareBase * (0.9 + 0.2*sim.RandomFloat("Hard Stare"))
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeEnemyMeleeWhite)
+
+			aura := ai.SeriousWoundAuras.Get(target)
+			aura.Activate(sim)
+			aura.AddStack(sim)
+		},
+	})
+}
+
+// Force of Will blasts a random player out of position, and they have to walk
+// back in.
+func (ai *DurumuAI) registerForceOfWill() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	forceOfWillBase := []float64{50_000, 60_000, 70_000, 85_000}[idx]
+
+	ai.ForceOfWill = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136413},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 20,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			player := encounter_utils.RandomPlayer(sim, "Force of Will")
+			spell.CalcAndDealDamage(sim, player, forceOfWillBase*(0.9+0.2*sim.RandomFloat("Force of Will Damage")), spell.OutcomeAlwaysHit)
+			encounter_utils.KnockBack(sim, player, 30)
+		},
+	})
+}
+
+func (ai *DurumuAI) registerLingeringGaze() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	lingeringGazeBase := []float64{40_000, 45_000, 55_000, 65_000}[idx]
+	numGazeTargets := core.TernaryInt(ai.raidSize == 10, 2, 5)
+
+	ai.LingeringGaze = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 134044},
+		SpellSchool:      core.SpellSchoolShadow,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 25,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for i := 0; i < numGazeTargets; i++ {
+				player := encounter_utils.RandomPlayer(sim, "Lingering Gaze")
+				spell.CalcAndDealDamage(sim, player, lingeringGazeBase*(0.9+0.2*sim.RandomFloat("Lingering Gaze Damage")), spell.OutcomeAlwaysHit)
+				encounter_utils.MovePlayer(sim, player, time.Second*2)
+			}
+		},
+	})
+}
+
+// Light Spectrum reveals the fogs hiding in the room, which the raid has to
+// kill while Durumu keeps attacking the tank.
+func (ai *DurumuAI) registerLightSpectrum() {
+	ai.LightSpectrum = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 133737},
+		ProcMask: core.ProcMaskEmpty,
+		Flags:    core.SpellFlagNoMetrics,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: durumuCycleDuration,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			sim.EnableTargetUnit(ai.FogUnit)
+
+			pa := sim.GetConsumedPendingActionFromPool()
+			pa.NextActionAt = sim.CurrentTime + ai.fogKillTime
+			pa.Priority = core.ActionPriorityDOT
+
+			pa.OnAction = func(sim *core.Simulation) {
+				sim.DisableTargetUnit(ai.FogUnit, true)
+			}
+
+			sim.AddPendingAction(pa)
+		},
+	})
+}
+
+func (ai *DurumuAI) registerDisintegrationBeam() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	eyeSorePulse := []float64{6_000, 7_000, 9_000, 10_500}[idx]
+
+	ai.DisintegrationBeam = encounter_utils.RegisterRaidChannel(ai.BossUnit, encounter_utils.RaidChannelConfig{
+		ActionID:      core.ActionID{SpellID: 133775},
+		SpellSchool:   core.SpellSchoolShadow,
+		Cooldown:      durumuCycleDuration,
+		ChannelTime:   time.Second * 55,
+		PulseBase:     eyeSorePulse,
+		PulseVariance: eyeSorePulse * 0.1,
+		MoveDuration:  ai.beamMovement,
+	})
+}
+
+func (ai *DurumuAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	ai.HardStare.CD.Set(core.DurationFromSeconds(3 + 5*sim.RandomFloat("Hard Stare Timing")))
+	ai.ForceOfWill.CD.Set(time.Second * 30)
+	ai.LingeringGaze.CD.Set(time.Second * 15)
+	ai.LightSpectrum.CD.Set(durumuFirstLightSpectrum)
+	ai.DisintegrationBeam.CD.Set(durumuFirstDisintegrationBeam)
+}
+
+func (ai *DurumuAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if ai.DisintegrationBeam.IsReady(sim) {
+		ai.DisintegrationBeam.Cast(sim, target)
+		return
+	}
+
+	if ai.LightSpectrum.IsReady(sim) {
+		ai.LightSpectrum.Cast(sim, target)
+		return
+	}
+
+	if ai.HardStare.IsReady(sim) {
+		ai.HardStare.Cast(sim, target)
+		return
+	}
+
+	if ai.LingeringGaze.IsReady(sim) {
+		ai.LingeringGaze.Cast(sim, target)
+		return
+	}
+
+	if ai.ForceOfWill.IsReady(sim) {
+		ai.ForceOfWill.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
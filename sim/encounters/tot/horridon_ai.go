@@ -0,0 +1,622 @@
+package tot
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const horridonMeleeDamageSpread = 0.4
+const horridonBossID int32 = 68476
+const jalakID int32 = 69374
+
+// Time between add waves coming out of an open door.
+const horridonWaveInterval = time.Second * 18
+
+type horridonTribe struct {
+	name    string
+	npcID   int32
+	spellID int32
+	school  core.SpellSchool
+}
+
+// Tribal doors open in this order. Each tribe is modeled as a single add
+// target that is enabled for every wave it sends out.
+var horridonTribes = []horridonTribe{
+	{"Farraki Wastewalker", 69175, 136719, core.SpellSchoolFire},
+	{"Gurubashi Venom Priest", 69164, 136587, core.SpellSchoolNature},
+	{"Drakkari Frozen Warlord", 69178, 136573, core.SpellSchoolFrost},
+	{"Amani'shi Beast Shaman", 69176, 136480, core.SpellSchoolNature},
+}
+
+func addHorridon(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{110_000_000, 330_000_000, 165_000_000, 495_000_000}
+	bossMinBaseDamage := []float64{120_000, 180_000, 175_000, 260_000}
+	tribeHealth := []float64{2_500_000, 7_500_000, 3_800_000, 11_000_000}
+	jalakHealth := []float64{20_000_000, 60_000_000, 30_000_000, 90_000_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createHorridonPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], tribeHealth[idx], jalakHealth[idx])
+	}
+}
+
+func createHorridonPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, tribeHealth float64, jalakHealth float64) {
+	bossName := encounter_utils.PresetName("Horridon", raidSize, isHeroic)
+	jalakName := encounter_utils.PresetName("War-God Jalak", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(horridonBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeBeast,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  horridonMeleeDamageSpread,
+			TargetInputs:  horridonTargetInputs(),
+		},
+
+		AI: makeHorridonAI(raidSize, isHeroic, true),
+	})
+
+	targetPathNames := []string{raidPrefix + "/" + bossName}
+
+	for tribeIdx, tribe := range horridonTribes {
+		tribeName := encounter_utils.PresetName(tribe.name, raidSize, isHeroic)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        encounter_utils.PresetID(tribe.npcID, raidSize, isHeroic),
+				Name:      tribeName,
+				Level:     92,
+				MobType:   proto.MobType_MobTypeHumanoid,
+				TankIndex: 1,
+
+				Stats: stats.Stats{
+					stats.Health: tribeHealth,
+					stats.Armor:  24835, // TODO: verify add armor
+				}.ToProtoArray(),
+
+				SpellSchool:     proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:      2.0,
+				MinBaseDamage:   bossMinBaseDamage * 0.2,
+				DamageSpread:    horridonMeleeDamageSpread,
+				TargetInputs:    []*proto.TargetInput{},
+				DisabledAtStart: true,
+			},
+
+			AI: makeHorridonTribeAI(raidSize, isHeroic, tribeIdx),
+		})
+
+		targetPathNames = append(targetPathNames, raidPrefix+"/"+tribeName)
+	}
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(jalakID, raidSize, isHeroic),
+			Name:      jalakName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health: jalakHealth,
+				stats.Armor:  24835,
+			}.ToProtoArray(),
+
+			SpellSchool:     proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:      2.0,
+			MinBaseDamage:   bossMinBaseDamage * 0.6,
+			DamageSpread:    horridonMeleeDamageSpread,
+			TargetInputs:    []*proto.TargetInput{},
+			DisabledAtStart: true,
+		},
+
+		AI: makeHorridonAI(raidSize, isHeroic, false),
+	})
+
+	targetPathNames = append(targetPathNames, raidPrefix+"/"+jalakName)
+	core.AddPresetEncounter(bossName, targetPathNames)
+}
+
+func horridonTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Door interval",
+			Tooltip:     "Time (in seconds) between tribal doors opening. Horridon charges the previous door, and is stunned by Headache, whenever a new one opens.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 115,
+		},
+		{
+			Label:       "Add wave kill time",
+			Tooltip:     "Time (in seconds) after spawning that each wave of tribal adds dies",
+			InputType:   proto.InputType_Number,
+			NumberValue: 15,
+		},
+		{
+			Label:       "Jalak kill time",
+			Tooltip:     "Time (in seconds) after jumping down that War-God Jalak dies, sending Horridon into a Rampage",
+			InputType:   proto.InputType_Number,
+			NumberValue: 40,
+		},
+	}
+}
+
+func makeHorridonAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &HorridonAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type HorridonAI struct {
+	// Unit references
+	Target     *core.Target
+	BossUnit   *core.Unit
+	TribeUnits []*core.Unit
+	JalakUnit  *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	doorInterval  time.Duration
+	waveKillTime  time.Duration
+	jalakKillTime time.Duration
+
+	// Spell + aura references
+	TriplePuncture      *core.Spell
+	TriplePunctureAuras core.AuraArray
+	DoubleSwipe         *core.Spell
+	Charge              *core.Spell
+	DireCall            *core.Spell
+	BestialCry          *core.Spell
+	HeadacheAura        *core.Aura
+	RampageAura         *core.Aura
+
+	// Encounter state
+	waveSpawnedAt []time.Duration
+}
+
+func (ai *HorridonAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+	ai.TribeUnits = target.Env.Encounter.AllTargetUnits[1 : len(horridonTribes)+1]
+	ai.JalakUnit = target.Env.Encounter.AllTargetUnits[len(horridonTribes)+1]
+
+	if !ai.isBoss {
+		ai.registerBestialCry()
+		return
+	}
+
+	// Save user input parameters
+	ai.doorInterval = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.waveKillTime = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+	ai.jalakKillTime = core.DurationFromSeconds(config.TargetInputs[2].NumberValue)
+	ai.waveSpawnedAt = make([]time.Duration, len(horridonTribes))
+
+	// Register relevant spells and auras
+	ai.registerTriplePuncture()
+	ai.registerDoubleSwipe()
+	ai.registerCharge()
+	ai.registerDireCall()
+	ai.registerHeadache()
+	ai.registerRampage()
+	ai.registerDoors()
+}
+
+// Each Triple Puncture makes the next one hit the tank 10% harder, which is
+// what forces tank swaps on this fight.
+func (ai *HorridonAI) registerTriplePuncture() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	triplePunctureBase := []float64{65_000, 95_000, 90_000, 135_000}[idx]
+
+	ai.TriplePunctureAuras = ai.BossUnit.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:     "Triple Puncture",
+			ActionID:  core.ActionID{SpellID: 136767},
+			Duration:  time.Second * 90,
+			MaxStacks: 99,
+		})
+	})
+
+	ai.TriplePuncture = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136767},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 11,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			aura := ai.TriplePunctureAuras.Get(target)
+			damageRoll := triplePunctureBase * (0.9 + 0.2*sim.RandomFloat("Triple Puncture"))
+			damageRoll *= 1 + 0.1*float64(aura.GetStacks())
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeEnemyMeleeWhite)
+
+			aura.Activate(sim)
+			aura.AddStack(sim)
+		},
+	})
+}
+
+func (ai *HorridonAI) registerDoubleSwipe() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	doubleSwipeBase := []float64{80_000, 120_000, 115_000, 170_000}[idx]
+
+	ai.DoubleSwipe = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136741},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second + core.BossGCD,
+				CastTime: time.Second,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 16,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageRoll := doubleSwipeBase * (0.9 + 0.2*sim.RandomFloat("Double Swipe"))
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeEnemyMeleeWhite)
+		},
+	})
+}
+
+// Horridon charges a random player, who has to get out of the way of the
+// Double Swipe that follows.
+func (ai *HorridonAI) registerCharge() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	chargeBase := []float64{40_000, 45_000, 55_000, 65_000}[idx]
+
+	ai.Charge = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136769},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 50,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			player := encounter_utils.RandomPlayer(sim, "Charge")
+			spell.CalcAndDealDamage(sim, player, chargeBase*(0.9+0.2*sim.RandomFloat("Charge Damage")), spell.OutcomeAlwaysHit)
+			encounter_utils.MovePlayer(sim, player, time.Second*2)
+			ai.DoubleSwipe.CD.Reset()
+		},
+	})
+}
+
+func (ai *HorridonAI) registerDireCall() {
+	if !ai.isHeroic {
+		return
+	}
+
+	direCallBase := []float64{0, 0, 55_000, 65_000}[encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)]
+
+	ai.DireCall = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 137458},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 62,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, direCallBase, direCallBase*0.1)
+		},
+	})
+}
+
+// Headache stuns Horridon after he charges into a door, and the raid gets a
+// damage window while he is down.
+func (ai *HorridonAI) registerHeadache() {
+	const headacheDuration = time.Second * 10
+
+	ai.HeadacheAura = ai.BossUnit.RegisterAura(core.Aura{
+		Label:    "Headache",
+		ActionID: core.ActionID{SpellID: 137294},
+		Duration: headacheDuration,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+headacheDuration)
+			aura.Unit.ExtendGCDUntil(sim, sim.CurrentTime+headacheDuration)
+		},
+	}).AttachMultiplicativePseudoStatBuff(&ai.BossUnit.PseudoStats.DamageTakenMultiplier, 1.5)
+}
+
+func (ai *HorridonAI) registerRampage() {
+	ai.RampageAura = ai.BossUnit.RegisterAura(core.Aura{
+		Label:    "Rampage",
+		ActionID: core.ActionID{SpellID: 136821},
+		Duration: core.NeverExpires,
+	}).AttachMultiplicativePseudoStatBuff(&ai.BossUnit.PseudoStats.DamageDealtMultiplier, 1.5)
+}
+
+// Doors open one after another. An open door sends out a wave of its tribe
+// every horridonWaveInterval until the next one opens, and War-God Jalak
+// jumps down once the last door has been cleared.
+func (ai *HorridonAI) registerDoors() {
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		for doorIdx := range horridonTribes {
+			doorOpensAt := ai.doorInterval*time.Duration(doorIdx) + time.Second*20
+			numWaves := int(ai.doorInterval / horridonWaveInterval)
+
+			for waveIdx := 0; waveIdx < numWaves; waveIdx++ {
+				pa := sim.GetConsumedPendingActionFromPool()
+				pa.NextActionAt = doorOpensAt + horridonWaveInterval*time.Duration(waveIdx)
+				pa.Priority = core.ActionPriorityDOT
+
+				pa.OnAction = func(sim *core.Simulation) {
+					if (waveIdx == 0) && (doorIdx > 0) {
+						ai.HeadacheAura.Activate(sim)
+					}
+
+					ai.spawnWave(sim, doorIdx)
+				}
+
+				sim.AddPendingAction(pa)
+			}
+		}
+
+		pa := sim.GetConsumedPendingActionFromPool()
+		pa.NextActionAt = ai.doorInterval*time.Duration(len(horridonTribes)) + time.Second*20
+		pa.Priority = core.ActionPriorityDOT
+
+		pa.OnAction = func(sim *core.Simulation) {
+			ai.HeadacheAura.Activate(sim)
+			sim.EnableTargetUnit(ai.JalakUnit)
+
+			killAction := sim.GetConsumedPendingActionFromPool()
+			killAction.NextActionAt = sim.CurrentTime + ai.jalakKillTime
+			killAction.Priority = core.ActionPriorityDOT
+
+			killAction.OnAction = func(sim *core.Simulation) {
+				sim.DisableTargetUnit(ai.JalakUnit, true)
+				ai.RampageAura.Activate(sim)
+			}
+
+			sim.AddPendingAction(killAction)
+		}
+
+		sim.AddPendingAction(pa)
+	})
+}
+
+func (ai *HorridonAI) spawnWave(sim *core.Simulation, tribeIdx int) {
+	tribeUnit := ai.TribeUnits[tribeIdx]
+	sim.EnableTargetUnit(tribeUnit)
+	ai.waveSpawnedAt[tribeIdx] = sim.CurrentTime
+	spawnedAt := sim.CurrentTime
+
+	pa := sim.GetConsumedPendingActionFromPool()
+	pa.NextActionAt = sim.CurrentTime + ai.waveKillTime
+	pa.Priority = core.ActionPriorityDOT
+
+	pa.OnAction = func(sim *core.Simulation) {
+		// Skip if a later wave of the same tribe is still alive.
+		if tribeUnit.IsEnabled() && (ai.waveSpawnedAt[tribeIdx] == spawnedAt) {
+			sim.DisableTargetUnit(tribeUnit, true)
+		}
+	}
+
+	sim.AddPendingAction(pa)
+}
+
+// War-God Jalak periodically roars at the raid for increasing damage.
+func (ai *HorridonAI) registerBestialCry() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	bestialCryBase := []float64{20_000, 24_000, 30_000, 35_000}[idx]
+	numCries := 0
+
+	ai.BestialCry = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136817},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			numCries++
+			damage := bestialCryBase * (1 + 0.5*float64(numCries-1))
+			encounter_utils.DealRaidDamage(sim, spell, damage, damage*0.1)
+		},
+	})
+
+	ai.Target.RegisterResetEffect(func(_ *core.Simulation) {
+		numCries = 0
+	})
+}
+
+func (ai *HorridonAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		ai.BestialCry.CD.Set(time.Second * 5)
+		return
+	}
+
+	ai.TriplePuncture.CD.Set(core.DurationFromSeconds(3 + 5*sim.RandomFloat("Triple Puncture Timing")))
+	ai.DoubleSwipe.CD.Set(time.Second * 16)
+	ai.Charge.CD.Set(time.Second * 30)
+
+	if ai.DireCall != nil {
+		ai.DireCall.CD.Set(time.Second * 60)
+	}
+}
+
+func (ai *HorridonAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if !ai.isBoss {
+		if ai.BestialCry.IsReady(sim) {
+			ai.BestialCry.Cast(sim, target)
+			return
+		}
+
+		ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+		return
+	}
+
+	if (ai.DireCall != nil) && ai.DireCall.IsReady(sim) {
+		ai.DireCall.Cast(sim, target)
+		return
+	}
+
+	if ai.Charge.IsReady(sim) {
+		ai.Charge.Cast(sim, target)
+		return
+	}
+
+	if ai.DoubleSwipe.IsReady(sim) {
+		ai.DoubleSwipe.Cast(sim, target)
+		return
+	}
+
+	if ai.TriplePuncture.IsReady(sim) {
+		ai.TriplePuncture.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
+
+func makeHorridonTribeAI(raidSize int32, isHeroic bool, tribeIdx int) core.AIFactory {
+	return func() core.TargetAI {
+		return &HorridonTribeAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			tribe:    horridonTribes[tribeIdx],
+		}
+	}
+}
+
+// Each tribe's adds cast their own signature spell while they are up.
+type HorridonTribeAI struct {
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	tribe    horridonTribe
+
+	// Spell references
+	TribeSpell *core.Spell
+}
+
+func (ai *HorridonTribeAI) Initialize(target *core.Target, _ *proto.Target) {
+	ai.Target = target
+
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	tribeSpellBase := []float64{15_000, 18_000, 22_000, 26_000}[idx]
+
+	ai.TribeSpell = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: ai.tribe.spellID},
+		SpellSchool:      ai.tribe.school,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 8,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, tribeSpellBase, tribeSpellBase*0.1)
+		},
+	})
+}
+
+func (ai *HorridonTribeAI) Reset(_ *core.Simulation) {
+}
+
+func (ai *HorridonTribeAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if ai.TribeSpell.IsReady(sim) {
+		ai.TribeSpell.Cast(sim, encounter_utils.SpellTarget(ai.Target))
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
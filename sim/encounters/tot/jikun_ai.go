@@ -0,0 +1,345 @@
+package tot
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const jiKunMeleeDamageSpread = 0.4
+const jiKunBossID int32 = 69712
+const jiKunJuvenileID int32 = 70095
+
+func addJiKun(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{100_000_000, 300_000_000, 150_000_000, 450_000_000}
+	bossMinBaseDamage := []float64{110_000, 165_000, 160_000, 240_000}
+	juvenileHealth := []float64{1_500_000, 4_500_000, 2_300_000, 6_800_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createJiKunPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], juvenileHealth[idx])
+	}
+}
+
+func createJiKunPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, juvenileHealth float64) {
+	bossName := encounter_utils.PresetName("Ji-Kun", raidSize, isHeroic)
+	juvenileName := encounter_utils.PresetName("Juvenile", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(jiKunBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeBeast,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  jiKunMeleeDamageSpread,
+			TargetInputs:  jiKunTargetInputs(),
+		},
+
+		AI: makeJiKunAI(raidSize, isHeroic, true),
+	})
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(jiKunJuvenileID, raidSize, isHeroic),
+			Name:      juvenileName,
+			Level:     92,
+			MobType:   proto.MobType_MobTypeBeast,
+			TankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health: juvenileHealth,
+				stats.Armor:  24835, // TODO: verify add armor
+			}.ToProtoArray(),
+
+			TargetInputs:    []*proto.TargetInput{},
+			DisabledAtStart: true,
+		},
+
+		AI: makeJiKunAI(raidSize, isHeroic, false),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + juvenileName,
+	})
+}
+
+func jiKunTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Nest interval",
+			Tooltip:     "Time (in seconds) between nests hatching. Every hatch sends a group of players up to the nest.",
+			InputType:   proto.InputType_Number,
+			NumberValue: 30,
+		},
+		{
+			Label:       "Nest kill time",
+			Tooltip:     "Time (in seconds) that the nest group spends away from the boss killing the hatched Juveniles",
+			InputType:   proto.InputType_Number,
+			NumberValue: 15,
+		},
+	}
+}
+
+func makeJiKunAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &JiKunAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type JiKunAI struct {
+	// Unit references
+	Target       *core.Target
+	BossUnit     *core.Unit
+	JuvenileUnit *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	nestInterval time.Duration
+	nestKillTime time.Duration
+
+	// Spell + aura references
+	TalonRake      *core.Spell
+	TalonRakeAuras core.AuraArray
+	Caw            *core.Spell
+	Quills         *core.Spell
+	DownDraft      *core.Spell
+}
+
+func (ai *JiKunAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+	ai.JuvenileUnit = target.Env.Encounter.AllTargetUnits[1]
+
+	if !ai.isBoss {
+		return
+	}
+
+	// Save user input parameters
+	ai.nestInterval = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+	ai.nestKillTime = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+
+	// Register relevant spells and auras
+	ai.registerTalonRake()
+	ai.registerCaw()
+	ai.registerQuills()
+	ai.registerDownDraft()
+	ai.registerNests()
+}
+
+// Each Talon Rake makes the next one hit the tank harder.
+func (ai *JiKunAI) registerTalonRake() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	talonRakeBase := []float64{75_000, 110_000, 105_000, 160_000}[idx]
+
+	ai.TalonRakeAuras = ai.BossUnit.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:     "Talon Rake",
+			ActionID:  core.ActionID{SpellID: 134366},
+			Duration:  time.Second * 60,
+			MaxStacks: 99,
+		})
+	})
+
+	ai.TalonRake = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 134366},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 20,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			aura := ai.TalonRakeAuras.Get(target)
+			damageRoll := talonRakeBase * (0.9 + 0.2*sim.RandomFloat("Talon Rake"))
+			damageRoll *= 1 + 0.5*float64(aura.GetStacks())
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeEnemyMeleeWhite)
+
+			aura.Activate(sim)
+			aura.AddStack(sim)
+		},
+	})
+}
+
+func (ai *JiKunAI) registerCaw() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	cawBase := []float64{45_000, 50_000, 60_000, 70_000}[idx]
+	numCawTargets := core.TernaryInt(ai.raidSize == 10, 2, 5)
+
+	ai.Caw = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 138926},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 18,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for i := 0; i < numCawTargets; i++ {
+				player := encounter_utils.RandomPlayer(sim, "Caw")
+				spell.CalcAndDealDamage(sim, player, cawBase*(0.9+0.2*sim.RandomFloat("Caw Damage")), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *JiKunAI) registerQuills() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	quillsPulse := []float64{25_000, 29_000, 36_000, 42_000}[idx]
+
+	ai.Quills = encounter_utils.RegisterRaidChannel(ai.BossUnit, encounter_utils.RaidChannelConfig{
+		ActionID:      core.ActionID{SpellID: 134380},
+		SpellSchool:   core.SpellSchoolNature,
+		Cooldown:      time.Second * 62,
+		ChannelTime:   time.Second * 10,
+		PulseBase:     quillsPulse,
+		PulseVariance: quillsPulse * 0.1,
+	})
+}
+
+// Down Draft blows the whole raid towards the edge of the platform, and
+// everyone has to walk back against the wind.
+func (ai *JiKunAI) registerDownDraft() {
+	ai.DownDraft = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 134370},
+		ProcMask: core.ProcMaskEmpty,
+		Flags:    core.SpellFlagNoMetrics,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 93,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for _, player := range sim.Raid.AllPlayerUnits {
+				encounter_utils.KnockBack(sim, player, 15)
+			}
+		},
+	})
+}
+
+// Hatching nests pull a group of players away from the boss, who fly up and
+// kill the Juveniles before coming back down.
+func (ai *JiKunAI) registerNests() {
+	numNestPlayers := core.TernaryInt(ai.raidSize == 10, 2, 5)
+
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   ai.nestInterval,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				sim.EnableTargetUnit(ai.JuvenileUnit)
+
+				for i := 0; i < numNestPlayers; i++ {
+					encounter_utils.MovePlayer(sim, encounter_utils.RandomPlayer(sim, "Nest Group"), time.Second*5)
+				}
+
+				pa := sim.GetConsumedPendingActionFromPool()
+				pa.NextActionAt = sim.CurrentTime + min(ai.nestKillTime, ai.nestInterval-time.Millisecond)
+				pa.Priority = core.ActionPriorityDOT
+
+				pa.OnAction = func(sim *core.Simulation) {
+					sim.DisableTargetUnit(ai.JuvenileUnit, true)
+				}
+
+				sim.AddPendingAction(pa)
+			},
+		})
+	})
+}
+
+func (ai *JiKunAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	ai.TalonRake.CD.Set(core.DurationFromSeconds(5 + 5*sim.RandomFloat("Talon Rake Timing")))
+	ai.Caw.CD.Set(time.Second * 15)
+	ai.Quills.CD.Set(time.Second * 40)
+	ai.DownDraft.CD.Set(time.Second * 90)
+}
+
+func (ai *JiKunAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if ai.Quills.IsReady(sim) {
+		ai.Quills.Cast(sim, target)
+		return
+	}
+
+	if ai.DownDraft.IsReady(sim) {
+		ai.DownDraft.Cast(sim, target)
+		return
+	}
+
+	if ai.TalonRake.IsReady(sim) {
+		ai.TalonRake.Cast(sim, target)
+		return
+	}
+
+	if ai.Caw.IsReady(sim) {
+		ai.Caw.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
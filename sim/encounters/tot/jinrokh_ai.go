@@ -0,0 +1,347 @@
+package tot
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const jinrokhMeleeDamageSpread = 0.4
+const jinrokhBossID int32 = 69465
+
+func addJinrokh(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{118_000_000, 354_000_000, 177_000_000, 531_000_000}
+	bossMinBaseDamage := []float64{125_000, 185_000, 180_000, 270_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createJinrokhPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx])
+	}
+}
+
+func createJinrokhPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64) {
+	bossName := encounter_utils.PresetName("Jin'rokh the Breaker", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(jinrokhBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  jinrokhMeleeDamageSpread,
+			TargetInputs:  []*proto.TargetInput{},
+		},
+
+		AI: makeJinrokhAI(raidSize, isHeroic),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+	})
+}
+
+func makeJinrokhAI(raidSize int32, isHeroic bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &JinrokhAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+		}
+	}
+}
+
+type JinrokhAI struct {
+	// Unit references
+	Target *core.Target
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+
+	// Spell + aura references
+	StaticBurst      *core.Spell
+	StaticWound      *core.Spell
+	StaticWoundAuras core.AuraArray
+	FocusedLightning *core.Spell
+	ThunderingThrow  *core.Spell
+	LightningStorm   *core.Spell
+	Ionization       *core.Spell
+	IonizationAuras  core.AuraArray
+}
+
+func (ai *JinrokhAI) Initialize(target *core.Target, _ *proto.Target) {
+	ai.Target = target
+
+	ai.registerStaticBurst()
+	ai.registerFocusedLightning()
+	ai.registerThunderingThrow()
+	ai.registerLightningStorm()
+	ai.registerIonization()
+}
+
+// Static Burst leaves a stacking Static Wound on the tank, which deals damage
+// every second and loses a stack each time it does.
+func (ai *JinrokhAI) registerStaticBurst() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	staticBurstBase := []float64{60_000, 90_000, 85_000, 125_000}[idx]
+	staticWoundBase := []float64{6_000, 9_000, 8_500, 12_500}[idx]
+
+	const staticWoundStacks = 10
+
+	ai.StaticWound = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 138349},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+	})
+
+	ai.StaticWoundAuras = ai.Target.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		var tickAction *core.PendingAction
+
+		return unit.RegisterAura(core.Aura{
+			Label:     "Static Wound",
+			ActionID:  core.ActionID{SpellID: 138349},
+			Duration:  time.Second * 25,
+			MaxStacks: staticWoundStacks,
+
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				tickAction = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+					Period:   time.Second,
+					Priority: core.ActionPriorityDOT,
+
+					OnAction: func(sim *core.Simulation) {
+						damage := staticWoundBase * float64(aura.GetStacks())
+						ai.StaticWound.CalcAndDealDamage(sim, aura.Unit, damage, ai.StaticWound.OutcomeAlwaysHit)
+						aura.RemoveStack(sim)
+					},
+				})
+			},
+
+			OnExpire: func(_ *core.Aura, sim *core.Simulation) {
+				tickAction.Cancel(sim)
+			},
+		})
+	})
+
+	ai.StaticBurst = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 137162},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 19,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageRoll := staticBurstBase * (0.9 + 0.2*sim.RandomFloat("Static Burst"))
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeAlwaysHit)
+
+			aura := ai.StaticWoundAuras.Get(target)
+			aura.Deactivate(sim)
+			aura.Activate(sim)
+			aura.SetStacks(sim, staticWoundStacks)
+		},
+	})
+}
+
+// Focused Lightning fixates on a random player, who kites it into a pool of
+// Conductive Water before it detonates.
+func (ai *JinrokhAI) registerFocusedLightning() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	detonationBase := []float64{70_000, 80_000, 100_000, 115_000}[idx]
+
+	ai.FocusedLightning = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 137399},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			player := encounter_utils.RandomPlayer(sim, "Focused Lightning")
+			encounter_utils.MovePlayer(sim, player, time.Second*4)
+			spell.CalcAndDealDamage(sim, player, detonationBase*(0.9+0.2*sim.RandomFloat("Focused Lightning Damage")), spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+// Thundering Throw hurls the tank into a statue, who then has to walk back to
+// the boss.
+func (ai *JinrokhAI) registerThunderingThrow() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	thunderingThrowBase := []float64{90_000, 130_000, 125_000, 180_000}[idx]
+
+	ai.ThunderingThrow = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 137175},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 90,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealDamage(sim, target, thunderingThrowBase, spell.OutcomeAlwaysHit)
+			encounter_utils.KnockBack(sim, target, 25)
+		},
+	})
+}
+
+func (ai *JinrokhAI) registerLightningStorm() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	lightningStormPulse := []float64{18_000, 21_000, 27_000, 31_000}[idx]
+
+	ai.LightningStorm = encounter_utils.RegisterRaidChannel(&ai.Target.Unit, encounter_utils.RaidChannelConfig{
+		ActionID:      core.ActionID{SpellID: 137313},
+		SpellSchool:   core.SpellSchoolNature,
+		Cooldown:      time.Second * 90,
+		ChannelTime:   time.Second * 15,
+		PulseBase:     lightningStormPulse,
+		PulseVariance: lightningStormPulse * 0.1,
+		MoveDuration:  time.Second * 3,
+	})
+}
+
+// On heroic, Ionization marks the whole raid. Players who fail to clear it
+// in a pool take a burst of damage when it expires.
+func (ai *JinrokhAI) registerIonization() {
+	if !ai.isHeroic {
+		return
+	}
+
+	ionizationBase := []float64{0, 0, 120_000, 140_000}[encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)]
+
+	ai.IonizationAuras = ai.Target.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:    "Ionization",
+			ActionID: core.ActionID{SpellID: 138732},
+			Duration: time.Second * 24,
+
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				// Only a natural expiry detonates, not the end of the fight.
+				if aura.ExpiresAt() > sim.CurrentTime {
+					return
+				}
+
+				damageRoll := ionizationBase * (0.9 + 0.2*sim.RandomFloat("Ionization"))
+				ai.Ionization.CalcAndDealDamage(sim, aura.Unit, damageRoll, ai.Ionization.OutcomeAlwaysHit)
+			},
+		})
+	})
+
+	ai.Ionization = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 138732},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 60,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for _, player := range sim.Raid.AllPlayerUnits {
+				ai.IonizationAuras.Get(player).Activate(sim)
+			}
+		},
+	})
+}
+
+func (ai *JinrokhAI) Reset(sim *core.Simulation) {
+	ai.StaticBurst.CD.Set(core.DurationFromSeconds(5 + 5*sim.RandomFloat("Static Burst Timing")))
+	ai.FocusedLightning.CD.Set(time.Second * 8)
+	ai.ThunderingThrow.CD.Set(time.Second * 30)
+	ai.LightningStorm.CD.Set(time.Second * 90)
+
+	if ai.Ionization != nil {
+		ai.Ionization.CD.Set(time.Second * 60)
+	}
+}
+
+func (ai *JinrokhAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if ai.LightningStorm.IsReady(sim) {
+		ai.LightningStorm.Cast(sim, target)
+		return
+	}
+
+	if (ai.Ionization != nil) && ai.Ionization.IsReady(sim) {
+		ai.Ionization.Cast(sim, target)
+		return
+	}
+
+	if ai.ThunderingThrow.IsReady(sim) {
+		ai.ThunderingThrow.Cast(sim, target)
+		return
+	}
+
+	if ai.StaticBurst.IsReady(sim) {
+		ai.StaticBurst.Cast(sim, target)
+		return
+	}
+
+	if ai.FocusedLightning.IsReady(sim) {
+		ai.FocusedLightning.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
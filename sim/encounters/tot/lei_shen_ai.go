@@ -0,0 +1,547 @@
+package tot
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const leiShenMeleeDamageSpread = 0.4
+const leiShenBossID int32 = 68397
+const diffusedLightningID int32 = 69012
+
+// Boss health percentages at which Lei Shen retreats to supercharge the
+// conduits, each ending the current phase.
+var leiShenIntermissionThresholds = []float64{65, 30}
+
+func addLeiShen(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{150_000_000, 450_000_000, 225_000_000, 675_000_000}
+	bossMinBaseDamage := []float64{130_000, 195_000, 190_000, 285_000}
+	diffusedLightningHealth := []float64{1_200_000, 3_600_000, 1_800_000, 5_400_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createLeiShenPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], diffusedLightningHealth[idx])
+	}
+}
+
+func createLeiShenPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, diffusedLightningHealth float64) {
+	bossName := encounter_utils.PresetName("Lei Shen", raidSize, isHeroic)
+	diffusedLightningName := encounter_utils.PresetName("Diffused Lightning", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(leiShenBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  leiShenMeleeDamageSpread,
+			TargetInputs:  leiShenTargetInputs(),
+		},
+
+		AI: makeLeiShenAI(raidSize, isHeroic, true),
+	})
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(diffusedLightningID, raidSize, isHeroic),
+			Name:      diffusedLightningName,
+			Level:     92,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health: diffusedLightningHealth,
+				stats.Armor:  24835, // TODO: verify add armor
+			}.ToProtoArray(),
+
+			TargetInputs:    []*proto.TargetInput{},
+			DisabledAtStart: true,
+		},
+
+		AI: makeLeiShenAI(raidSize, isHeroic, false),
+	})
+
+	core.AddPresetEncounter(bossName, []string{
+		raidPrefix + "/" + bossName,
+		raidPrefix + "/" + diffusedLightningName,
+	})
+}
+
+func leiShenTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:       "Intermission duration",
+			Tooltip:     "Time (in seconds) that Lei Shen spends untargetable during each intermission, while the raid kills Diffused Lightning",
+			InputType:   proto.InputType_Number,
+			NumberValue: 45,
+		},
+	}
+}
+
+func makeLeiShenAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &LeiShenAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type LeiShenAI struct {
+	// Unit references
+	Target                *core.Target
+	BossUnit              *core.Unit
+	DiffusedLightningUnit *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	intermissionDuration time.Duration
+
+	// Spell + aura references
+	Decapitate             *core.Spell
+	DecapitateAuras        core.AuraArray
+	Thunderstruck          *core.Spell
+	StaticShock            *core.Spell
+	StaticShockAuras       core.AuraArray
+	FusionSlash            *core.Spell
+	LightningWhip          *core.Spell
+	BallLightning          *core.Spell
+	OverwhelmingPower      *core.Spell
+	OverwhelmingPowerAuras core.AuraArray
+	ViolentGaleWinds       *core.Spell
+	SuperchargeConduits    *core.Spell
+
+	// Encounter state
+	phase int
+}
+
+func (ai *LeiShenAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+	ai.DiffusedLightningUnit = target.Env.Encounter.AllTargetUnits[1]
+
+	if !ai.isBoss {
+		return
+	}
+
+	// Save user input parameters
+	ai.intermissionDuration = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+
+	// Register relevant spells and auras
+	ai.registerDecapitate()
+	ai.registerThunderstruck()
+	ai.registerStaticShock()
+	ai.registerFusionSlash()
+	ai.registerLightningWhip()
+	ai.registerBallLightning()
+	ai.registerOverwhelmingPower()
+	ai.registerViolentGaleWinds()
+	ai.registerSuperchargeConduits()
+}
+
+// Decapitate marks the tank, who runs away from Lei Shen before it goes off.
+func (ai *LeiShenAI) registerDecapitate() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	decapitateBase := []float64{150_000, 220_000, 210_000, 310_000}[idx]
+
+	ai.DecapitateAuras = ai.BossUnit.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:    "Decapitate",
+			ActionID: core.ActionID{SpellID: 134912},
+			Duration: time.Second * 5,
+
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				// Only a natural expiry goes off, not the end of the fight.
+				if aura.ExpiresAt() > sim.CurrentTime {
+					return
+				}
+
+				damageRoll := decapitateBase * (0.9 + 0.2*sim.RandomFloat("Decapitate"))
+				ai.Decapitate.CalcAndDealDamage(sim, aura.Unit, damageRoll, ai.Decapitate.OutcomeAlwaysHit)
+			},
+		})
+	})
+
+	ai.Decapitate = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 134912},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 50,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			ai.DecapitateAuras.Get(target).Activate(sim)
+			encounter_utils.MovePlayer(sim, target, time.Second*3)
+		},
+	})
+}
+
+func (ai *LeiShenAI) registerThunderstruck() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	thunderstruckBase := []float64{60_000, 70_000, 85_000, 100_000}[idx]
+
+	ai.Thunderstruck = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 135095},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 46,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, thunderstruckBase, thunderstruckBase*0.1)
+		},
+	})
+}
+
+// Static Shock marks a random player. When it expires, its damage is split
+// between everyone who stacked up with them.
+func (ai *LeiShenAI) registerStaticShock() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	staticShockTotal := []float64{1_000_000, 2_500_000, 1_400_000, 3_500_000}[idx]
+
+	ai.StaticShock = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 135695},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 40,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			ai.StaticShockAuras.Get(encounter_utils.RandomPlayer(sim, "Static Shock")).Activate(sim)
+		},
+	})
+
+	ai.StaticShockAuras = ai.BossUnit.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:    "Static Shock",
+			ActionID: core.ActionID{SpellID: 135695},
+			Duration: time.Second * 8,
+
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				if aura.ExpiresAt() > sim.CurrentTime {
+					return
+				}
+
+				splitDamage := staticShockTotal / float64(len(sim.Raid.AllPlayerUnits))
+				encounter_utils.DealRaidDamage(sim, ai.StaticShock, splitDamage, splitDamage*0.05)
+			},
+		})
+	})
+}
+
+func (ai *LeiShenAI) registerFusionSlash() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	fusionSlashBase := []float64{200_000, 300_000, 290_000, 430_000}[idx]
+
+	ai.FusionSlash = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136478},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 42,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageRoll := fusionSlashBase * (0.9 + 0.2*sim.RandomFloat("Fusion Slash"))
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *LeiShenAI) registerLightningWhip() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	lightningWhipPulse := []float64{30_000, 35_000, 43_000, 50_000}[idx]
+
+	ai.LightningWhip = encounter_utils.RegisterRaidChannel(ai.BossUnit, encounter_utils.RaidChannelConfig{
+		ActionID:      core.ActionID{SpellID: 136850},
+		SpellSchool:   core.SpellSchoolNature,
+		Cooldown:      time.Second * 46,
+		ChannelTime:   time.Second * 3,
+		PulseBase:     lightningWhipPulse,
+		PulseVariance: lightningWhipPulse * 0.1,
+		MoveDuration:  time.Second * 2,
+	})
+}
+
+func (ai *LeiShenAI) registerBallLightning() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	ballLightningBase := []float64{45_000, 52_000, 65_000, 75_000}[idx]
+	numBallLightningTargets := core.TernaryInt(ai.raidSize == 10, 3, 6)
+
+	ai.BallLightning = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136543},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 46,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for i := 0; i < numBallLightningTargets; i++ {
+				player := encounter_utils.RandomPlayer(sim, "Ball Lightning")
+				spell.CalcAndDealDamage(sim, player, ballLightningBase*(0.9+0.2*sim.RandomFloat("Ball Lightning Damage")), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+// In the final phase, Lei Shen's melee stacks Overwhelming Power on the tank,
+// increasing all damage they take.
+func (ai *LeiShenAI) registerOverwhelmingPower() {
+	const damageTakenPerStack = 0.15
+
+	ai.OverwhelmingPowerAuras = ai.BossUnit.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:     "Overwhelming Power",
+			ActionID:  core.ActionID{SpellID: 136913},
+			Duration:  time.Second * 60,
+			MaxStacks: 99,
+
+			OnStacksChange: func(aura *core.Aura, _ *core.Simulation, oldStacks int32, newStacks int32) {
+				aura.Unit.PseudoStats.DamageTakenMultiplier *= (1 + damageTakenPerStack*float64(newStacks)) / (1 + damageTakenPerStack*float64(oldStacks))
+			},
+		})
+	})
+
+	ai.OverwhelmingPower = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 136913},
+		ProcMask: core.ProcMaskEmpty,
+		Flags:    core.SpellFlagNoMetrics,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			aura := ai.OverwhelmingPowerAuras.Get(target)
+			aura.Activate(sim)
+			aura.AddStack(sim)
+		},
+	})
+}
+
+// Violent Gale Winds periodically push the whole raid away from Lei Shen.
+func (ai *LeiShenAI) registerViolentGaleWinds() {
+	ai.ViolentGaleWinds = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 136889},
+		ProcMask: core.ProcMaskEmpty,
+		Flags:    core.SpellFlagNoMetrics,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for _, player := range sim.Raid.AllPlayerUnits {
+				encounter_utils.KnockBack(sim, player, 10)
+			}
+		},
+	})
+}
+
+// Lei Shen retreats to supercharge the conduits, becoming untargetable while
+// the raid deals with Diffused Lightning. He comes back in the next phase.
+func (ai *LeiShenAI) registerSuperchargeConduits() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	overchargePulse := []float64{15_000, 17_000, 21_000, 25_000}[idx]
+
+	ai.SuperchargeConduits = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 137045},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			sim.EnableTargetUnit(ai.DiffusedLightningUnit)
+			sim.DisableTargetUnit(ai.BossUnit, false)
+
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				NumTicks: int(ai.intermissionDuration / (time.Second * 2)),
+				Priority: core.ActionPriorityDOT,
+
+				OnAction: func(sim *core.Simulation) {
+					encounter_utils.DealRaidDamage(sim, spell, overchargePulse, overchargePulse*0.1)
+				},
+			})
+
+			pa := sim.GetConsumedPendingActionFromPool()
+			pa.NextActionAt = sim.CurrentTime + ai.intermissionDuration
+			pa.Priority = core.ActionPriorityDOT
+
+			pa.OnAction = func(sim *core.Simulation) {
+				sim.EnableTargetUnit(ai.BossUnit)
+				sim.DisableTargetUnit(ai.DiffusedLightningUnit, true)
+				ai.startPhase(sim, ai.phase+1)
+			}
+
+			sim.AddPendingAction(pa)
+		},
+	})
+}
+
+func (ai *LeiShenAI) startPhase(sim *core.Simulation, phase int) {
+	ai.phase = phase
+
+	switch phase {
+	case 2:
+		ai.FusionSlash.CD.Set(sim.CurrentTime + time.Second*10)
+		ai.LightningWhip.CD.Set(sim.CurrentTime + time.Second*20)
+		ai.BallLightning.CD.Set(sim.CurrentTime + time.Second*15)
+	case 3:
+		ai.LightningWhip.CD.Set(sim.CurrentTime + time.Second*15)
+		ai.BallLightning.CD.Set(sim.CurrentTime + time.Second*25)
+		ai.ViolentGaleWinds.CD.Set(sim.CurrentTime + time.Second*20)
+		ai.OverwhelmingPower.CD.Set(sim.CurrentTime + time.Second*5)
+	}
+}
+
+func (ai *LeiShenAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	ai.phase = 1
+	ai.Decapitate.CD.Set(time.Second * 40)
+	ai.Thunderstruck.CD.Set(time.Second * 25)
+	ai.StaticShock.CD.Set(time.Second * 20)
+}
+
+func (ai *LeiShenAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if (ai.phase <= len(leiShenIntermissionThresholds)) && encounter_utils.BossHealthBelow(sim, leiShenIntermissionThresholds[ai.phase-1]) {
+		ai.SuperchargeConduits.Cast(sim, target)
+		return
+	}
+
+	var rotation []*core.Spell
+
+	switch ai.phase {
+	case 1:
+		rotation = []*core.Spell{ai.Thunderstruck, ai.Decapitate, ai.StaticShock}
+	case 2:
+		rotation = []*core.Spell{ai.LightningWhip, ai.FusionSlash, ai.BallLightning}
+	default:
+		rotation = []*core.Spell{ai.LightningWhip, ai.ViolentGaleWinds, ai.BallLightning, ai.OverwhelmingPower}
+	}
+
+	for _, spell := range rotation {
+		if spell.IsReady(sim) {
+			spell.Cast(sim, target)
+			return
+		}
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,369 @@
+package tot
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/encounters/encounter_utils"
+)
+
+const primordiusMeleeDamageSpread = 0.4
+const primordiusBossID int32 = 69017
+const viscousHorrorID int32 = 69070
+
+func addPrimordius(raidPrefix string) {
+	// Approximate values, pending a proper fit against logs.
+	bossHealth := []float64{96_000_000, 288_000_000, 144_000_000, 432_000_000}
+	bossMinBaseDamage := []float64{105_000, 155_000, 150_000, 225_000}
+	viscousHorrorHealth := []float64{0, 0, 9_000_000, 27_000_000}
+
+	for _, difficulty := range encounter_utils.Difficulties {
+		idx := encounter_utils.ScalingIndex(difficulty.RaidSize, difficulty.IsHeroic)
+		createPrimordiusPreset(raidPrefix, difficulty.RaidSize, difficulty.IsHeroic, bossHealth[idx], bossMinBaseDamage[idx], viscousHorrorHealth[idx])
+	}
+}
+
+func createPrimordiusPreset(raidPrefix string, raidSize int32, isHeroic bool, bossHealth float64, bossMinBaseDamage float64, viscousHorrorHealth float64) {
+	bossName := encounter_utils.PresetName("Primordius", raidSize, isHeroic)
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: raidPrefix,
+
+		Config: &proto.Target{
+			Id:        encounter_utils.PresetID(primordiusBossID, raidSize, isHeroic),
+			Name:      bossName,
+			Level:     93,
+			MobType:   proto.MobType_MobTypeUnknown,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      bossHealth,
+				stats.Armor:       24835,
+				stats.AttackPower: 0, // actual value doesn't matter in Cata/MoP, as long as damage parameters are fit consistently
+			}.ToProtoArray(),
+
+			SpellSchool:   proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:    2.0,
+			MinBaseDamage: bossMinBaseDamage,
+			DamageSpread:  primordiusMeleeDamageSpread,
+			TargetInputs:  primordiusTargetInputs(isHeroic),
+		},
+
+		AI: makePrimordiusAI(raidSize, isHeroic, true),
+	})
+
+	targetPathNames := []string{raidPrefix + "/" + bossName}
+
+	// Viscous Horrors only show up on heroic.
+	if isHeroic {
+		viscousHorrorName := encounter_utils.PresetName("Viscous Horror", raidSize, isHeroic)
+
+		core.AddPresetTarget(&core.PresetTarget{
+			PathPrefix: raidPrefix,
+
+			Config: &proto.Target{
+				Id:        encounter_utils.PresetID(viscousHorrorID, raidSize, isHeroic),
+				Name:      viscousHorrorName,
+				Level:     92,
+				MobType:   proto.MobType_MobTypeUnknown,
+				TankIndex: 1,
+
+				Stats: stats.Stats{
+					stats.Health: viscousHorrorHealth,
+					stats.Armor:  24835, // TODO: verify add armor
+				}.ToProtoArray(),
+
+				SpellSchool:     proto.SpellSchool_SpellSchoolPhysical,
+				SwingSpeed:      2.0,
+				MinBaseDamage:   bossMinBaseDamage * 0.5,
+				DamageSpread:    primordiusMeleeDamageSpread,
+				TargetInputs:    []*proto.TargetInput{},
+				DisabledAtStart: true,
+			},
+
+			AI: makePrimordiusAI(raidSize, isHeroic, false),
+		})
+
+		targetPathNames = append(targetPathNames, raidPrefix+"/"+viscousHorrorName)
+	}
+
+	core.AddPresetEncounter(bossName, targetPathNames)
+}
+
+func primordiusTargetInputs(isHeroic bool) []*proto.TargetInput {
+	inputs := []*proto.TargetInput{
+		{
+			Label:       "Evolution interval",
+			Tooltip:     "Time (in seconds) between Living Fluids reaching Primordius and making him Evolve",
+			InputType:   proto.InputType_Number,
+			NumberValue: 32,
+		},
+	}
+
+	if isHeroic {
+		inputs = append(inputs, &proto.TargetInput{
+			Label:       "Viscous Horror kill time",
+			Tooltip:     "Time (in seconds) after spawning that each Viscous Horror dies",
+			InputType:   proto.InputType_Number,
+			NumberValue: 25,
+		})
+	}
+
+	return inputs
+}
+
+func makePrimordiusAI(raidSize int32, isHeroic bool, isBoss bool) core.AIFactory {
+	return func() core.TargetAI {
+		return &PrimordiusAI{
+			raidSize: raidSize,
+			isHeroic: isHeroic,
+			isBoss:   isBoss,
+		}
+	}
+}
+
+type PrimordiusAI struct {
+	// Unit references
+	Target            *core.Target
+	BossUnit          *core.Unit
+	ViscousHorrorUnit *core.Unit
+
+	// Static parameters associated with a given preset
+	raidSize int32
+	isHeroic bool
+	isBoss   bool
+
+	// Dynamic parameters taken from user inputs
+	evolutionInterval     time.Duration
+	viscousHorrorKillTime time.Duration
+
+	// Spell + aura references
+	PrimordialStrike    *core.Spell
+	MalformedBlood      *core.Spell
+	MalformedBloodAuras core.AuraArray
+	CausticGas          *core.Spell
+	EvolutionAura       *core.Aura
+
+	// Encounter state
+	viscousHorrorSpawnedAt time.Duration
+}
+
+func (ai *PrimordiusAI) Initialize(target *core.Target, config *proto.Target) {
+	// Save unit references
+	ai.Target = target
+	ai.BossUnit = target.Env.Encounter.AllTargetUnits[0]
+
+	if !ai.isBoss {
+		return
+	}
+
+	if ai.isHeroic {
+		ai.ViscousHorrorUnit = target.Env.Encounter.AllTargetUnits[1]
+		ai.viscousHorrorKillTime = core.DurationFromSeconds(config.TargetInputs[1].NumberValue)
+	}
+
+	// Save user input parameters
+	ai.evolutionInterval = core.DurationFromSeconds(config.TargetInputs[0].NumberValue)
+
+	// Register relevant spells and auras
+	ai.registerPrimordialStrike()
+	ai.registerCausticGas()
+	ai.registerEvolution()
+	ai.registerViscousHorrors()
+}
+
+// Primordial Strike leaves a stacking Malformed Blood on the tank, which deals
+// Nature damage over time for every stack.
+func (ai *PrimordiusAI) registerPrimordialStrike() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	primordialStrikeBase := []float64{70_000, 105_000, 100_000, 150_000}[idx]
+	malformedBloodBase := []float64{4_000, 6_000, 5_500, 8_500}[idx]
+
+	ai.MalformedBlood = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136050},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+	})
+
+	ai.MalformedBloodAuras = ai.BossUnit.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		var tickAction *core.PendingAction
+
+		return unit.RegisterAura(core.Aura{
+			Label:     "Malformed Blood",
+			ActionID:  core.ActionID{SpellID: 136050},
+			Duration:  time.Second * 60,
+			MaxStacks: 99,
+
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				tickAction = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+					Period:   time.Second * 2,
+					Priority: core.ActionPriorityDOT,
+
+					OnAction: func(sim *core.Simulation) {
+						damage := malformedBloodBase * float64(aura.GetStacks())
+						ai.MalformedBlood.CalcAndDealDamage(sim, aura.Unit, damage, ai.MalformedBlood.OutcomeAlwaysHit)
+					},
+				})
+			},
+
+			OnExpire: func(_ *core.Aura, sim *core.Simulation) {
+				tickAction.Cancel(sim)
+			},
+		})
+	})
+
+	ai.PrimordialStrike = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136037},
+		SpellSchool:      core.SpellSchoolPhysical,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagMeleeMetrics,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.BossGCD,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 20,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageRoll := primordialStrikeBase * (0.9 + 0.2*sim.RandomFloat("Primordial Strike"))
+			spell.CalcAndDealDamage(sim, target, damageRoll, spell.OutcomeEnemyMeleeWhite)
+
+			aura := ai.MalformedBloodAuras.Get(target)
+			aura.Activate(sim)
+			aura.AddStack(sim)
+		},
+	})
+}
+
+func (ai *PrimordiusAI) registerCausticGas() {
+	idx := encounter_utils.ScalingIndex(ai.raidSize, ai.isHeroic)
+	causticGasBase := []float64{45_000, 52_000, 65_000, 75_000}[idx]
+
+	ai.CausticGas = ai.BossUnit.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 136216},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagIgnoreArmor,
+		DamageMultiplier: 1,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second*2 + core.BossGCD,
+				CastTime: time.Second * 2,
+			},
+
+			CD: core.Cooldown{
+				Timer:    ai.BossUnit.NewTimer(),
+				Duration: time.Second * 14,
+			},
+
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			encounter_utils.DealRaidDamage(sim, spell, causticGasBase, causticGasBase*0.1)
+		},
+	})
+}
+
+// Every Living Fluid the raid fails to soak reaches Primordius, who Evolves
+// and hits harder for the rest of the fight.
+func (ai *PrimordiusAI) registerEvolution() {
+	const damagePerStack = 0.1
+
+	ai.EvolutionAura = ai.BossUnit.RegisterAura(core.Aura{
+		Label:     "Evolution",
+		ActionID:  core.ActionID{SpellID: 139144},
+		Duration:  core.NeverExpires,
+		MaxStacks: 20,
+
+		OnStacksChange: func(aura *core.Aura, _ *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= (1 + damagePerStack*float64(newStacks)) / (1 + damagePerStack*float64(oldStacks))
+		},
+	})
+
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   ai.evolutionInterval,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: func(sim *core.Simulation) {
+				ai.EvolutionAura.Activate(sim)
+				ai.EvolutionAura.AddStack(sim)
+			},
+		})
+	})
+}
+
+func (ai *PrimordiusAI) registerViscousHorrors() {
+	if ai.ViscousHorrorUnit == nil {
+		return
+	}
+
+	ai.BossUnit.RegisterResetEffect(func(sim *core.Simulation) {
+		core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   time.Second * 30,
+			Priority: core.ActionPriorityDOT,
+
+			OnAction: ai.spawnViscousHorror,
+		})
+	})
+}
+
+func (ai *PrimordiusAI) spawnViscousHorror(sim *core.Simulation) {
+	sim.EnableTargetUnit(ai.ViscousHorrorUnit)
+	ai.viscousHorrorSpawnedAt = sim.CurrentTime
+	spawnedAt := sim.CurrentTime
+
+	pa := sim.GetConsumedPendingActionFromPool()
+	pa.NextActionAt = sim.CurrentTime + ai.viscousHorrorKillTime
+	pa.Priority = core.ActionPriorityDOT
+
+	pa.OnAction = func(sim *core.Simulation) {
+		// Skip if a newer Viscous Horror is still alive.
+		if ai.ViscousHorrorUnit.IsEnabled() && (ai.viscousHorrorSpawnedAt == spawnedAt) {
+			sim.DisableTargetUnit(ai.ViscousHorrorUnit, true)
+		}
+	}
+
+	sim.AddPendingAction(pa)
+}
+
+func (ai *PrimordiusAI) Reset(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	ai.PrimordialStrike.CD.Set(core.DurationFromSeconds(5 + 5*sim.RandomFloat("Primordial Strike Timing")))
+	ai.CausticGas.CD.Set(time.Second * 12)
+}
+
+func (ai *PrimordiusAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.isBoss {
+		return
+	}
+
+	target := encounter_utils.SpellTarget(ai.Target)
+
+	if ai.CausticGas.IsReady(sim) {
+		ai.CausticGas.Cast(sim, target)
+		return
+	}
+
+	if ai.PrimordialStrike.IsReady(sim) {
+		ai.PrimordialStrike.Cast(sim, target)
+		return
+	}
+
+	ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+core.BossGCD)
+}
@@ -0,0 +1,11 @@
+package tot
+
+func Register() {
+	addJinrokh("Throne of Thunder")
+	addHorridon("Throne of Thunder")
+	addCouncilOfElders("Throne of Thunder")
+	addDurumu("Throne of Thunder")
+	addPrimordius("Throne of Thunder")
+	addJiKun("Throne of Thunder")
+	addLeiShen("Throne of Thunder")
+}
@@ -0,0 +1,315 @@
+// Package gym wraps an interactive simulation in a reinforcement learning
+// style environment: players are driven by external policies which receive a
+// fixed-size observation vector, an action mask, and a per-step reward.
+//
+// The environment runs the sim with SimOptions.Interactive, so every player in
+// the raid waits for input whenever its GCD is ready. Players which are not
+// listed in Options.Players are still asked for input, and are told to wait.
+package gym
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// ActionWait is the action index which does nothing until the next decision.
+const ActionWait = 0
+
+const defaultWaitDuration = time.Millisecond * 100
+
+type Options struct {
+	// Raid indices (party index * 5 + index within party) of the players
+	// controlled by the environment. Defaults to the first player.
+	Players []int32 `json:"players"`
+
+	// Labels of auras whose remaining duration and stacks are included in the
+	// observation, for the acting player and its current target respectively.
+	SelfAuras   []string `json:"selfAuras"`
+	TargetAuras []string `json:"targetAuras"`
+
+	// How long the wait action pauses a player. Defaults to 100ms.
+	WaitDuration time.Duration `json:"waitDuration"`
+}
+
+// Implemented by balance druids.
+type eclipseAgent interface {
+	CurrentSolarEnergy() int32
+	CurrentLunarEnergy() int32
+}
+
+type agent struct {
+	raidIndex int32
+	character *core.Character
+	eclipse   eclipseAgent
+
+	// Castable spells, indexed by action - 1.
+	actions     []*core.Spell
+	selfAuras   []*core.Aura
+	targetAuras []string
+
+	damageAtLastStep float64
+}
+
+type Env struct {
+	options Options
+	sim     *core.Simulation
+	agents  []*agent
+
+	// Index into agents of the player currently waiting for input, or -1 if
+	// the waiting player is not controlled by the environment.
+	current int
+	done    bool
+}
+
+// New builds an environment from a raid sim request. The request is copied and
+// forced into interactive mode; the player specs must already be registered,
+// e.g. with sim.RegisterAll().
+func New(rsr *proto.RaidSimRequest, options Options) (*Env, error) {
+	rsr = googleProto.Clone(rsr).(*proto.RaidSimRequest)
+	if rsr.SimOptions == nil {
+		rsr.SimOptions = &proto.SimOptions{}
+	}
+	rsr.SimOptions.Interactive = true
+	rsr.SimOptions.Iterations = 1
+
+	// Spell casting expects every player to have a rotation, even if only its
+	// prepull actions are used.
+	for _, party := range rsr.GetRaid().GetParties() {
+		for _, player := range party.GetPlayers() {
+			if player != nil && player.Rotation == nil {
+				player.Rotation = &proto.APLRotation{}
+			}
+		}
+	}
+
+	if len(options.Players) == 0 {
+		options.Players = []int32{0}
+	}
+	if options.WaitDuration <= 0 {
+		options.WaitDuration = defaultWaitDuration
+	}
+
+	env := &Env{
+		options: options,
+		sim:     core.NewSim(rsr, simsignals.Signals{}),
+		done:    true,
+	}
+
+	for _, raidIndex := range options.Players {
+		character := env.findCharacter(raidIndex)
+		if character == nil {
+			return nil, fmt.Errorf("no player at raid index %d", raidIndex)
+		}
+		agent := &agent{
+			raidIndex:   raidIndex,
+			character:   character,
+			targetAuras: options.TargetAuras,
+		}
+		agent.eclipse, _ = character.Env.Raid.GetPlayerFromUnit(&character.Unit).(eclipseAgent)
+
+		for _, spell := range character.Spellbook {
+			if spell.Flags.Matches(core.SpellFlagAPL) {
+				agent.actions = append(agent.actions, spell)
+			}
+		}
+		for _, label := range options.SelfAuras {
+			aura := character.GetAura(label)
+			if aura == nil {
+				return nil, fmt.Errorf("%s has no aura with label %q", character.Label, label)
+			}
+			agent.selfAuras = append(agent.selfAuras, aura)
+		}
+		env.agents = append(env.agents, agent)
+	}
+
+	return env, nil
+}
+
+func (env *Env) findCharacter(raidIndex int32) *core.Character {
+	for _, party := range env.sim.Raid.Parties {
+		for _, player := range party.Players {
+			character := player.GetCharacter()
+			if int32(party.Index*5+character.PartyIndex) == raidIndex {
+				return character
+			}
+		}
+	}
+	return nil
+}
+
+// Sim returns the underlying simulation, e.g. for reading metrics.
+func (env *Env) Sim() *core.Simulation {
+	return env.sim
+}
+
+func (env *Env) NumAgents() int {
+	return len(env.agents)
+}
+
+// CurrentAgent returns the index into Options.Players of the player which
+// needs to act next, or -1 once the episode is done.
+func (env *Env) CurrentAgent() int {
+	if env.done {
+		return -1
+	}
+	return env.current
+}
+
+func (env *Env) Done() bool {
+	return env.done
+}
+
+// Reset starts a new episode with the given seed, and advances the sim until a
+// controlled player needs input.
+func (env *Env) Reset(seed int64) {
+	if !env.done {
+		env.sim.Cleanup()
+	}
+
+	env.sim.Reseed(seed)
+	env.sim.Reset()
+	env.sim.PrePull()
+	env.done = false
+
+	for _, agent := range env.agents {
+		agent.damageAtLastStep = 0
+	}
+	env.advance()
+}
+
+// Step performs an action for the current agent, then advances the sim until
+// the next decision. The reward is the damage done by the acting player and its
+// pets since its previous step.
+//
+// Actions which can not be cast, see ActionMask(), behave like ActionWait.
+func (env *Env) Step(action int) (float64, bool, error) {
+	if env.done {
+		return 0, true, errors.New("episode is done, call Reset() first")
+	}
+	agent := env.agents[env.current]
+	if action < 0 || action > len(agent.actions) {
+		return 0, false, fmt.Errorf("action %d out of range [0, %d]", action, len(agent.actions))
+	}
+
+	sim := env.sim
+	casted := false
+	if action != ActionWait {
+		spell := agent.actions[action-1]
+		target := agent.target(spell)
+		if spell.CanCast(sim, target) {
+			casted = spell.Cast(sim, target)
+		}
+		// Instant off-GCD casts leave the agent waiting for more input at the same timestamp.
+		if casted && spell.CurCast.EffectiveTime() == 0 && agent.character.GCD.IsReady(sim) {
+			return agent.collectReward(), false, nil
+		}
+	}
+	if !casted {
+		agent.character.WaitUntil(sim, sim.CurrentTime+env.options.WaitDuration)
+	}
+	sim.NeedsInput = false
+
+	env.advance()
+	return agent.collectReward(), env.done, nil
+}
+
+// Runs the sim until a controlled player needs input or the episode ends.
+// Uncontrolled players are told to wait.
+func (env *Env) advance() {
+	sim := env.sim
+	for {
+		for !sim.NeedsInput {
+			if sim.Step() {
+				sim.Cleanup()
+				env.done = true
+				return
+			}
+		}
+
+		env.current = -1
+		for i, agent := range env.agents {
+			if agent.character == sim.InputCharacter {
+				env.current = i
+				return
+			}
+		}
+
+		sim.InputCharacter.WaitUntil(sim, sim.CurrentTime+env.options.WaitDuration)
+		sim.NeedsInput = false
+	}
+}
+
+// NumActions returns the size of the action space for an agent, including
+// ActionWait.
+func (env *Env) NumActions(agentIndex int) int {
+	return len(env.agents[agentIndex].actions) + 1
+}
+
+// ActionIDs lists the spell behind each action of an agent. ActionWait maps to
+// an empty ActionID.
+func (env *Env) ActionIDs(agentIndex int) []core.ActionID {
+	actionIDs := []core.ActionID{{}}
+	for _, spell := range env.agents[agentIndex].actions {
+		actionIDs = append(actionIDs, spell.ActionID)
+	}
+	return actionIDs
+}
+
+// ActionMask reports which actions the current agent can take right now, using
+// Spell.CanCast.
+func (env *Env) ActionMask() []bool {
+	if env.done {
+		return nil
+	}
+	agent := env.agents[env.current]
+	mask := make([]bool, len(agent.actions)+1)
+	mask[ActionWait] = true
+	for i, spell := range agent.actions {
+		mask[i+1] = spell.CanCast(env.sim, agent.target(spell))
+	}
+	return mask
+}
+
+// DamageDone returns the total damage done by an agent and its pets so far
+// this episode.
+func (env *Env) DamageDone(agentIndex int) float64 {
+	return env.agents[agentIndex].damageDone()
+}
+
+func (agent *agent) target(spell *core.Spell) *core.Unit {
+	if spell.Flags.Matches(core.SpellFlagHelpful) {
+		return &agent.character.Unit
+	}
+	return agent.character.CurrentTarget
+}
+
+func (agent *agent) damageDone() float64 {
+	total := unitDamageDone(&agent.character.Unit)
+	for _, pet := range agent.character.Pets {
+		total += unitDamageDone(&pet.Unit)
+	}
+	return total
+}
+
+func unitDamageDone(unit *core.Unit) float64 {
+	total := 0.0
+	for _, spell := range unit.Spellbook {
+		for _, metrics := range spell.SpellMetrics {
+			total += metrics.TotalDamage
+		}
+	}
+	return total
+}
+
+func (agent *agent) collectReward() float64 {
+	damage := agent.damageDone()
+	reward := damage - agent.damageAtLastStep
+	agent.damageAtLastStep = damage
+	return reward
+}
@@ -0,0 +1,105 @@
+package gym
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func init() {
+	sim.RegisterAll()
+}
+
+var testRequest = &proto.RaidSimRequest{
+	Raid: core.SinglePlayerRaidProto(
+		&proto.Player{
+			Class:     proto.Class_ClassWarrior,
+			Race:      proto.Race_RaceOrc,
+			Equipment: &proto.EquipmentSpec{},
+			Spec: &proto.Player_ArmsWarrior{
+				ArmsWarrior: &proto.ArmsWarrior{
+					Options: &proto.ArmsWarrior_Options{
+						ClassOptions: &proto.WarriorOptions{},
+					},
+				},
+			},
+			TalentsString: "113332",
+		},
+		&proto.PartyBuffs{},
+		&proto.RaidBuffs{},
+		&proto.Debuffs{}),
+	Encounter: &proto.Encounter{
+		Duration: 30,
+		Targets: []*proto.Target{
+			{
+				Stats:   stats.Stats{stats.Armor: 24835}.ToProtoArray(),
+				MobType: proto.MobType_MobTypeMechanical,
+				Level:   93,
+			},
+		},
+	},
+	SimOptions: &proto.SimOptions{
+		IsTest: true,
+	},
+}
+
+// Plays an episode, always picking the first castable spell.
+func runGreedyEpisode(t *testing.T, env *Env, seed int64) float64 {
+	env.Reset(seed)
+	totalReward := 0.0
+	for !env.Done() {
+		if size := len(env.Observation()); size != env.ObservationSize(env.CurrentAgent()) {
+			t.Fatalf("Expected observation of size %d but found %d", env.ObservationSize(env.CurrentAgent()), size)
+		}
+
+		action := ActionWait
+		for i, castable := range env.ActionMask() {
+			if i != ActionWait && castable {
+				action = i
+				break
+			}
+		}
+
+		reward, _, err := env.Step(action)
+		if err != nil {
+			t.Fatal(err)
+		}
+		totalReward += reward
+	}
+	return totalReward
+}
+
+func TestEnvEpisode(t *testing.T) {
+	env, err := New(testRequest, Options{TargetAuras: []string{"Weakened Armor"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(env.ObservationLabels(0)) != env.ObservationSize(0) {
+		t.Fatalf("Expected %d observation labels but found %d", env.ObservationSize(0), len(env.ObservationLabels(0)))
+	}
+
+	totalReward := runGreedyEpisode(t, env, 1)
+	if totalReward <= 0 {
+		t.Fatalf("Expected positive reward, found %f", totalReward)
+	}
+	if totalReward != env.DamageDone(0) {
+		t.Fatalf("Rewards add up to %f but damage done was %f", totalReward, env.DamageDone(0))
+	}
+
+	if _, _, err := env.Step(ActionWait); err == nil {
+		t.Fatalf("Expected an error when stepping a finished episode")
+	}
+
+	if replay := runGreedyEpisode(t, env, 1); replay != totalReward {
+		t.Fatalf("Expected identical reward %f when replaying seed, found %f", totalReward, replay)
+	}
+}
+
+func TestEnvInvalidPlayer(t *testing.T) {
+	if _, err := New(testRequest, Options{Players: []int32{3}}); err == nil {
+		t.Fatalf("Expected an error for an empty raid slot")
+	}
+}
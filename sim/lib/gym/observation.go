@@ -0,0 +1,162 @@
+package gym
+
+import (
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+// Values which don't depend on the class, in observation order.
+var baseObservationLabels = []string{
+	"Remaining Duration",
+	"Remaining Duration Percent",
+	"GCD Remaining",
+	"Cast Remaining",
+	"Health Percent",
+	"Target Health Percent",
+	"Mana",
+	"Rage",
+	"Energy",
+	"Combo Points",
+	"Focus",
+	"Runic Power",
+	"Blood Runes",
+	"Frost Runes",
+	"Unholy Runes",
+	"Death Runes",
+	"Secondary Resource",
+	"Solar Energy",
+	"Lunar Energy",
+}
+
+// ObservationSize returns the length of the observation vector for an agent.
+// It stays the same for the lifetime of the environment.
+func (env *Env) ObservationSize(agentIndex int) int {
+	return env.agents[agentIndex].observationSize()
+}
+
+func (agent *agent) observationSize() int {
+	return len(baseObservationLabels) + 4*len(agent.actions) + 2*len(agent.selfAuras) + 2*len(agent.targetAuras)
+}
+
+// ObservationLabels describes each entry of an agent's observation vector.
+func (env *Env) ObservationLabels(agentIndex int) []string {
+	agent := env.agents[agentIndex]
+	labels := append([]string{}, baseObservationLabels...)
+	for _, spell := range agent.actions {
+		labels = append(labels,
+			fmt.Sprintf("%s Cooldown", spell.ActionID),
+			fmt.Sprintf("%s Charges", spell.ActionID),
+			fmt.Sprintf("%s Dot Remaining", spell.ActionID),
+			fmt.Sprintf("%s Buff Remaining", spell.ActionID))
+	}
+	for _, aura := range agent.selfAuras {
+		labels = append(labels, aura.Label+" Remaining", aura.Label+" Stacks")
+	}
+	for _, label := range agent.targetAuras {
+		labels = append(labels, "Target "+label+" Remaining", "Target "+label+" Stacks")
+	}
+	return labels
+}
+
+// Observation returns the observation vector of the current agent. Durations
+// are in seconds, and resources which the player doesn't use are 0.
+func (env *Env) Observation() []float64 {
+	if env.done {
+		return nil
+	}
+	return env.observe(env.agents[env.current])
+}
+
+func (env *Env) observe(agent *agent) []float64 {
+	sim := env.sim
+	character := agent.character
+	target := character.CurrentTarget
+	obs := make([]float64, 0, agent.observationSize())
+
+	obs = append(obs,
+		sim.GetRemainingDuration().Seconds(),
+		sim.GetRemainingDurationPercent(),
+		character.GCD.TimeToReady(sim).Seconds(),
+		max(0, character.Hardcast.Expires-sim.CurrentTime).Seconds(),
+		healthPercent(&character.Unit),
+		healthPercent(target),
+	)
+
+	var mana, rage, energy, comboPoints, focus, runicPower float64
+	var bloodRunes, frostRunes, unholyRunes, deathRunes, secondary, solar, lunar float64
+	if character.HasManaBar() {
+		mana = character.CurrentMana()
+	}
+	if character.HasRageBar() {
+		rage = character.CurrentRage()
+	}
+	if character.HasEnergyBar() {
+		energy = character.CurrentEnergy()
+		comboPoints = float64(character.ComboPoints())
+	}
+	if character.HasFocusBar() {
+		focus = character.CurrentFocus()
+	}
+	if character.HasRunicPowerBar() {
+		runicPower = character.CurrentRunicPower()
+		bloodRunes = float64(character.CurrentBloodRunes())
+		frostRunes = float64(character.CurrentFrostRunes())
+		unholyRunes = float64(character.CurrentUnholyRunes())
+		deathRunes = float64(character.CurrentDeathRunes())
+	}
+	if bar := character.GetSecondaryResourceBar(); bar != nil {
+		secondary = float64(bar.Value())
+	}
+	if agent.eclipse != nil {
+		solar = float64(agent.eclipse.CurrentSolarEnergy())
+		lunar = float64(agent.eclipse.CurrentLunarEnergy())
+	}
+	obs = append(obs, mana, rage, energy, comboPoints, focus, runicPower,
+		bloodRunes, frostRunes, unholyRunes, deathRunes, secondary, solar, lunar)
+
+	for _, spell := range agent.actions {
+		var dotRemaining, buffRemaining float64
+		if dot := spell.Dot(agent.target(spell)); dot != nil {
+			dotRemaining = env.auraRemaining(dot.Aura)
+		}
+		if spell.RelatedSelfBuff != nil {
+			buffRemaining = env.auraRemaining(spell.RelatedSelfBuff)
+		}
+		obs = append(obs,
+			spell.TimeToReady(sim).Seconds(),
+			float64(spell.GetNumCharges()),
+			dotRemaining,
+			buffRemaining)
+	}
+
+	for _, aura := range agent.selfAuras {
+		obs = append(obs, env.auraRemaining(aura), float64(aura.GetStacks()))
+	}
+	for _, label := range agent.targetAuras {
+		aura := target.GetAura(label)
+		if aura == nil {
+			obs = append(obs, 0, 0)
+			continue
+		}
+		obs = append(obs, env.auraRemaining(aura), float64(aura.GetStacks()))
+	}
+
+	return obs
+}
+
+// Remaining duration of an aura, or 0 if inactive. Auras which never expire
+// are capped to the remaining fight length.
+func (env *Env) auraRemaining(aura *core.Aura) float64 {
+	if aura == nil || !aura.IsActive() {
+		return 0
+	}
+	return min(aura.RemainingDuration(env.sim), env.sim.GetRemainingDuration()).Seconds()
+}
+
+func healthPercent(unit *core.Unit) float64 {
+	if unit == nil || !unit.HasHealthBar() || unit.MaxHealth() <= 0 {
+		return 0
+	}
+	return unit.CurrentHealthPercent()
+}
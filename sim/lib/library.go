@@ -6,7 +6,7 @@ import (
 	"bytes"
 	"compress/zlib"
 	"encoding/base64"
-	"encoding/json"
+	encodingjson "encoding/json"
 	"log"
 	"unsafe"
 
@@ -14,10 +14,13 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/lib/gym"
 	"google.golang.org/protobuf/encoding/protojson"
 	goproto "google.golang.org/protobuf/proto"
 )
 
+// State for the single-sim exports started by new. Those predate the env
+// exports and are kept for existing callers.
 var _default_rsr = proto.RaidSimRequest{
 	Raid:       &proto.Raid{},
 	Encounter:  &proto.Encounter{},
@@ -28,6 +31,8 @@ var _active_seed int64 = 1
 var _aura_labels = []string{}
 var _target_aura_labels = []string{}
 
+var _active_env *gym.Env
+
 //export runSim
 func runSim(json *C.char) *C.char {
 	input := &proto.RaidSimRequest{}
@@ -149,6 +154,8 @@ func getDatabase(itemIds *int32, numItems int32, enchantIds *int32, numEnchants
 	return C.CString(string(out))
 }
 
+// Deprecated: use newEnv, which drives the sim through an RL env.
+//
 //export new
 func new(json *C.char) {
 	input := &proto.RaidSimRequest{}
@@ -238,6 +245,7 @@ func getSpellCount() int {
 func getSpells(storage *int32, n int32) {
 	player := _active_sim.Raid.Parties[0].Players[0]
 	spellbook := player.GetCharacter().Spellbook
+	n = min(n, int32(len(spellbook)))
 	spells := unsafe.Slice(storage, n)
 	for i, spell := range spellbook[:n] {
 		if spell.Tag != -1 {
@@ -257,6 +265,10 @@ func getCooldowns(storage *float64, spellbookIndices *int32, n int32) {
 	cds := unsafe.Slice(storage, n)
 	for i := int32(0); i < n; i++ {
 		spellbookIndex := spells[i]
+		if spellbookIndex < 0 || int(spellbookIndex) >= len(spellbook) {
+			cds[i] = 0.0
+			continue
+		}
 		spell := spellbook[spellbookIndex]
 		cds[i] = spell.TimeToReady(_active_sim).Seconds()
 	}
@@ -284,7 +296,7 @@ func registerTargetAuras(strings **C.char) {
 func getAuras(storage *float64, n int32) {
 	player := _active_sim.Raid.Parties[0].Players[0]
 	auras := unsafe.Slice(storage, n)
-	for i, label := range _aura_labels {
+	for i, label := range _aura_labels[:min(int(n), len(_aura_labels))] {
 		aura := player.GetCharacter().GetAura(label)
 		if aura != nil {
 			auras[i] = aura.RemainingDuration(_active_sim).Seconds()
@@ -299,7 +311,7 @@ func getTargetAuras(storage *float64, n int32) {
 	player := _active_sim.Raid.Parties[0].Players[0]
 	target := player.GetCharacter().CurrentTarget
 	auras := unsafe.Slice(storage, n)
-	for i, label := range _target_aura_labels {
+	for i, label := range _target_aura_labels[:min(int(n), len(_target_aura_labels))] {
 		aura := target.GetAura(label)
 		if aura != nil {
 			auras[i] = aura.RemainingDuration(_active_sim).Seconds()
@@ -335,7 +347,7 @@ func getSpellMetrics() *C.char {
 			}
 		}
 	}
-	out, err := json.Marshal(all_metrics)
+	out, err := encodingjson.Marshal(all_metrics)
 	if err != nil {
 		panic(err)
 	}
@@ -357,6 +369,115 @@ func cleanup() {
 	_active_sim.Cleanup()
 }
 
+//export newEnv
+func newEnv(json *C.char, optionsJson *C.char) {
+	input := &proto.RaidSimRequest{}
+	jsonString := C.GoString(json)
+	err := protojson.Unmarshal([]byte(jsonString), input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+	options := gym.Options{}
+	if optionsString := C.GoString(optionsJson); optionsString != "" {
+		if err := encodingjson.Unmarshal([]byte(optionsString), &options); err != nil {
+			log.Fatalf("failed to parse env options: %s", err)
+		}
+	}
+	sim.RegisterAll()
+	_active_env, err = gym.New(input, options)
+	if err != nil {
+		log.Fatalf("failed to create env: %s", err)
+	}
+}
+
+//export reset
+func reset(seed int64) {
+	_active_env.Reset(seed)
+}
+
+//export envStep
+func envStep(action int32, reward *float64) bool {
+	stepReward, done, err := _active_env.Step(int(action))
+	if err != nil {
+		log.Fatalf("failed to step env: %s", err)
+	}
+	*reward = stepReward
+	return done
+}
+
+//export getNumAgents
+func getNumAgents() int32 {
+	return int32(_active_env.NumAgents())
+}
+
+// Returns -1 once the episode is done.
+//
+//export getCurrentAgent
+func getCurrentAgent() int32 {
+	return int32(_active_env.CurrentAgent())
+}
+
+//export getNumActions
+func getNumActions(agent int32) int32 {
+	return int32(_active_env.NumActions(int(agent)))
+}
+
+// Spell IDs behind each action, with 0 for the wait action.
+//
+//export getActionSpells
+func getActionSpells(agent int32, storage *int32, n int32) {
+	actionIDs := _active_env.ActionIDs(int(agent))
+	n = min(n, int32(len(actionIDs)))
+	spells := unsafe.Slice(storage, n)
+	for i, actionID := range actionIDs[:n] {
+		spells[i] = actionID.SpellID
+	}
+}
+
+//export getActionMask
+func getActionMask(storage *uint8, n int32) {
+	actionMask := _active_env.ActionMask()
+	n = min(n, int32(len(actionMask)))
+	mask := unsafe.Slice(storage, n)
+	for i, castable := range actionMask[:n] {
+		if castable {
+			mask[i] = 1
+		} else {
+			mask[i] = 0
+		}
+	}
+}
+
+//export getObservationSize
+func getObservationSize(agent int32) int32 {
+	return int32(_active_env.ObservationSize(int(agent)))
+}
+
+//export getObservationLabels
+func getObservationLabels(agent int32) *C.char {
+	out, err := encodingjson.Marshal(_active_env.ObservationLabels(int(agent)))
+	if err != nil {
+		panic(err)
+	}
+	return C.CString(string(out))
+}
+
+//export getObservation
+func getObservation(storage *float64, n int32) {
+	obs := unsafe.Slice(storage, n)
+	copy(obs, _active_env.Observation())
+}
+
+//export getEnvRemainingDuration
+func getEnvRemainingDuration() float64 {
+	return _active_env.Sim().GetRemainingDuration().Seconds()
+}
+
+//export getAgentDamageDone
+func getAgentDamageDone(agent int32) float64 {
+	return _active_env.DamageDone(int(agent))
+}
+
 //export FreeCString
 func FreeCString(s *C.char) {
 	C.free(unsafe.Pointer(s))
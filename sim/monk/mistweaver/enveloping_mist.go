@@ -0,0 +1,82 @@
+package mistweaver
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/monk"
+)
+
+/*
+Tooltip:
+Wraps the target in healing mists, healing for ${6*($m1+$SPH*0.45)} over 6 sec, and increasing healing received from your Soothing Mist by 30%.
+
+Instant when cast while channeling Soothing Mist.
+*/
+func (mw *MistweaverMonk) registerEnvelopingMist() {
+	actionID := core.ActionID{SpellID: 124682}
+	chiMetrics := mw.NewChiMetrics(actionID)
+	chiCost := int32(3)
+	baseTickHealing := mw.CalcScalingSpellDmg(0.6850000024)
+
+	mw.EnvelopingMist = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagCastWhileChanneling | monk.SpellFlagSpender | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellEnvelopingMist,
+		MaxRange:       40,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 2,
+			},
+			ModifyCast: mw.instantDuringSoothingMist,
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return mw.GetChi() >= chiCost
+		},
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Enveloping Mist" + mw.Label,
+			},
+			NumberOfTicks:    6,
+			TickLength:       time.Second,
+			BonusCoefficient: 0.4499999881,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, baseTickHealing)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			mw.SpendChi(sim, chiCost, chiMetrics)
+			spell.Hot(mw.channelOrSmartTarget(sim, target)).Apply(sim)
+		},
+	})
+}
+
+// Enveloping Mist and Surging Mist are instant while channeling Soothing Mist.
+func (mw *MistweaverMonk) instantDuringSoothingMist(_ *core.Simulation, _ *core.Spell, cast *core.Cast) {
+	if dot := mw.ChanneledDot; dot != nil && dot.Spell == mw.SoothingMist {
+		cast.CastTime = 0
+	}
+}
+
+// Spells cast during Soothing Mist land on the channel target.
+func (mw *MistweaverMonk) channelOrSmartTarget(sim *core.Simulation, target *core.Unit) *core.Unit {
+	if dot := mw.ChanneledDot; dot != nil && dot.Spell == mw.SoothingMist {
+		return mw.soothingMistTarget
+	}
+	return mw.smartHealTarget(sim, target)
+}
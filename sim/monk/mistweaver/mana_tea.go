@@ -0,0 +1,112 @@
+package mistweaver
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/monk"
+)
+
+/*
+Tooltip:
+Every 4 Chi you consume grants a charge of Mana Tea, stacking up to 20 times.
+
+Drink: Consumes up to 20 charges, restoring 4% of your maximum mana per charge consumed. Channeled, 0.5 sec per charge.
+
+-- Glyph of Mana Tea --
+
+	Mana Tea is instant and consumes up to 2 charges, but has a 10 sec cooldown.
+
+-- Glyph of Mana Tea --
+*/
+func (mw *MistweaverMonk) registerManaTea() {
+	stackActionID := core.ActionID{SpellID: 115867}
+	actionID := core.ActionID{SpellID: 115294}
+	manaMetrics := mw.NewManaMetrics(actionID)
+	hasGlyph := mw.HasMajorGlyph(proto.MonkMajorGlyph_GlyphOfManaTea)
+
+	mw.Monk.RegisterOnChiSpent(func(sim *core.Simulation, chiSpent int32) {
+		accumulatedChi := mw.outstandingChi + chiSpent
+
+		for accumulatedChi >= 4 {
+			mw.AddBrewStacks(sim, 1)
+			accumulatedChi -= 4
+		}
+
+		mw.outstandingChi = accumulatedChi
+	})
+
+	mw.ManaTeaStackAura = core.BlockPrepull(mw.RegisterAura(core.Aura{
+		Label:     "Mana Tea Stacks" + mw.Label,
+		ActionID:  stackActionID,
+		Duration:  time.Minute * 2,
+		MaxStacks: 20,
+	}))
+
+	mw.Monk.RegisterOnNewBrewStacks(func(sim *core.Simulation, stacksToAdd int32) {
+		mw.ManaTeaStackAura.Activate(sim)
+		mw.ManaTeaStackAura.SetStacks(sim, mw.ManaTeaStackAura.GetStacks()+stacksToAdd)
+	})
+
+	drinkStack := func(sim *core.Simulation) {
+		mw.AddMana(sim, mw.MaxMana()*0.04, manaMetrics)
+		mw.ManaTeaStackAura.RemoveStack(sim)
+	}
+
+	config := core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellManaTea,
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return mw.ManaTeaStackAura.GetStacks() > 0
+		},
+	}
+
+	if hasGlyph {
+		config.Cast = core.CastConfig{
+			DefaultCast: core.Cast{
+				NonEmpty: true,
+			},
+			CD: core.Cooldown{
+				Timer:    mw.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		}
+		config.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for range min(2, mw.ManaTeaStackAura.GetStacks()) {
+				drinkStack(sim)
+			}
+		}
+	} else {
+		config.Flags |= core.SpellFlagChanneled
+		config.Cast = core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		}
+		config.Hot = core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Mana Tea" + mw.Label,
+			},
+			NumberOfTicks: 20,
+			TickLength:    time.Millisecond * 500,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				if mw.ManaTeaStackAura.GetStacks() > 0 {
+					drinkStack(sim)
+				}
+			},
+		}
+		config.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			dot := spell.SelfHot()
+			dot.BaseTickCount = mw.ManaTeaStackAura.GetStacks()
+			dot.Apply(sim)
+			mw.ExtendGCDUntil(sim, dot.ExpiresAt()+mw.ReactionTime)
+		}
+	}
+
+	mw.RegisterSpell(config)
+}
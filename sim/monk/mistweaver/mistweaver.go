@@ -32,6 +32,9 @@ func NewMistweaverMonk(character *core.Character, options *proto.Player) *Mistwe
 	}
 	mw.EnableManaBar()
 
+	// Shared monk spells pick their mana costs from the stance at registration.
+	mw.Stance = monk.WiseSerpent
+
 	strAPDep := mw.NewDynamicStatDependency(stats.Strength, stats.AttackPower, 1)
 	agiAPDep := mw.NewDynamicStatDependency(stats.Agility, stats.AttackPower, 2)
 
@@ -50,6 +53,18 @@ func NewMistweaverMonk(character *core.Character, options *proto.Player) *Mistwe
 
 type MistweaverMonk struct {
 	*monk.Monk
+
+	SoothingMist     *core.Spell
+	RenewingMist     *core.Spell
+	EnvelopingMist   *core.Spell
+	SurgingMist      *core.Spell
+	GiftOfTheSerpent *core.Spell
+
+	ManaTeaStackAura    *core.Aura
+	ThunderFocusTeaAura *core.Aura
+
+	soothingMistTarget *core.Unit
+	outstandingChi     int32
 }
 
 func (mw *MistweaverMonk) GetMonk() *monk.Monk {
@@ -68,12 +83,83 @@ func (mw *MistweaverMonk) ApplyTalents() {
 }
 
 func (mw *MistweaverMonk) Reset(sim *core.Simulation) {
+	mw.outstandingChi = 0
+	// Mistweavers heal from Stance of the Wise Serpent, which fades with every iteration.
+	mw.Stance = monk.WiseSerpent
 	mw.Monk.Reset(sim)
 }
 
 func (mw *MistweaverMonk) RegisterSpecializationEffects() {
 	mw.RegisterMastery()
+
+	mw.registerSoothingMist()
+	mw.registerEnvelopingMist()
+	mw.registerSurgingMist()
+	mw.registerRenewingMist()
+	mw.registerUplift()
+	mw.registerManaTea()
+	mw.registerThunderFocusTea()
+}
+
+// Mistweaver heals default to the lowest health ally when cast on an enemy,
+// e.g. when the APL uses a plain Cast action.
+func (mw *MistweaverMonk) smartHealTarget(sim *core.Simulation, target *core.Unit) *core.Unit {
+	if target == nil || target.Type == core.EnemyUnit {
+		if lowest := sim.Raid.GetLowestHealthAllyUnit(); lowest != nil {
+			return lowest
+		}
+		return &mw.Unit
+	}
+	return target
 }
 
+func (mw *MistweaverMonk) getMasteryPercent() float64 {
+	return (8.0 + mw.GetMasteryPoints()) * 0.0125
+}
+
+/*
+Mastery: Gift of the Serpent
+Your healing spells have a chance to summon a Healing Sphere near a random injured ally, which heals for ${$m1+$AP*0.75} when picked up.
+
+The sphere is assumed to be picked up right away by the lowest health ally.
+*/
 func (mw *MistweaverMonk) RegisterMastery() {
+	actionID := core.ActionID{SpellID: 124041}
+	baseHealing := mw.CalcScalingSpellDmg(9.1219997406)
+
+	mw.GiftOfTheSerpent = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: monk.MonkSpellGiftOfTheSerpent,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			heal := baseHealing + spell.MeleeAttackPower()*0.75
+			spell.CalcAndDealHealing(sim, mw.smartHealTarget(sim, target), heal, spell.OutcomeHealingCrit)
+		},
+	})
+
+	procMastery := func(sim *core.Simulation, spell *core.Spell) {
+		if spell.Flags.Matches(core.SpellFlagPassiveSpell) {
+			return
+		}
+		if sim.Proc(mw.getMasteryPercent(), "Gift of the Serpent") {
+			mw.GiftOfTheSerpent.Cast(sim, mw.CurrentTarget)
+		}
+	}
+
+	core.MakePermanent(mw.RegisterAura(core.Aura{
+		Label: "Mastery: Gift of the Serpent" + mw.Label,
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			procMastery(sim, spell)
+		},
+		OnPeriodicHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			procMastery(sim, spell)
+		},
+	}))
 }
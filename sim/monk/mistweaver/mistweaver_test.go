@@ -0,0 +1,73 @@
+package mistweaver
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/common" // imported to get item effects included.
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func init() {
+	RegisterMistweaverMonk()
+	common.RegisterAllEffects()
+}
+
+func TestMistweaver(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:    proto.Class_ClassMonk,
+			Race:     proto.Race_RaceTroll,
+			IsHealer: true,
+
+			GearSet: core.GetGearSet("../../../ui/monk/mistweaver/gear_sets", "default"),
+			Talents: MistweaverTalents,
+			OtherTalentSets: []core.TalentsCombo{
+				{Label: "GlyphOfManaTea", Talents: MistweaverTalents, Glyphs: ManaTeaGlyphs},
+			},
+			Glyphs:      MistweaverDefaultGlyphs,
+			Consumables: FullConsumesSpec,
+			SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsMistweaver},
+			Rotation:    core.GetAplRotation("../../../ui/monk/mistweaver/apls", "default"),
+
+			ItemFilter: ItemFilter,
+		},
+	}))
+}
+
+var MistweaverTalents = "213322"
+
+var MistweaverDefaultGlyphs = &proto.Glyphs{
+	Minor1: int32(proto.MonkMinorGlyph_GlyphOfBlackoutKick),
+}
+
+var ManaTeaGlyphs = &proto.Glyphs{
+	Major1: int32(proto.MonkMajorGlyph_GlyphOfManaTea),
+	Minor1: int32(proto.MonkMinorGlyph_GlyphOfBlackoutKick),
+}
+
+var PlayerOptionsMistweaver = &proto.Player_MistweaverMonk{
+	MistweaverMonk: &proto.MistweaverMonk{
+		Options: &proto.MistweaverMonk_Options{
+			ClassOptions: &proto.MonkOptions{},
+		},
+	},
+}
+
+var FullConsumesSpec = &proto.ConsumesSpec{
+	FlaskId:  76085, // Flask of the Warm Sun
+	FoodId:   74650, // Mogu Fish Stew
+	PotId:    76093, // Potion of the Jade Serpent
+	PrepotId: 76093, // Potion of the Jade Serpent
+}
+
+var ItemFilter = core.ItemFilter{
+	ArmorType: proto.ArmorType_ArmorTypeLeather,
+
+	WeaponTypes: []proto.WeaponType{
+		proto.WeaponType_WeaponTypeMace,
+		proto.WeaponType_WeaponTypeSword,
+		proto.WeaponType_WeaponTypeStaff,
+		proto.WeaponType_WeaponTypeOffHand,
+	},
+}
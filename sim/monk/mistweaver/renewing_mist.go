@@ -0,0 +1,156 @@
+package mistweaver
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/monk"
+)
+
+/*
+Tooltip:
+Surrounds the target with healing mists, restoring ${9*($m1+$SPH*0.19)} health over 18 sec, and generates 1 Chi.
+
+When Renewing Mist heals, it jumps to up to 2 additional injured allies without Renewing Mist.
+*/
+func (mw *MistweaverMonk) registerRenewingMist() {
+	actionID := core.ActionID{SpellID: 115151}
+	chiMetrics := mw.NewChiMetrics(actionID)
+	baseTickHealing := mw.CalcScalingSpellDmg(0.5170000196)
+	numJumps := 2
+
+	mw.RenewingMist = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | monk.SpellFlagBuilder | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellRenewingMist,
+		MaxRange:       40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 5.85,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    mw.NewTimer(),
+				Duration: time.Second * 8,
+			},
+		},
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Renewing Mist" + mw.Label,
+			},
+			NumberOfTicks:       9,
+			TickLength:          time.Second * 2,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.1899999976,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, baseTickHealing)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = mw.smartHealTarget(sim, target)
+			spell.Hot(target).Apply(sim)
+
+			for jump := 0; jump < numJumps; jump++ {
+				jumpTarget := mw.lowestHealthAllyWithout(sim, spell)
+				if jumpTarget == nil {
+					break
+				}
+				spell.Hot(jumpTarget).Apply(sim)
+			}
+
+			mw.AddChi(sim, spell, 1, chiMetrics)
+		},
+	})
+}
+
+// Returns the lowest health ally which doesn't have the spell's HoT, or nil.
+func (mw *MistweaverMonk) lowestHealthAllyWithout(sim *core.Simulation, spell *core.Spell) *core.Unit {
+	var lowest *core.Unit
+	for _, unit := range sim.Raid.GetHealableAllyUnits() {
+		if spell.Hot(unit).IsActive() {
+			continue
+		}
+		if lowest == nil || unit.CurrentHealthPercent() < lowest.CurrentHealthPercent() {
+			lowest = unit
+		}
+	}
+	return lowest
+}
+
+/*
+Tooltip:
+Heals all targets with your Renewing Mist for ${$m1+$SPH*0.68}.
+*/
+func (mw *MistweaverMonk) registerUplift() {
+	actionID := core.ActionID{SpellID: 116670}
+	chiMetrics := mw.NewChiMetrics(actionID)
+	chiCost := int32(2)
+	baseHealing := mw.CalcScalingSpellDmg(3.5669999123)
+
+	mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | monk.SpellFlagSpender | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellUplift,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			if mw.GetChi() < chiCost {
+				return false
+			}
+			for _, unit := range sim.Raid.GetHealableAllyUnits() {
+				if mw.RenewingMist.Hot(unit).IsActive() {
+					return true
+				}
+			}
+			return false
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+		BonusCoefficient: 0.6800000072,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			mw.SpendChi(sim, chiCost, chiMetrics)
+
+			refreshRenewingMist := mw.ThunderFocusTeaAura.IsActive()
+			for _, unit := range sim.Raid.GetHealableAllyUnits() {
+				renewingMist := mw.RenewingMist.Hot(unit)
+				if !renewingMist.IsActive() {
+					continue
+				}
+				spell.CalcAndDealHealing(sim, unit, baseHealing, spell.OutcomeHealingCrit)
+				if refreshRenewingMist {
+					renewingMist.Apply(sim)
+				}
+			}
+
+			if refreshRenewingMist {
+				mw.ThunderFocusTeaAura.Deactivate(sim)
+			}
+		},
+	})
+}
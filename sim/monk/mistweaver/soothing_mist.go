@@ -0,0 +1,113 @@
+package mistweaver
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/monk"
+)
+
+/*
+Tooltip:
+Heals the target for ${8*($m1+$SPH*0.268)} over 8 sec. While channeling, Enveloping Mist and Surging Mist may be cast instantly on the target.
+
+Requires Stance of the Wise Serpent. Casting any other spell cancels the channel.
+*/
+func (mw *MistweaverMonk) registerSoothingMist() {
+	actionID := core.ActionID{SpellID: 115175}
+	manaMetrics := mw.NewManaMetrics(actionID)
+	baseTickHealing := mw.CalcScalingSpellDmg(0.8930000067)
+
+	mw.SoothingMist = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagChanneled | core.SpellFlagHelpful | core.SpellFlagCastWhileChanneling | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellSoothingMist,
+		MaxRange:       40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 1,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return mw.StanceMatches(monk.WiseSerpent)
+		},
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Soothing Mist" + mw.Label,
+			},
+			NumberOfTicks:       8,
+			TickLength:          time.Second,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.2679999769,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, baseTickHealing)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				cost := dot.Spell.Cost.GetCurrentCost()
+				if mw.CurrentMana() < cost {
+					mw.cancelChannel(sim, dot)
+					return
+				}
+				mw.SpendMana(sim, cost, manaMetrics)
+
+				// Snapshot again so Enveloping Mist applied mid-channel is picked up.
+				dot.SnapshotHeal(target, baseTickHealing)
+				if mw.EnvelopingMist.Hot(target).IsActive() {
+					dot.SnapshotAttackerMultiplier *= 1.3
+				}
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeTickHealingCrit)
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = mw.smartHealTarget(sim, target)
+			mw.soothingMistTarget = target
+			spell.Hot(target).Apply(sim)
+		},
+	})
+
+	// Any cast other than the ones usable during the channel ends it.
+	core.MakePermanent(mw.RegisterAura(core.Aura{
+		Label: "Soothing Mist Interrupt" + mw.Label,
+		OnApplyEffects: func(aura *core.Aura, sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			if spell == mw.SoothingMist || !spell.Flags.Matches(core.SpellFlagAPL) || spell.Flags.Matches(core.SpellFlagCastWhileChanneling) {
+				return
+			}
+			// Ended before the new spell applies, so a newly started channel isn't cleared.
+			if dot := mw.ChanneledDot; dot != nil && dot.Spell == mw.SoothingMist {
+				dot.Deactivate(sim)
+			}
+		},
+	}))
+}
+
+// Ends a channel from within a tick or cast callback.
+func (mw *MistweaverMonk) cancelChannel(sim *core.Simulation, dot *core.Dot) {
+	// Deactivating within OnTick causes a panic since tickAction gets set to nil in the default OnExpire
+	pa := sim.GetConsumedPendingActionFromPool()
+	pa.NextActionAt = sim.CurrentTime
+
+	pa.OnAction = func(sim *core.Simulation) {
+		if dot.IsActive() {
+			dot.Deactivate(sim)
+			if mw.GCD.IsReady(sim) {
+				mw.WaitUntil(sim, sim.CurrentTime+mw.ReactionTime)
+			}
+		}
+	}
+
+	sim.AddPendingAction(pa)
+}
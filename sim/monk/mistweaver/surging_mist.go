@@ -0,0 +1,51 @@
+package mistweaver
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/monk"
+)
+
+/*
+Tooltip:
+Heals the target for ${$m1+$SPH*1.8} and generates 1 Chi.
+
+Instant when cast while channeling Soothing Mist.
+*/
+func (mw *MistweaverMonk) registerSurgingMist() {
+	actionID := core.ActionID{SpellID: 116694}
+	chiMetrics := mw.NewChiMetrics(actionID)
+	baseHealing := mw.CalcScalingSpellDmg(17.8199996948)
+
+	mw.SurgingMist = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagCastWhileChanneling | monk.SpellFlagBuilder | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellSurgingMist,
+		MaxRange:       40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 8.8,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+			ModifyCast: mw.instantDuringSoothingMist,
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+		BonusCoefficient: 1.7999999523,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, mw.channelOrSmartTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+			mw.AddChi(sim, spell, 1, chiMetrics)
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package mistweaver
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/monk"
+)
+
+/*
+Tooltip:
+You receive a jolt of energy, empowering your next Surging Mist or Uplift within 30 sec.
+
+Surging Mist heals for 200% of its normal amount.
+Uplift also refreshes the duration of your Renewing Mist on all targets.
+*/
+func (mw *MistweaverMonk) registerThunderFocusTea() {
+	actionID := core.ActionID{SpellID: 116680}
+	chiMetrics := mw.NewChiMetrics(actionID)
+	chiCost := int32(1)
+
+	surgingMistMod := mw.AddDynamicMod(core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  monk.MonkSpellSurgingMist,
+		FloatValue: 1.0,
+	})
+
+	mw.ThunderFocusTeaAura = mw.RegisterAura(core.Aura{
+		Label:    "Thunder Focus Tea" + mw.Label,
+		ActionID: actionID,
+		Duration: time.Second * 30,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			surgingMistMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			surgingMistMod.Deactivate()
+		},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell == mw.SurgingMist {
+				aura.Deactivate(sim)
+			}
+		},
+	})
+
+	mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagNoOnCastComplete | monk.SpellFlagSpender | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellThunderFocusTea,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    mw.NewTimer(),
+				Duration: time.Second * 45,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return mw.GetChi() >= chiCost
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			mw.SpendChi(sim, chiCost, chiMetrics)
+			mw.ThunderFocusTeaAura.Activate(sim)
+		},
+
+		RelatedSelfBuff: mw.ThunderFocusTeaAura,
+	})
+}
@@ -90,7 +90,7 @@ func (monk *Monk) ChangeStance(sim *core.Simulation, newStance Stance) {
 	case SturdyOx:
 		monk.StanceOfTheSturdyOx.Cast(sim, &monk.Unit)
 	case WiseSerpent:
-		monk.StanceOfTheWiseSerpent.Cast(sim, &monk.Unit)
+		monk.StanceOfTheWiseSerpentAura.Activate(sim)
 	case FierceTiger:
 		if monk.Spec == proto.Spec_SpecWindwalkerMonk {
 			monk.StanceOfTheFierceTigerAura.Activate(sim)
@@ -353,6 +353,16 @@ const (
 	MonkSpellPurifyingBrew
 	MonkSpellGiftOfTheOx
 
+	// Mistweaver
+	MonkSpellSoothingMist
+	MonkSpellRenewingMist
+	MonkSpellUplift
+	MonkSpellEnvelopingMist
+	MonkSpellSurgingMist
+	MonkSpellManaTea
+	MonkSpellThunderFocusTea
+	MonkSpellGiftOfTheSerpent
+
 	MonkSpellLast
 	MonkSpellsAll = MonkSpellLast<<1 - 1
 )
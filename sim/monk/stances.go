@@ -110,7 +110,7 @@ func (monk *Monk) registerStanceOfTheWiseSerpent(stanceCD *core.Timer) {
 		ActionID:    actionID,
 		SpellSchool: core.SpellSchoolNature,
 		ProcMask:    core.ProcMaskSpellHealing,
-		Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
 
 		DamageMultiplier: 0.25,
 		ThreatMultiplier: 1,
@@ -121,19 +121,30 @@ func (monk *Monk) registerStanceOfTheWiseSerpent(stanceCD *core.Timer) {
 		},
 	})
 
+	procEminence := func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+		if !result.Landed() || result.Damage == 0 || spell.ProcMask.Matches(core.ProcMaskWhiteHit) {
+			return
+		}
+
+		target := sim.Raid.GetLowestHealthAllyUnit()
+		if target == nil {
+			target = &monk.Unit
+		}
+
+		dmgDone = result.Damage
+		eminenceHeal.Cast(sim, target)
+	}
+
 	// When the Monk deals non-autoattack damage, he/she will heal the lowest health nearby target within 20 yards equal to 25% of the damage done.
 	eminenceAura := monk.RegisterAura(core.Aura{
 		Label:    "Eminence" + monk.Label,
 		ActionID: core.ActionID{SpellID: 126890},
 		Duration: core.NeverExpires,
 		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if result == nil || !result.Landed() || result.Damage == 0 || spell.ProcMask.Matches(core.ProcMaskWhiteHit) {
-				return
-			}
-
-			dmgDone = result.Damage
-			// Should be a smart heal
-			eminenceHeal.Cast(sim, &monk.Unit)
+			procEminence(sim, spell, result)
+		},
+		OnPeriodicDamageDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			procEminence(sim, spell, result)
 		},
 	})
 
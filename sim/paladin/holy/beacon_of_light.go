@@ -0,0 +1,114 @@
+package holy
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+The target becomes a Beacon of Light to all other targets within a 60 yard radius.
+Your heals on other party or raid members will also heal the Beacon for 50% of the amount healed,
+or 100% if healed with Holy Light,
+or 15% if healed with Light of Dawn or Holy Radiance.
+Only one target can be the Beacon of Light at a time.
+
+-- Glyph of Beacon of Light --
+Your Beacon of Light no longer triggers the global cooldown.
+-- /Glyph of Beacon of Light --
+*/
+func (holy *HolyPaladin) registerBeaconOfLight() {
+	actionID := core.ActionID{SpellID: 53563}
+	hasGlyph := holy.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfBeaconOfLight)
+
+	var transferAmount float64
+	beaconHeal := holy.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 53652},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagIgnoreModifiers | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, transferAmount, spell.OutcomeHealing)
+		},
+	})
+
+	transferRate := func(spell *core.Spell) float64 {
+		switch {
+		case spell.Matches(paladin.SpellMaskHolyLight):
+			return 1
+		case spell.Matches(paladin.SpellMaskLightOfDawn | paladin.SpellMaskHolyRadiance):
+			return 0.15
+		default:
+			return 0.5
+		}
+	}
+
+	transfer := func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+		if result.Damage <= 0 || spell == beaconHeal || spell == holy.IlluminatedHealing {
+			return
+		}
+		beacon := holy.beaconTarget
+		if beacon == nil || result.Target == beacon || !holy.BeaconOfLightAuras.Get(beacon).IsActive() {
+			return
+		}
+
+		transferAmount = result.Damage * transferRate(spell)
+		beaconHeal.Cast(sim, beacon)
+	}
+
+	core.MakePermanent(holy.RegisterAura(core.Aura{
+		Label: "Beacon of Light Transfer" + holy.Label,
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			transfer(sim, spell, result)
+		},
+		OnPeriodicHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			transfer(sim, spell, result)
+		},
+	}))
+
+	holy.BeaconOfLightAuras = holy.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:    "Beacon of Light" + holy.Label,
+			ActionID: actionID,
+			Duration: core.NeverExpires,
+		})
+	})
+
+	holy.BeaconOfLight = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: paladin.SpellMaskBeaconOfLight,
+
+		MaxRange: 60,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 6,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.TernaryDuration(hasGlyph, 0, core.GCDDefault),
+				NonEmpty: hasGlyph,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if target.IsOpponent(&holy.Unit) {
+				target = &holy.Unit
+			}
+
+			if holy.beaconTarget != nil && holy.beaconTarget != target {
+				holy.BeaconOfLightAuras.Get(holy.beaconTarget).Deactivate(sim)
+			}
+			holy.beaconTarget = target
+			holy.BeaconOfLightAuras.Get(target).Activate(sim)
+		},
+
+		RelatedAuraArrays: holy.BeaconOfLightAuras.ToMap(),
+	})
+}
@@ -0,0 +1,45 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+A large heal that heals a friendly target for (<12983-14503> + 1.49 * <SP>).
+*/
+func (holy *HolyPaladin) registerDivineLight() {
+	holy.DivineLight = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 82326},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: paladin.SpellMaskDivineLight,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 36,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 1.49000000954,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := holy.CalcAndRollDamageRange(sim, 13.89999961853, 0.10800000280)
+			spell.CalcAndDealHealing(sim, holy.healTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+}
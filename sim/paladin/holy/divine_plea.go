@@ -0,0 +1,57 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+You gain 12% of your total mana over 9 sec,
+but the amount healed by your Flash of Light, Divine Light and Holy Light spells is reduced by 50%.
+*/
+func (holy *HolyPaladin) registerDivinePlea() {
+	actionID := core.ActionID{SpellID: 54428}
+	manaMetrics := holy.NewManaMetrics(actionID)
+
+	holy.DivinePlea = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: paladin.SpellMaskDivinePlea,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Minute * 2,
+			},
+		},
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Divine Plea" + holy.Label,
+			},
+			NumberOfTicks: 3,
+			TickLength:    time.Second * 3,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				holy.AddMana(sim, holy.MaxMana()*0.04, manaMetrics)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	})
+
+	holy.DivinePlea.SelfHot().Aura.AttachSpellMod(core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  paladin.SpellMaskFlashOfLight | paladin.SpellMaskDivineLight | paladin.SpellMaskHolyLight,
+		FloatValue: -0.5,
+	})
+}
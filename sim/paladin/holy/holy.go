@@ -36,6 +36,21 @@ func NewHolyPaladin(character *core.Character, options *proto.Player) *HolyPalad
 
 type HolyPaladin struct {
 	*paladin.Paladin
+
+	BeaconOfLight      *core.Spell
+	DivineLight        *core.Spell
+	DivinePlea         *core.Spell
+	HolyLight          *core.Spell
+	HolyRadiance       *core.Spell
+	HolyShockHeal      *core.Spell
+	HolyShockDamage    *core.Spell
+	IlluminatedHealing *core.Spell
+	LightOfDawn        *core.Spell
+
+	BeaconOfLightAuras      core.AuraArray
+	IlluminatedHealingAuras core.DamageAbsorptionAuraArray
+
+	beaconTarget *core.Unit
 }
 
 func (holy *HolyPaladin) GetPaladin() *paladin.Paladin {
@@ -47,12 +62,52 @@ func (holy *HolyPaladin) ApplyTalents() {
 	holy.ApplyArmorSpecializationEffect(stats.Intellect, proto.ArmorType_ArmorTypePlate, 86525)
 }
 
+func (holy *HolyPaladin) GetMainTarget() *core.Unit {
+	target := holy.Env.Raid.GetFirstTargetDummy()
+	if target == nil {
+		return &holy.Unit
+	} else {
+		return &target.Unit
+	}
+}
+
 func (holy *HolyPaladin) Initialize() {
+	holy.CurrentTarget = holy.GetMainTarget()
 	holy.Paladin.Initialize()
 
+	holy.registerMastery()
+
+	holy.registerBeaconOfLight()
+	holy.registerDivineLight()
+	holy.registerDivinePlea()
+	holy.registerHolyLight()
+	holy.registerHolyRadiance()
+	holy.registerHolyShock()
+	holy.registerInfusionOfLight()
+	holy.registerLightOfDawn()
+
 	holy.registerHotfixPassive()
 }
 
 func (holy *HolyPaladin) Reset(sim *core.Simulation) {
+	holy.beaconTarget = nil
 	holy.Paladin.Reset(sim)
 }
+
+// Heals cast on an enemy, e.g. from a plain Cast APL action, land on the
+// lowest health ally instead.
+func (holy *HolyPaladin) healTarget(sim *core.Simulation, target *core.Unit) *core.Unit {
+	if target != nil && !target.IsOpponent(&holy.Unit) {
+		return target
+	}
+	if lowest := sim.Raid.GetLowestHealthAllyUnit(); lowest != nil {
+		return lowest
+	}
+	return &holy.Unit
+}
+
+// Returns up to maxTargets allies for a group heal, starting with the primary
+// target and followed by the lowest health allies.
+func (holy *HolyPaladin) smartHealTargets(sim *core.Simulation, primary *core.Unit, maxTargets int) []*core.Unit {
+	return sim.Raid.GetSmartHealTargets(holy.healTarget(sim, primary), maxTargets)
+}
@@ -0,0 +1,45 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+Heals a friendly target for (<7102-7934> + 0.785 * <SP>).
+*/
+func (holy *HolyPaladin) registerHolyLight() {
+	holy.HolyLight = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 635},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: paladin.SpellMaskHolyLight,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 12.6,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 0.78500002623,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := holy.CalcAndRollDamageRange(sim, 7.60500001907, 0.10800000280)
+			spell.CalcAndDealHealing(sim, holy.healTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+}
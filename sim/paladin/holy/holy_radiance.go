@@ -0,0 +1,65 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+Imbues a friendly target with radiant energy, healing that target for (<5098-6230> + 0.675 * <SP>)
+and all allies within 10 yards for 50% of that amount.
+Grants a charge of Holy Power.
+*/
+func (holy *HolyPaladin) registerHolyRadiance() {
+	hpActionID := core.ActionID{SpellID: 88852}
+	holy.CanTriggerHolyAvengerHpGain(hpActionID)
+
+	// Allies within 10 yards of the primary target.
+	numSplashTargets := 5
+
+	holy.HolyRadiance = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 82327},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: paladin.SpellMaskHolyRadiance,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 36,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 0.67500001192,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			targets := holy.smartHealTargets(sim, target, numSplashTargets+1)
+
+			for idx, unit := range targets {
+				baseHealing := holy.CalcAndRollDamageRange(sim, 5.09800004959, 0.20000000298)
+				if idx > 0 {
+					spell.DamageMultiplier *= 0.5
+				}
+				spell.CalcAndDealHealing(sim, unit, baseHealing, spell.OutcomeHealingCrit)
+				if idx > 0 {
+					spell.DamageMultiplier /= 0.5
+				}
+			}
+
+			holy.HolyPower.Gain(sim, 1, hpActionID)
+		},
+	})
+}
@@ -0,0 +1,95 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+Blasts the target with Holy energy, causing (<1296-1454> + 1.36 * <SP>) Holy damage to an enemy,
+or (<8324-9011> + 0.833 * <SP>) healing to an ally, and grants a charge of Holy Power.
+Holy Shock has an additional 25% chance to be a critical strike.
+*/
+func (holy *HolyPaladin) registerHolyShock() {
+	actionID := core.ActionID{SpellID: 20473}
+	hpActionID := core.ActionID{SpellID: 148976}
+	holy.CanTriggerHolyAvengerHpGain(hpActionID)
+
+	cooldown := core.Cooldown{
+		Timer:    holy.NewTimer(),
+		Duration: time.Second * 6,
+	}
+
+	holy.HolyShockHeal = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: paladin.SpellMaskHolyShockHeal,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 8,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: cooldown,
+		},
+
+		BonusCritPercent: 25,
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 0.83300000429,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := holy.CalcAndRollDamageRange(sim, 8.34300041199, 0.07999999821)
+			spell.CalcAndDealHealing(sim, holy.healTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+			holy.HolyPower.Gain(sim, 1, hpActionID)
+		},
+	})
+
+	holy.HolyShockDamage = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID.WithTag(1),
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: paladin.SpellMaskHolyShockDamage,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 8,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: cooldown,
+		},
+
+		BonusCritPercent: 25,
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 1.36000001431,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := holy.CalcAndRollDamageRange(sim, 1.29999995232, 0.11500000209)
+			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
+
+			if result.Landed() {
+				holy.HolyPower.Gain(sim, 1, hpActionID)
+			}
+		},
+	})
+}
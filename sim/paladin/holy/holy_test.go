@@ -0,0 +1,77 @@
+package holy
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/common" // imported to get item effects included.
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func init() {
+	RegisterHolyPaladin()
+	common.RegisterAllEffects()
+}
+
+func TestHoly(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassPaladin,
+			Race:       proto.Race_RaceBloodElf,
+			OtherRaces: []proto.Race{proto.Race_RaceHuman},
+			IsHealer:   true,
+
+			GearSet: core.GetGearSet("../../../ui/paladin/retribution/gear_sets", "preraid"),
+			Talents: StandardTalents,
+			OtherTalentSets: []core.TalentsCombo{
+				{Label: "GlyphOfLightOfDawn", Talents: StandardTalents, Glyphs: LightOfDawnGlyphs},
+			},
+			Glyphs:      StandardGlyphs,
+			Consumables: FullConsumesSpec,
+			SpecOptions: core.SpecOptionsCombo{Label: "Seal of Insight", SpecOptions: SealOfInsight},
+			Rotation:    core.GetAplRotation("../../../ui/paladin/holy/apls", "default"),
+
+			ItemFilter: core.ItemFilter{
+				WeaponTypes: []proto.WeaponType{
+					proto.WeaponType_WeaponTypeSword,
+					proto.WeaponType_WeaponTypeMace,
+					proto.WeaponType_WeaponTypeShield,
+					proto.WeaponType_WeaponTypeOffHand,
+				},
+				ArmorType:         proto.ArmorType_ArmorTypePlate,
+				RangedWeaponTypes: []proto.RangedWeaponType{},
+			},
+		},
+	}))
+}
+
+var StandardTalents = "312231"
+
+var StandardGlyphs = &proto.Glyphs{
+	Major1: int32(proto.PaladinMajorGlyph_GlyphOfDivinity),
+	Major2: int32(proto.PaladinMajorGlyph_GlyphOfBeaconOfLight),
+	Major3: int32(proto.PaladinMajorGlyph_GlyphOfIllumination),
+}
+
+var LightOfDawnGlyphs = &proto.Glyphs{
+	Major1: int32(proto.PaladinMajorGlyph_GlyphOfDivinity),
+	Major2: int32(proto.PaladinMajorGlyph_GlyphOfBeaconOfLight),
+	Major3: int32(proto.PaladinMajorGlyph_GlyphOfLightOfDawn),
+}
+
+var FullConsumesSpec = &proto.ConsumesSpec{
+	FlaskId:  76085, // Flask of the Warm Sun
+	FoodId:   74650, // Mogu Fish Stew
+	PotId:    76093, // Potion of the Jade Serpent
+	PrepotId: 76093, // Potion of the Jade Serpent
+}
+
+var SealOfInsight = &proto.Player_HolyPaladin{
+	HolyPaladin: &proto.HolyPaladin{
+		Options: &proto.HolyPaladin_Options{
+			ClassOptions: &proto.PaladinOptions{
+				Seal: proto.PaladinSeal_Insight,
+			},
+		},
+	},
+}
@@ -0,0 +1,64 @@
+package holy
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+Consumes up to 3 Holy Power to unleash a wave of healing energy before you,
+healing up to 6 injured allies within 30 yards for (<1627-1812> + 0.152 * <SP>) per charge of Holy Power.
+
+-- Glyph of Light of Dawn --
+Light of Dawn affects 2 fewer targets, but heals each target for 25% more.
+-- /Glyph of Light of Dawn --
+*/
+func (holy *HolyPaladin) registerLightOfDawn() {
+	actionID := core.ActionID{SpellID: 85222}
+	hasGlyph := holy.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfLightOfDawn)
+	numTargets := core.TernaryInt(hasGlyph, 4, 6)
+
+	holy.LightOfDawn = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: paladin.SpellMaskLightOfDawn,
+		MetricSplits:   4,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			ModifyCast: func(sim *core.Simulation, spell *core.Spell, cast *core.Cast) {
+				holy.DynamicHolyPowerSpent = holy.SpendableHolyPower()
+				spell.SetMetricsSplit(holy.DynamicHolyPowerSpent)
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return holy.HolyPower.CanSpend(1)
+		},
+
+		DamageMultiplier: core.TernaryFloat64(hasGlyph, 1.25, 1),
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 0.15199999511,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			damageMultiplier := spell.DamageMultiplier
+			spell.DamageMultiplier *= float64(holy.DynamicHolyPowerSpent)
+
+			for _, unit := range holy.smartHealTargets(sim, target, numTargets) {
+				baseHealing := holy.CalcAndRollDamageRange(sim, 1.50999999046, 0.10700000077)
+				spell.CalcAndDealHealing(sim, unit, baseHealing, spell.OutcomeHealingCrit)
+			}
+
+			spell.DamageMultiplier = damageMultiplier
+
+			holy.HolyPower.SpendUpTo(sim, holy.DynamicHolyPowerSpent, actionID)
+		},
+	})
+}
@@ -0,0 +1,50 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+// Your direct healing spells also place an absorb shield on your target for ((8 + <Mastery Rating> / 600) * 1.5)% of the amount healed, lasting 15 sec.
+// The total absorb on a target cannot exceed 1/3 of your maximum health.
+func (holy *HolyPaladin) registerMastery() {
+	holy.IlluminatedHealing = holy.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 86273},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagIgnoreModifiers | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+
+	holy.IlluminatedHealingAuras = holy.NewAllyDamageAbsorptionAuraArray(func(target *core.Unit) *core.DamageAbsorptionAura {
+		return target.NewDamageAbsorptionAura(core.AbsorptionAuraConfig{
+			Aura: core.Aura{
+				Label:    "Illuminated Healing" + holy.Label,
+				ActionID: holy.IlluminatedHealing.ActionID,
+				Duration: time.Second * 15,
+			},
+		})
+	})
+
+	core.MakePermanent(holy.RegisterAura(core.Aura{
+		Label:    "Mastery: Illuminated Healing" + holy.Label,
+		ActionID: core.ActionID{SpellID: 76669},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if result.Damage <= 0 || spell == holy.IlluminatedHealing || spell.Flags.Matches(core.SpellFlagPassiveSpell) {
+				return
+			}
+
+			shield := holy.IlluminatedHealingAuras.Get(result.Target)
+			if added := shield.AddShieldStrength(sim, result.Damage*holy.getMasteryPercent(), holy.MaxHealth()/3); added > 0 {
+				holy.IlluminatedHealing.RecordShielding(sim, result.Target, added)
+			}
+		},
+	}))
+}
+
+func (holy *HolyPaladin) getMasteryPercent() float64 {
+	return ((8.0 + holy.GetMasteryPoints()) * 1.5) / 100.0
+}
@@ -1,72 +0,0 @@
-package priest
-
-import (
-	"time"
-
-	"github.com/wowsims/mop/sim/core"
-	"github.com/wowsims/mop/sim/core/proto"
-)
-
-func (priest *Priest) RegisterHolyFireSpell() {
-	hasGlyph := priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfSmite)
-
-	priest.HolyFire = priest.RegisterSpell(core.SpellConfig{
-		ActionID:    core.ActionID{SpellID: 48135},
-		SpellSchool: core.SpellSchoolHoly,
-		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       core.SpellFlagAPL,
-
-		ManaCost: core.ManaCostOptions{
-			BaseCost: 0.11,
-		},
-		Cast: core.CastConfig{
-			DefaultCast: core.Cast{
-				GCD:      core.GCDDefault,
-				CastTime: time.Millisecond*2000 - time.Millisecond*100*time.Duration(priest.Talents.DivineFury),
-			},
-			CD: core.Cooldown{
-				Timer:    priest.NewTimer(),
-				Duration: time.Second * 10,
-			},
-		},
-
-		BonusCritRating:  float64(priest.Talents.HolySpecialization) * 1 * core.CritRatingPerCritChance,
-		DamageMultiplier: 1 + 0.05*float64(priest.Talents.SearingLight),
-		CritMultiplier:   priest.DefaultCritMultiplier(),
-		ThreatMultiplier: 1 - []float64{0, .07, .14, .20}[priest.Talents.SilentResolve],
-
-		Dot: core.DotConfig{
-			Aura: core.Aura{
-				Label: "HolyFire",
-				OnGain: func(aura *core.Aura, sim *core.Simulation) {
-					if hasGlyph {
-						priest.Smite.DamageMultiplier *= 1.2
-					}
-				},
-				OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-					if hasGlyph {
-						priest.Smite.DamageMultiplier /= 1.2
-					}
-				},
-			},
-			NumberOfTicks: 7,
-			TickLength:    time.Second * 1,
-			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
-				dot.SnapshotBaseDamage = 50 + 0.024*dot.Spell.SpellPower()
-				dot.SnapshotAttackerMultiplier = dot.Spell.AttackerDamageMultiplier(dot.Spell.Unit.AttackTables[target.UnitIndex])
-			},
-			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeTick)
-			},
-		},
-
-		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			baseDamage := sim.Roll(900, 1140) + 0.5711*spell.SpellPower()
-			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
-			if result.Landed() {
-				spell.Dot(target).Apply(sim)
-			}
-			spell.DealDamage(sim, result)
-		},
-	})
-}
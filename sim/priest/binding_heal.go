@@ -0,0 +1,46 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const bindingHealScale = 8.8009996414
+const bindingHealVariance = 0.25
+const bindingHealCoeff = 0.8990000486
+
+// Heals the target and the priest.
+func (priest *Priest) registerBindingHealSpell() {
+	priest.BindingHeal = priest.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 32546},
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask:   PriestSpellBindingHeal,
+		BonusCoefficient: bindingHealCoeff,
+		MaxRange:         40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 2.8,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = priest.HealTarget(sim, target)
+			priest.calcAndDealDirectHealing(sim, spell, target, priest.CalcAndRollDamageRange(sim, bindingHealScale, bindingHealVariance))
+			if target != &priest.Unit {
+				priest.calcAndDealDirectHealing(sim, spell, &priest.Unit, priest.CalcAndRollDamageRange(sim, bindingHealScale, bindingHealVariance))
+			}
+		},
+	})
+}
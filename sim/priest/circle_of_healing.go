@@ -0,0 +1,50 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const cohScale = 4.6999998093
+const cohVariance = 0.1000000015
+const cohCoeff = 0.4670000076
+
+func (priest *Priest) RegisterCircleOfHealingSpell() {
+	maxTargets := 5 + core.TernaryInt(priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfCircleOfHealing), 1, 0)
+
+	priest.CircleOfHealing = priest.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 34861},
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask:   PriestSpellCircleOfHealing,
+		BonusCoefficient: cohCoeff,
+		MaxRange:         40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 3.2,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			for _, healTarget := range priest.GroupHealTargets(sim, target, maxTargets) {
+				baseHealing := priest.CalcAndRollDamageRange(sim, cohScale, cohVariance)
+				spell.CalcAndDealHealing(sim, healTarget, baseHealing, spell.OutcomeHealingCrit)
+			}
+		},
+	})
+}
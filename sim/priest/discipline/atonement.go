@@ -0,0 +1,40 @@
+package discipline
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+// Smite, Holy Fire and Penance heal the lowest health ally for 90% of the
+// damage dealt, or 50% if that ally is the priest.
+func (discPriest *DisciplinePriest) registerAtonement() {
+	atonement := discPriest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 81751},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreAttackerModifiers,
+		ClassSpellMask: priest.PriestSpellAtonement,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   discPriest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+	})
+
+	core.MakeProcTriggerAura(&discPriest.Unit, core.ProcTrigger{
+		Name:           "Atonement",
+		ActionID:       core.ActionID{SpellID: 81749},
+		Callback:       core.CallbackOnSpellHitDealt | core.CallbackOnPeriodicDamageDealt,
+		ClassSpellMask: priest.PriestSpellSmite | priest.PriestSpellHolyFire | priest.PriestSpellPenance,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Outcome:        core.OutcomeLanded,
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if result.Damage <= 0 {
+				return
+			}
+
+			target := discPriest.HealTarget(sim, nil)
+			healing := result.Damage * core.TernaryFloat64(target == &discPriest.Unit, 0.5, 0.9)
+			atonement.CalcAndDealHealing(sim, target, healing, atonement.OutcomeHealingCrit)
+		},
+	})
+}
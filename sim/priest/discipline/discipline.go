@@ -1,6 +1,9 @@
 package discipline
 
 import (
+	"math"
+	"time"
+
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/priest"
@@ -27,6 +30,12 @@ type DisciplinePriest struct {
 	*priest.Priest
 
 	Options *proto.DisciplinePriest_Options
+
+	DivineAegis *core.Spell
+	SpiritShell *core.Spell
+
+	DivineAegisAuras core.DamageAbsorptionAuraArray
+	SpiritShellAuras core.DamageAbsorptionAuraArray
 }
 
 func newDisciplinePriest(character *core.Character, options *proto.Player) *DisciplinePriest {
@@ -68,15 +77,48 @@ func (discPriest *DisciplinePriest) GetMainTarget() *core.Unit {
 func (discPriest *DisciplinePriest) Initialize() {
 	discPriest.CurrentTarget = discPriest.GetMainTarget()
 	discPriest.Priest.Initialize()
-	// discPriest.Priest.RegisterHealingSpells()
+	discPriest.Priest.RegisterHealingSpells()
+	discPriest.RegisterPenanceSpell()
 
-	// // discPriest.ApplyRapture(discPriest.Options.RapturesPerMinute)
-	// discPriest.RegisterHymnOfHopeCD()
+	discPriest.registerAtonement()
+	discPriest.registerDivineAegis()
+	discPriest.registerGrace()
+	discPriest.registerSpiritShell()
+	discPriest.registerShieldDiscipline() // Mastery
 }
 
-func (holyPriest *DisciplinePriest) ApplyTalents() {
+func (discPriest *DisciplinePriest) ApplyTalents() {
+	discPriest.Priest.ApplyTalents()
 }
 
 func (discPriest *DisciplinePriest) Reset(sim *core.Simulation) {
-	//discPriest.Priest.Reset(sim)
+	discPriest.Priest.Reset(sim)
+}
+
+// Adds to a stacking absorb, capped at a percentage of the target's max health.
+// Shields are not affected by healing pseudostats, so only the spell multiplier applies.
+func applyCappedShield(sim *core.Simulation, spell *core.Spell, shield *core.DamageAbsorptionAura, amount float64, maxHealthPercent float64) {
+	target := shield.Unit
+	maxStrength := math.MaxFloat64
+	if target.HasHealthBar() {
+		maxStrength = target.MaxHealth() * maxHealthPercent
+	}
+
+	if added := shield.AddShieldStrength(sim, amount*spell.DamageMultiplier, maxStrength); added > 0 {
+		spell.RecordShielding(sim, target, added)
+	}
+}
+
+// Creates a stacking absorb on every ally. Its strength is only ever set
+// through applyCappedShield.
+func (discPriest *DisciplinePriest) newCappedShieldAuras(spell *core.Spell, label string) core.DamageAbsorptionAuraArray {
+	return discPriest.NewAllyDamageAbsorptionAuraArray(func(target *core.Unit) *core.DamageAbsorptionAura {
+		return target.NewDamageAbsorptionAura(core.AbsorptionAuraConfig{
+			Aura: core.Aura{
+				Label:    label + discPriest.Label,
+				ActionID: spell.ActionID,
+				Duration: time.Second * 15,
+			},
+		})
+	})
 }
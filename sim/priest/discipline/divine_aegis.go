@@ -0,0 +1,38 @@
+package discipline
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+// Critical heals and all heals from Prayer of Healing create a shield on the
+// target absorbing 50% of the amount healed, stacking up to 40% of its
+// maximum health.
+func (discPriest *DisciplinePriest) registerDivineAegis() {
+	discPriest.DivineAegis = discPriest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 47753},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: priest.PriestSpellDivineAegis,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+	discPriest.DivineAegisAuras = discPriest.newCappedShieldAuras(discPriest.DivineAegis, "Divine Aegis")
+
+	core.MakePermanent(discPriest.RegisterAura(core.Aura{
+		Label:    "Divine Aegis Passive",
+		ActionID: core.ActionID{SpellID: 47515},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if result.Damage <= 0 || spell == discPriest.DivineAegis {
+				return
+			}
+			if !result.DidCrit() && !spell.Matches(priest.PriestSpellPrayerOfHealing) {
+				return
+			}
+
+			applyCappedShield(sim, discPriest.DivineAegis, discPriest.DivineAegisAuras.Get(result.Target), result.Damage*0.5, 0.4)
+		},
+	}))
+}
@@ -0,0 +1,50 @@
+package discipline
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+// Flash Heal, Greater Heal, Heal and Penance increase the healing the target
+// receives from the priest by 10%, stacking 3 times.
+func (discPriest *DisciplinePriest) registerGrace() {
+	graceAuras := discPriest.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:     "Grace-" + strconv.Itoa(int(discPriest.Index)),
+			ActionID:  core.ActionID{SpellID: 77613},
+			Duration:  time.Second * 15,
+			MaxStacks: 3,
+			OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks, newStacks int32) {
+				attackTable := discPriest.AttackTables[aura.Unit.UnitIndex]
+				attackTable.HealingDealtMultiplier /= 1 + 0.1*float64(oldStacks)
+				attackTable.HealingDealtMultiplier *= 1 + 0.1*float64(newStacks)
+			},
+		})
+	})
+
+	graceSpells := priest.PriestSpellFlashHeal | priest.PriestSpellGreaterHeal | priest.PriestSpellHeal
+
+	core.MakePermanent(discPriest.RegisterAura(core.Aura{
+		Label:    "Grace Passive",
+		ActionID: core.ActionID{SpellID: 47517},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !spell.Matches(graceSpells) {
+				return
+			}
+			grace := graceAuras.Get(result.Target)
+			grace.Activate(sim)
+			grace.AddStack(sim)
+		},
+		OnPeriodicHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell != discPriest.PenanceHeal {
+				return
+			}
+			grace := graceAuras.Get(result.Target)
+			grace.Activate(sim)
+			grace.AddStack(sim)
+		},
+	}))
+}
@@ -0,0 +1,26 @@
+package discipline
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+// Mastery: Shield Discipline
+// Increases the potency of all your damage absorption spells.
+func (discPriest *DisciplinePriest) registerShieldDiscipline() {
+	absorbMod := discPriest.AddDynamicMod(core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  priest.PriestSpellPowerWordShield | priest.PriestSpellDivineAegis | priest.PriestSpellSpiritShell,
+		FloatValue: discPriest.getMasteryBonus(discPriest.GetStat(stats.MasteryRating)),
+	})
+	absorbMod.Activate()
+
+	discPriest.AddOnMasteryStatChanged(func(sim *core.Simulation, oldMasteryRating float64, newMasteryRating float64) {
+		absorbMod.UpdateFloatValue(discPriest.getMasteryBonus(newMasteryRating))
+	})
+}
+
+func (discPriest *DisciplinePriest) getMasteryBonus(masteryRating float64) float64 {
+	return (8.0 + core.MasteryRatingToMasteryPoints(masteryRating)) * 0.016
+}
@@ -0,0 +1,57 @@
+package discipline
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+// For 15 sec, Heal, Flash Heal, Greater Heal and Prayer of Healing no longer
+// heal but create absorb shields instead, stacking up to 60% of the target's
+// maximum health.
+func (discPriest *DisciplinePriest) registerSpiritShell() {
+	actionID := core.ActionID{SpellID: 109964}
+
+	discPriest.SpiritShell = discPriest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 114908},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: priest.PriestSpellSpiritShell,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+	discPriest.SpiritShellAuras = discPriest.newCappedShieldAuras(discPriest.SpiritShell, "Spirit Shell Absorb")
+
+	discPriest.SpiritShellAura = discPriest.RegisterAura(core.Aura{
+		Label:    "Spirit Shell",
+		ActionID: actionID,
+		Duration: time.Second * 15,
+	})
+
+	discPriest.ApplySpiritShell = func(sim *core.Simulation, target *core.Unit, amount float64) {
+		applyCappedShield(sim, discPriest.SpiritShell, discPriest.SpiritShellAuras.Get(target), amount, 0.6)
+	}
+
+	discPriest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: priest.PriestSpellSpiritShell,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    discPriest.NewTimer(),
+				Duration: time.Minute,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			discPriest.SpiritShellAura.Activate(sim)
+		},
+
+		RelatedSelfBuff: discPriest.SpiritShellAura,
+	})
+}
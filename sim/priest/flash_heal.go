@@ -0,0 +1,42 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const flashHealScale = 11.7989997864
+const flashHealVariance = 0.1500000060
+const flashHealCoeff = 1.3140000105
+
+func (priest *Priest) registerFlashHealSpell() {
+	priest.FlashHeal = priest.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 2061},
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask:   PriestSpellFlashHeal,
+		BonusCoefficient: flashHealCoeff,
+		MaxRange:         40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 2.8,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := priest.CalcAndRollDamageRange(sim, flashHealScale, flashHealVariance)
+			priest.calcAndDealDirectHealing(sim, spell, priest.HealTarget(sim, target), baseHealing)
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const greaterHealScale = 21.9300003052
+const greaterHealVariance = 0.1500000060
+const greaterHealCoeff = 2.1900000572
+
+func (priest *Priest) registerGreaterHealSpell() {
+	priest.GreaterHeal = priest.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 2060},
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask:   PriestSpellGreaterHeal,
+		BonusCoefficient: greaterHealCoeff,
+		MaxRange:         40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 5.9,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := priest.CalcAndRollDamageRange(sim, greaterHealScale, greaterHealVariance)
+			priest.calcAndDealDirectHealing(sim, spell, priest.HealTarget(sim, target), baseHealing)
+		},
+	})
+}
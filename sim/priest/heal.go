@@ -0,0 +1,42 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const healScale = 9.8109998703
+const healVariance = 0.1500000060
+const healCoeff = 1.0240000486
+
+func (priest *Priest) registerHealSpell() {
+	priest.Heal = priest.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 2050},
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask:   PriestSpellHeal,
+		BonusCoefficient: healCoeff,
+		MaxRange:         40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 1.9,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := priest.CalcAndRollDamageRange(sim, healScale, healVariance)
+			priest.calcAndDealDirectHealing(sim, spell, priest.HealTarget(sim, target), baseHealing)
+		},
+	})
+}
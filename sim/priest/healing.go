@@ -0,0 +1,48 @@
+package priest
+
+import (
+	"github.com/wowsims/mop/sim/core"
+)
+
+// Registers the healing and Atonement spells shared by Discipline and Holy.
+func (priest *Priest) RegisterHealingSpells() {
+	priest.registerSmiteSpell()
+	priest.registerHolyFireSpell()
+	priest.registerFlashHealSpell()
+	priest.registerGreaterHealSpell()
+	priest.registerHealSpell()
+	priest.registerBindingHealSpell()
+	priest.registerRenewSpell()
+	priest.registerPowerWordShieldSpell()
+	priest.registerPrayerOfHealingSpell()
+	priest.registerPrayerOfMendingSpell()
+	priest.registerHymnOfHopeSpell()
+}
+
+// Heals cast on an enemy, e.g. from a plain Cast APL action, land on the
+// lowest health ally instead.
+func (priest *Priest) HealTarget(sim *core.Simulation, target *core.Unit) *core.Unit {
+	if target != nil && target.Type != core.EnemyUnit {
+		return target
+	}
+	if lowest := sim.Raid.GetLowestHealthAllyUnit(); lowest != nil {
+		return lowest
+	}
+	return &priest.Unit
+}
+
+// Returns up to maxTargets allies for a group heal, starting with the primary
+// target and followed by the lowest health allies.
+func (priest *Priest) GroupHealTargets(sim *core.Simulation, primary *core.Unit, maxTargets int) []*core.Unit {
+	return sim.Raid.GetSmartHealTargets(priest.HealTarget(sim, primary), maxTargets)
+}
+
+// Deals a direct heal, or turns it into a Spirit Shell absorb while Spirit Shell is active.
+func (priest *Priest) calcAndDealDirectHealing(sim *core.Simulation, spell *core.Spell, target *core.Unit, baseHealing float64) {
+	result := spell.CalcHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+	if priest.SpiritShellAura != nil && priest.SpiritShellAura.IsActive() {
+		priest.ApplySpiritShell(sim, target, result.Damage)
+		result.Damage = 0
+	}
+	spell.DealHealing(sim, result)
+}
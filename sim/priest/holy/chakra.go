@@ -0,0 +1,110 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+const chakraSerenitySpells = priest.PriestSpellFlashHeal | priest.PriestSpellGreaterHeal | priest.PriestSpellHeal |
+	priest.PriestSpellBindingHeal | priest.PriestSpellHolyWordSerenity
+const chakraSanctuarySpells = priest.PriestSpellAoeHeals | priest.PriestSpellHolyWordSanctuary
+const chakraChastiseSpells = priest.PriestSpellSmite | priest.PriestSpellHolyFire
+
+// Chakra: Serenity, Sanctuary and Chastise share a cooldown and only one
+// state can be active at a time.
+func (holyPriest *HolyPriest) registerChakras() {
+	cdTimer := holyPriest.NewTimer()
+
+	serenityMod := holyPriest.AddDynamicMod(core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  chakraSerenitySpells,
+		FloatValue: 0.25,
+	})
+	holyPriest.ChakraSerenityAura = holyPriest.registerChakra(core.ActionID{SpellID: 81208}, "Chakra: Serenity", cdTimer, core.Aura{
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			serenityMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			serenityMod.Deactivate()
+		},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			// Direct single target heals refresh the duration of Renew on the target.
+			if !spell.Matches(chakraSerenitySpells) {
+				return
+			}
+			if renew := holyPriest.Renew.Hot(result.Target); renew.IsActive() {
+				renew.ApplyRollover(sim)
+			}
+		},
+	})
+
+	sanctuaryMod := holyPriest.AddDynamicMod(core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  chakraSanctuarySpells,
+		FloatValue: 0.25,
+	})
+	circleOfHealingMod := holyPriest.AddDynamicMod(core.SpellModConfig{
+		Kind:      core.SpellMod_Cooldown_Flat,
+		ClassMask: priest.PriestSpellCircleOfHealing,
+		TimeValue: -time.Second * 2,
+	})
+	holyPriest.ChakraSanctuaryAura = holyPriest.registerChakra(core.ActionID{SpellID: 81206}, "Chakra: Sanctuary", cdTimer, core.Aura{
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			sanctuaryMod.Activate()
+			circleOfHealingMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			sanctuaryMod.Deactivate()
+			circleOfHealingMod.Deactivate()
+		},
+	})
+
+	chastiseMod := holyPriest.AddDynamicMod(core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  chakraChastiseSpells,
+		FloatValue: 0.5,
+	})
+	holyPriest.ChakraChastiseAura = holyPriest.registerChakra(core.ActionID{SpellID: 81209}, "Chakra: Chastise", cdTimer, core.Aura{
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			chastiseMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			chastiseMod.Deactivate()
+		},
+	})
+}
+
+func (holyPriest *HolyPriest) registerChakra(actionID core.ActionID, label string, cdTimer *core.Timer, config core.Aura) *core.Aura {
+	config.Label = label
+	config.ActionID = actionID
+	config.Duration = core.NeverExpires
+	aura := holyPriest.RegisterAura(config)
+
+	holyPriest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
+		ClassSpellMask: priest.PriestSpellChakra,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    cdTimer,
+				Duration: time.Second * 30,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for _, chakra := range []*core.Aura{holyPriest.ChakraSerenityAura, holyPriest.ChakraSanctuaryAura, holyPriest.ChakraChastiseAura} {
+				if chakra != nil && chakra != aura {
+					chakra.Deactivate(sim)
+				}
+			}
+			aura.Activate(sim)
+		},
+
+		RelatedSelfBuff: aura,
+	})
+
+	return aura
+}
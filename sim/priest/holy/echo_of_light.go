@@ -0,0 +1,59 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+// Mastery: Echo of Light
+// Your direct healing spells heal for an additional amount over 6 sec.
+// Further procs roll the remaining healing into the new HoT.
+func (holyPriest *HolyPriest) registerEchoOfLight() {
+	echoSpells := priest.PriestSpellFlashHeal | priest.PriestSpellGreaterHeal | priest.PriestSpellHeal | priest.PriestSpellBindingHeal |
+		priest.PriestSpellPrayerOfHealing | priest.PriestSpellCircleOfHealing | priest.PriestSpellPrayerOfMending |
+		priest.PriestSpellHolyWordSerenity
+
+	holyPriest.EchoOfLight = holyPriest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 77489},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagIgnoreModifiers | core.SpellFlagNoSpellMods | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: priest.PriestSpellEchoOfLight,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Echo of Light",
+			},
+			NumberOfTicks: 6,
+			TickLength:    time.Second,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.Spell.CalcAndDealPeriodicHealing(sim, target, dot.SnapshotBaseDamage, dot.OutcomeTick)
+			},
+		},
+	})
+
+	core.MakePermanent(holyPriest.RegisterAura(core.Aura{
+		Label:    "Mastery: Echo of Light",
+		ActionID: core.ActionID{SpellID: 77485},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !spell.Matches(echoSpells) || result.Damage <= 0 {
+				return
+			}
+
+			hot := holyPriest.EchoOfLight.Hot(result.Target)
+			totalHealing := hot.OutstandingDmg() + result.Damage*holyPriest.getMasteryPercent()
+			hot.Apply(sim)
+			hot.SnapshotBaseDamage = totalHealing / float64(hot.BaseTickCount)
+		},
+	}))
+}
+
+func (holyPriest *HolyPriest) getMasteryPercent() float64 {
+	return (8.0 + holyPriest.GetMasteryPoints()) * 0.0125
+}
@@ -41,24 +41,44 @@ func NewHolyPriest(character *core.Character, options *proto.Player) *HolyPriest
 
 type HolyPriest struct {
 	*priest.Priest
+
+	ChakraChastiseAura  *core.Aura
+	ChakraSanctuaryAura *core.Aura
+	ChakraSerenityAura  *core.Aura
+
+	EchoOfLight *core.Spell
 }
 
 func (holyPriest *HolyPriest) GetPriest() *priest.Priest {
 	return holyPriest.Priest
 }
 
+func (holyPriest *HolyPriest) GetMainTarget() *core.Unit {
+	target := holyPriest.Env.Raid.GetFirstTargetDummy()
+	if target == nil {
+		return &holyPriest.Unit
+	} else {
+		return &target.Unit
+	}
+}
+
 func (holyPriest *HolyPriest) Initialize() {
+	holyPriest.CurrentTarget = holyPriest.GetMainTarget()
 	holyPriest.Priest.Initialize()
+	holyPriest.Priest.RegisterHealingSpells()
+	holyPriest.RegisterCircleOfHealingSpell()
 
-	// holyPriest.RegisterHolyFireSpell()
-	// holyPriest.RegisterSmiteSpell()
-	// holyPriest.RegisterPenanceSpell()
-	// holyPriest.RegisterHymnOfHopeCD()
+	holyPriest.registerChakras()
+	holyPriest.registerHolyWordSerenity()
+	holyPriest.registerHolyWordSanctuary()
+	holyPriest.registerSerendipity()
+	holyPriest.registerEchoOfLight() // Mastery
 }
 
 func (holyPriest *HolyPriest) ApplyTalents() {
+	holyPriest.Priest.ApplyTalents()
 }
 
 func (holyPriest *HolyPriest) Reset(sim *core.Simulation) {
-	//holyPriest.Priest.Reset(sim)
+	holyPriest.Priest.Reset(sim)
 }
@@ -0,0 +1,120 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+const hwSerenityScale = 12.0
+const hwSerenityVariance = 0.16
+const hwSerenityCoeff = 1.3
+
+// Holy Word: Serenity is only available while in Chakra: Serenity.
+func (holyPriest *HolyPriest) registerHolyWordSerenity() {
+	holyPriest.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 88684},
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask:   priest.PriestSpellHolyWordSerenity,
+		BonusCoefficient: hwSerenityCoeff,
+		MaxRange:         40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 2,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holyPriest.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return holyPriest.ChakraSerenityAura.IsActive()
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holyPriest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := holyPriest.CalcAndRollDamageRange(sim, hwSerenityScale, hwSerenityVariance)
+			spell.CalcAndDealHealing(sim, holyPriest.HealTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+}
+
+const hwSanctuaryScale = 0.5460000038
+const hwSanctuaryCoeff = 0.0583000004
+const hwSanctuaryMaxTargets = 6
+
+// Holy Word: Sanctuary is only available while in Chakra: Sanctuary. The
+// consecrated area is modelled as healing the lowest health allies each tick.
+func (holyPriest *HolyPriest) registerHolyWordSanctuary() {
+	healSpell := holyPriest.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 88686},
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagPassiveSpell | core.SpellFlagNoOnCastComplete,
+		ClassSpellMask:   priest.PriestSpellHolyWordSanctuary,
+		BonusCoefficient: hwSanctuaryCoeff,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holyPriest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, holyPriest.CalcScalingSpellDmg(hwSanctuaryScale), spell.OutcomeHealingCrit)
+		},
+	})
+
+	holyPriest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 88685},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: priest.PriestSpellHolyWordSanctuary,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 4,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holyPriest.NewTimer(),
+				Duration: time.Second * 40,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return holyPriest.ChakraSanctuaryAura.IsActive()
+		},
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Holy Word: Sanctuary",
+			},
+			NumberOfTicks: 15,
+			TickLength:    time.Second * 2,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				for _, healTarget := range holyPriest.GroupHealTargets(sim, nil, hwSanctuaryMaxTargets) {
+					healSpell.Cast(sim, healTarget)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+// Serendipity: Flash Heal and Binding Heal reduce the cast time of your next
+// Greater Heal or Prayer of Healing by 20%, stacking up to 2 times.
+func (holyPriest *HolyPriest) registerSerendipity() {
+	affectedSpells := priest.PriestSpellGreaterHeal | priest.PriestSpellPrayerOfHealing
+
+	castTimeMod := holyPriest.AddDynamicMod(core.SpellModConfig{
+		Kind:       core.SpellMod_CastTime_Pct,
+		ClassMask:  affectedSpells,
+		FloatValue: -0.2,
+	})
+
+	serendipityAura := holyPriest.RegisterAura(core.Aura{
+		Label:     "Serendipity",
+		ActionID:  core.ActionID{SpellID: 63735},
+		Duration:  time.Second * 20,
+		MaxStacks: 2,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			castTimeMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			castTimeMod.Deactivate()
+		},
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks, newStacks int32) {
+			castTimeMod.UpdateFloatValue(-0.2 * float64(newStacks))
+		},
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(affectedSpells) {
+				aura.Deactivate(sim)
+			}
+		},
+	})
+
+	core.MakeProcTriggerAura(&holyPriest.Unit, core.ProcTrigger{
+		Name:           "Serendipity Trigger",
+		Callback:       core.CallbackOnCastComplete,
+		ClassSpellMask: priest.PriestSpellFlashHeal | priest.PriestSpellBindingHeal,
+		Handler: func(sim *core.Simulation, spell *core.Spell, _ *core.SpellResult) {
+			serendipityAura.Activate(sim)
+			serendipityAura.AddStack(sim)
+		},
+	})
+}
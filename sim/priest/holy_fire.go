@@ -0,0 +1,68 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const holyFireScale = 1.0800000429
+const holyFireVariance = 0.2379999906
+const holyFireCoeff = 1.1100000143
+const holyFireDotScale = 0.0311999992
+const holyFireDotCoeff = 0.0311999992
+
+func (priest *Priest) registerHolyFireSpell() {
+	priest.HolyFire = priest.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 14914},
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagAPL,
+		ClassSpellMask:   PriestSpellHolyFire,
+		BonusCoefficient: holyFireCoeff,
+		MaxRange:         30,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 1.8,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Holy Fire",
+			},
+			NumberOfTicks:    7,
+			TickLength:       time.Second,
+			BonusCoefficient: holyFireDotCoeff,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.Snapshot(target, priest.CalcScalingSpellDmg(holyFireDotScale))
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := priest.CalcAndRollDamageRange(sim, holyFireScale, holyFireVariance)
+			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
+			if result.Landed() {
+				spell.Dot(target).Apply(sim)
+			}
+			spell.DealDamage(sim, result)
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+// Restores 2% of maximum mana every 2 sec for 8 sec.
+func (priest *Priest) registerHymnOfHopeSpell() {
+	actionID := core.ActionID{SpellID: 64901}
+	manaMetrics := priest.NewManaMetrics(actionID)
+
+	priest.HymnOfHope = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagChanneled | core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellHymnOfHope,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Minute * 6,
+			},
+		},
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Hymn of Hope",
+			},
+			NumberOfTicks:       4,
+			TickLength:          time.Second * 2,
+			AffectedByCastSpeed: true,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, _ *core.Dot) {
+				priest.AddMana(sim, priest.MaxMana()*0.02, manaMetrics)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
@@ -0,0 +1,104 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const penanceDamageScale = 0.7800000310
+const penanceDamageCoeff = 0.8379999995
+const penanceHealScale = 4.5500001907
+const penanceHealCoeff = 0.8379999995
+
+// Penance fires one bolt immediately and two more over the 2 sec channel.
+func (priest *Priest) RegisterPenanceSpell() {
+	// Damage and healing Penance share a cooldown.
+	cdTimer := priest.NewTimer()
+	priest.Penance = priest.makePenanceSpell(false, cdTimer)
+	priest.PenanceHeal = priest.makePenanceSpell(true, cdTimer)
+}
+
+func (priest *Priest) makePenanceSpell(isHeal bool, cdTimer *core.Timer) *core.Spell {
+	actionID := core.ActionID{SpellID: 47540}
+	procMask := core.ProcMaskSpellDamage
+	flags := core.SpellFlagChanneled | core.SpellFlagAPL
+	if isHeal {
+		actionID = actionID.WithTag(1)
+		procMask = core.ProcMaskSpellHealing
+		flags |= core.SpellFlagHelpful
+	}
+
+	config := core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       procMask,
+		Flags:          flags,
+		ClassSpellMask: PriestSpellPenance,
+		MaxRange:       40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 3.1,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    cdTimer,
+				Duration: time.Second*10 - core.TernaryDuration(priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfPenance), time.Second*2, 0),
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+	}
+
+	dotConfig := core.DotConfig{
+		Aura: core.Aura{
+			Label: "Penance",
+		},
+		NumberOfTicks:       2,
+		TickLength:          time.Second,
+		AffectedByCastSpeed: true,
+	}
+
+	if isHeal {
+		dotConfig.Aura.Label += " (Heal)"
+		dotConfig.BonusCoefficient = penanceHealCoeff
+		dotConfig.OnSnapshot = func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+			dot.SnapshotHeal(target, priest.CalcScalingSpellDmg(penanceHealScale))
+		}
+		dotConfig.OnTick = func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+			dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+		}
+		config.Hot = dotConfig
+		config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			hot := spell.Hot(priest.HealTarget(sim, target))
+			hot.Apply(sim)
+			hot.TickOnce(sim)
+		}
+	} else {
+		dotConfig.BonusCoefficient = penanceDamageCoeff
+		dotConfig.OnSnapshot = func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+			dot.Snapshot(target, priest.CalcScalingSpellDmg(penanceDamageScale))
+		}
+		dotConfig.OnTick = func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+			dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeSnapshotCrit)
+		}
+		config.Dot = dotConfig
+		config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcOutcome(sim, target, spell.OutcomeMagicHitNoHitCounter)
+			if result.Landed() {
+				dot := spell.Dot(target)
+				dot.Apply(sim)
+				dot.TickOnce(sim)
+			}
+			spell.DealOutcome(sim, result)
+		}
+	}
+
+	return priest.RegisterSpell(config)
+}
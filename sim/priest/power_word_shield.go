@@ -0,0 +1,98 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const pwsScale = 18.5149993896
+const pwsCoeff = 1.8709999323
+
+func (priest *Priest) registerPowerWordShieldSpell() {
+	hasGlyph := priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfPowerWordShield)
+	var glyphHeal *core.Spell
+
+	priest.WeakenedSouls = priest.NewAllyAuraArray(func(target *core.Unit) *core.Aura {
+		return target.GetOrRegisterAura(core.Aura{
+			Label:    "Weakened Soul",
+			ActionID: core.ActionID{SpellID: 6788},
+			Duration: time.Second * 15,
+		})
+	})
+
+	priest.PowerWordShield = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 17},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellPowerWordShield,
+		MaxRange:       40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 6.1,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return !priest.WeakenedSouls.Get(priest.HealTarget(sim, target)).IsActive()
+		},
+
+		DamageMultiplier: core.TernaryFloat64(hasGlyph, 0.8, 1),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = priest.HealTarget(sim, target)
+			shield := priest.PowerWordShieldAuras.Get(target)
+			shield.Activate(sim)
+			spell.RecordShielding(sim, target, shield.ShieldStrength)
+			priest.WeakenedSouls.Get(target).Activate(sim)
+
+			if glyphHeal != nil {
+				glyphHeal.Cast(sim, target)
+			}
+		},
+	})
+
+	// Shields are not affected by healing pseudostats, so only the spell multiplier applies.
+	priest.PowerWordShieldAuras = priest.NewAllyDamageAbsorptionAuraArray(func(target *core.Unit) *core.DamageAbsorptionAura {
+		return target.NewDamageAbsorptionAura(core.AbsorptionAuraConfig{
+			Aura: core.Aura{
+				Label:    "Power Word: Shield" + priest.Label,
+				ActionID: priest.PowerWordShield.ActionID,
+				Duration: time.Second * 15,
+			},
+			ShieldStrengthCalculator: func(target *core.Unit) float64 {
+				spell := priest.PowerWordShield
+				return (priest.CalcScalingSpellDmg(pwsScale) + pwsCoeff*spell.HealingPower(target)) * spell.DamageMultiplier
+			},
+		})
+	})
+
+	// Glyph of Power Word: Shield moves 20% of the absorb into an instant heal.
+	if hasGlyph {
+		glyphHeal = priest.RegisterSpell(core.SpellConfig{
+			ActionID:    core.ActionID{SpellID: 56160},
+			SpellSchool: core.SpellSchoolHoly,
+			ProcMask:    core.ProcMaskSpellHealing,
+			Flags:       core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
+
+			DamageMultiplier: 0.2,
+			CritMultiplier:   priest.DefaultCritMultiplier(),
+			ThreatMultiplier: 1,
+
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				baseHealing := priest.CalcScalingSpellDmg(pwsScale) + pwsCoeff*spell.HealingPower(target)
+				spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+			},
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const pohScale = 8.0799999237
+const pohVariance = 0.0549999997
+const pohCoeff = 0.8379999995
+const pohMaxTargets = 5
+
+func (priest *Priest) registerPrayerOfHealingSpell() {
+	priest.PrayerOfHealing = priest.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 596},
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask:   PriestSpellPrayerOfHealing,
+		BonusCoefficient: pohCoeff,
+		MaxRange:         40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 4.5,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			for _, healTarget := range priest.GroupHealTargets(sim, target, pohMaxTargets) {
+				baseHealing := priest.CalcAndRollDamageRange(sim, pohScale, pohVariance)
+				priest.calcAndDealDirectHealing(sim, spell, healTarget, baseHealing)
+			}
+		},
+	})
+}
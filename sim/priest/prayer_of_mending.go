@@ -0,0 +1,105 @@
+package priest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const pomScale = 5.7470002174
+const pomCoeff = 0.5709999800
+const pomMaxCharges = 5
+
+// Without incoming damage the mending is assumed to trigger after this delay.
+const pomAutoProcDelay = time.Second * 5
+
+func (priest *Priest) registerPrayerOfMendingSpell() {
+	actionID := core.ActionID{SpellID: 33076}
+
+	pomAuras := make([]*core.Aura, len(priest.Env.AllUnits))
+	for _, unit := range priest.Env.AllUnits {
+		if !priest.IsOpponent(unit) {
+			pomAuras[unit.UnitIndex] = priest.makePrayerOfMendingAura(unit)
+		}
+	}
+
+	var remainingCharges int
+	priest.ProcPrayerOfMending = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		pomAuras[target.UnitIndex].Deactivate(sim)
+
+		baseHealing := priest.CalcScalingSpellDmg(pomScale)
+		spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+
+		remainingCharges--
+		if remainingCharges <= 0 {
+			return
+		}
+
+		// Bounce to the lowest health ally other than the current target.
+		if targets := priest.GroupHealTargets(sim, target, 2); len(targets) > 1 {
+			pomAuras[targets[1].UnitIndex].Activate(sim)
+		}
+	}
+
+	priest.PrayerOfMending = priest.RegisterSpell(core.SpellConfig{
+		ActionID:         actionID,
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask:   PriestSpellPrayerOfMending,
+		BonusCoefficient: pomCoeff,
+		MaxRange:         40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 3.5,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			for _, aura := range pomAuras {
+				if aura != nil {
+					aura.Deactivate(sim)
+				}
+			}
+
+			pomAuras[priest.HealTarget(sim, target).UnitIndex].Activate(sim)
+			remainingCharges = pomMaxCharges
+		},
+	})
+}
+
+func (priest *Priest) makePrayerOfMendingAura(target *core.Unit) *core.Aura {
+	return target.RegisterAura(core.Aura{
+		Label:    "Prayer of Mending-" + strconv.Itoa(int(priest.Index)),
+		ActionID: core.ActionID{SpellID: 41635},
+		Duration: time.Second * 30,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			sim.AddPendingAction(core.NewDelayedAction(core.DelayedActionOptions{
+				DoAt: sim.CurrentTime + pomAutoProcDelay,
+				OnAction: func(sim *core.Simulation) {
+					if aura.IsActive() {
+						priest.ProcPrayerOfMending(sim, aura.Unit, priest.PrayerOfMending)
+					}
+				},
+			}))
+		},
+		OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if result.Damage > 0 {
+				priest.ProcPrayerOfMending(sim, aura.Unit, priest.PrayerOfMending)
+			}
+		},
+	})
+}
@@ -35,6 +35,7 @@ type Priest struct {
 	CircleOfHealing   *core.Spell
 	FlashHeal         *core.Spell
 	GreaterHeal       *core.Spell
+	Heal              *core.Spell
 	Penance           *core.Spell
 	PenanceHeal       *core.Spell
 	PowerWordShield   *core.Spell
@@ -45,15 +46,21 @@ type Priest struct {
 	InnerFocus        *core.Spell
 	HolyFire          *core.Spell
 	Smite             *core.Spell
+	HymnOfHope        *core.Spell
 	ShadowWordPain    *core.Spell
 	Shadowfiend       *core.Spell
 	VampiricTouch     *core.Spell
 	MindBender        *core.Spell
 	ShadowyApparition *core.Spell
 
-	WeakenedSouls core.AuraArray
+	WeakenedSouls        core.AuraArray
+	PowerWordShieldAuras core.DamageAbsorptionAuraArray
 
 	ProcPrayerOfMending core.ApplySpellResults
+
+	// Set by Discipline. While active, direct heals are converted into absorbs.
+	SpiritShellAura  *core.Aura
+	ApplySpiritShell func(sim *core.Simulation, target *core.Unit, amount float64)
 }
 
 type SelfBuffs struct {
@@ -202,6 +209,11 @@ const (
 	PriestSpellSmite
 	PriestSpellVampiricEmbrace
 	PriestSpellVampiricTouch
+	PriestSpellHeal
+	PriestSpellAtonement
+	PriestSpellSpiritShell
+	PriestSpellEchoOfLight
+	PriestSpellChakra
 
 	PriestSpellLast
 	PriestSpellsAll    = PriestSpellLast<<1 - 1
@@ -225,6 +237,18 @@ const (
 		PriestSpellShadowWordDeath |
 		PriestSpellShadowWordPain |
 		PriestSpellVampiricEmbrace
+	PriestSpellSingleTargetHeals = PriestSpellFlashHeal |
+		PriestSpellGreaterHeal |
+		PriestSpellHeal |
+		PriestSpellBindingHeal |
+		PriestSpellPenance |
+		PriestSpellRenew |
+		PriestSpellHolyWordSerenity
+	PriestSpellAoeHeals = PriestSpellPrayerOfHealing |
+		PriestSpellCircleOfHealing |
+		PriestSpellPrayerOfMending |
+		PriestSpellHolyWordSanctuary |
+		PriestSpellDivineHymn
 	PriestShadowSpells = PriestSpellImprovedDevouringPlague |
 		PriestSpellDevouringPlague |
 		PriestSpellShadowWordDeath |
@@ -0,0 +1,42 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const smiteScale = 2.2390000820
+const smiteVariance = 0.1150000021
+const smiteCoeff = 0.8560000062
+
+func (priest *Priest) registerSmiteSpell() {
+	priest.Smite = priest.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 585},
+		SpellSchool:      core.SpellSchoolHoly,
+		ProcMask:         core.ProcMaskSpellDamage,
+		Flags:            core.SpellFlagAPL,
+		ClassSpellMask:   PriestSpellSmite,
+		BonusCoefficient: smiteCoeff,
+		MaxRange:         30,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 1.5,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := priest.CalcAndRollDamageRange(sim, smiteScale, smiteVariance)
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
+		},
+	})
+}
@@ -64,7 +64,7 @@ func (shaman *Shaman) registerAscendanceSpell() {
 				originalOHSpell = shaman.AutoAttacks.OHAuto()
 				shaman.AutoAttacks.SetMHSpell(windslashMH)
 				shaman.AutoAttacks.SetOHSpell(windslashOH)
-			} else {
+			} else if shaman.LavaBurst != nil {
 				shaman.LavaBurst.CD.Reset()
 			}
 
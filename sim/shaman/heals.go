@@ -0,0 +1,205 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Heals cast on an enemy, e.g. from a plain Cast APL action, land on the
+// lowest health ally instead.
+func (shaman *Shaman) HealTarget(sim *core.Simulation, target *core.Unit) *core.Unit {
+	if target != nil && !target.IsOpponent(&shaman.Unit) {
+		return target
+	}
+	if lowest := sim.Raid.GetLowestHealthAllyUnit(); lowest != nil {
+		return lowest
+	}
+	return &shaman.Unit
+}
+
+// Returns up to maxTargets allies for a group heal, starting with the primary
+// target and followed by the lowest health allies.
+func (shaman *Shaman) SmartHealTargets(sim *core.Simulation, primary *core.Unit, maxTargets int) []*core.Unit {
+	return sim.Raid.GetSmartHealTargets(shaman.HealTarget(sim, primary), maxTargets)
+}
+
+/*
+When you critically heal with your Healing Wave, Greater Healing Wave, Healing Surge, Unleash Life or Riptide spells,
+you summon an Ancestral spirit to aid you, instantly healing the lowest percentage health friendly party or raid target
+within 40 yards for 30% of the amount healed.
+*/
+func (shaman *Shaman) registerAncestralAwakening() {
+	shaman.AncestralAwakening = shaman.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 52752},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagIgnoreModifiers | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: SpellMaskAncestralAwakening,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, shaman.HealTarget(sim, nil), shaman.ancestralHealingAmount, spell.OutcomeHealing)
+		},
+	})
+
+	core.MakeProcTriggerAura(&shaman.Unit, core.ProcTrigger{
+		Name:           "Ancestral Awakening Trigger" + shaman.Label,
+		ActionID:       core.ActionID{SpellID: 51558},
+		Callback:       core.CallbackOnHealDealt,
+		Outcome:        core.OutcomeCrit,
+		ClassSpellMask: SpellMaskSingleTargetHeal,
+
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			shaman.ancestralHealingAmount = result.Damage * 0.3
+			shaman.AncestralAwakening.Cast(sim, result.Target)
+		},
+	})
+}
+
+func (shaman *Shaman) newSingleTargetHealConfig(actionID core.ActionID, classMask int64, castTime time.Duration, costPercent float64) core.SpellConfig {
+	return core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: classMask,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: costPercent,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: castTime,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   shaman.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+	}
+}
+
+// Heals a friendly target for (<9241-10473> + 1.135 * <SP>).
+func (shaman *Shaman) registerHealingSurgeSpell() {
+	config := shaman.newSingleTargetHealConfig(core.ActionID{SpellID: 8004}, SpellMaskHealingSurge, time.Millisecond*1500, 20.7)
+	config.BonusCoefficient = 1.13499999046
+	config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		baseHealing := shaman.CalcAndRollDamageRange(sim, 9.86999988556, 0.13300000131)
+		spell.CalcAndDealHealing(sim, shaman.HealTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+	}
+
+	shaman.HealingSurge = shaman.RegisterSpell(config)
+}
+
+// Heals a friendly target for (<7009-7943> + 0.756 * <SP>).
+func (shaman *Shaman) registerHealingWaveSpell() {
+	config := shaman.newSingleTargetHealConfig(core.ActionID{SpellID: 331}, SpellMaskHealingWave, time.Millisecond*2500, 9.3)
+	config.BonusCoefficient = 0.75599998236
+	config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		baseHealing := shaman.CalcAndRollDamageRange(sim, 7.48799991608, 0.13300000131)
+		spell.CalcAndDealHealing(sim, shaman.HealTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+	}
+
+	shaman.HealingWave = shaman.RegisterSpell(config)
+}
+
+// A slow but very potent heal that restores (<12852-14567> + 1.377 * <SP>) health.
+func (shaman *Shaman) registerGreaterHealingWaveSpell() {
+	config := shaman.newSingleTargetHealConfig(core.ActionID{SpellID: 77472}, SpellMaskGreaterHealingWave, time.Millisecond*2500, 27)
+	config.BonusCoefficient = 1.37699997425
+	config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		baseHealing := shaman.CalcAndRollDamageRange(sim, 13.72900009155, 0.13300000131)
+		spell.CalcAndDealHealing(sim, shaman.HealTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+	}
+
+	shaman.GreaterHealingWave = shaman.RegisterSpell(config)
+}
+
+/*
+Heals a friendly target for (<2736-2964> + 0.3 * <SP>) and another (<1302> + 0.14 * <SP>) over 18 sec.
+
+-- Glyph of Riptide --
+Removes the cooldown of Riptide, but reduces the initial direct healing by 75%.
+-- /Glyph of Riptide --
+*/
+func (shaman *Shaman) registerRiptideSpell() {
+	hasGlyph := shaman.HasMajorGlyph(proto.ShamanMajorGlyph_GlyphOfRiptide)
+	directMultiplier := core.TernaryFloat64(hasGlyph, 0.25, 1)
+
+	config := shaman.newSingleTargetHealConfig(core.ActionID{SpellID: 61295}, SpellMaskRiptide, 0, 10)
+	if !hasGlyph {
+		config.Cast.CD = core.Cooldown{
+			Timer:    shaman.NewTimer(),
+			Duration: time.Second * 6,
+		}
+	}
+	config.BonusCoefficient = 0.30000001192
+	config.Hot = core.DotConfig{
+		Aura: core.Aura{
+			Label: "Riptide",
+		},
+		NumberOfTicks:       6,
+		TickLength:          time.Second * 3,
+		AffectedByCastSpeed: true,
+		BonusCoefficient:    0.14000000060,
+
+		OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+			dot.SnapshotHeal(target, shaman.CalcScalingSpellDmg(1.30200004578))
+		},
+		OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+			dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+		},
+	}
+	config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		target = shaman.HealTarget(sim, target)
+		baseHealing := shaman.CalcAndRollDamageRange(sim, 2.73600006104, 0.08299999684)
+
+		spell.DamageMultiplier *= directMultiplier
+		spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		spell.DamageMultiplier /= directMultiplier
+
+		spell.Hot(target).Apply(sim)
+	}
+
+	shaman.Riptide = shaman.RegisterSpell(config)
+}
+
+/*
+Heals the friendly target for (<4765-5441> + 0.688 * <SP>), then jumps to heal the most injured nearby allies.
+Healing is reduced by 10% with each jump. Heals 4 total targets.
+If the primary target has your Riptide, Chain Heal's healing is increased by 25%.
+*/
+func (shaman *Shaman) registerChainHealSpell() {
+	numTargets := 4
+	jumpMultiplier := 0.9
+
+	config := shaman.newSingleTargetHealConfig(core.ActionID{SpellID: 1064}, SpellMaskChainHeal, time.Millisecond*2500, 26.1)
+	config.BonusCoefficient = 0.68800002337
+	config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		targets := shaman.SmartHealTargets(sim, target, numTargets)
+
+		multiplier := 1.0
+		if shaman.Riptide != nil && shaman.Riptide.Hot(targets[0]).IsActive() {
+			multiplier = 1.25
+		}
+
+		damageMultiplier := spell.DamageMultiplier
+		for _, unit := range targets {
+			spell.DamageMultiplier = damageMultiplier * multiplier
+			baseHealing := shaman.CalcAndRollDamageRange(sim, 4.76499986649, 0.13300000131)
+			spell.CalcAndDealHealing(sim, unit, baseHealing, spell.OutcomeHealingCrit)
+			multiplier *= jumpMultiplier
+		}
+		spell.DamageMultiplier = damageMultiplier
+	}
+
+	shaman.ChainHeal = shaman.RegisterSpell(config)
+}
@@ -0,0 +1,66 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/shaman"
+)
+
+/*
+Calls forth healing rains to blanket the area targeted by the Shaman,
+restoring (<1006> + 0.165 * <SP>) health to up to 6 allies in the area every 2 sec for 10 sec.
+*/
+func (resto *RestorationShaman) registerHealingRainSpell() {
+	numTargets := 6
+
+	resto.HealingRain = resto.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 73920},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: shaman.SpellMaskHealingRain,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 21.6,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 2,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Healing Rain" + resto.Label,
+			},
+			NumberOfTicks:       5,
+			TickLength:          time.Second * 2,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.16500000656,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				for _, unit := range resto.SmartHealTargets(sim, nil, numTargets) {
+					dot.Spell.CalcAndDealPeriodicHealing(sim, unit, resto.CalcScalingSpellDmg(0.98799997568), dot.Spell.OutcomeHealingCrit)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/shaman"
+)
+
+/*
+Summons a Water Totem with 10% of the caster's health for 10 sec.
+Pulses every 2 sec, healing the 5 most injured party or raid members within 40 yards for (<4819> + 0.484 * <SP>).
+*/
+func (resto *RestorationShaman) registerHealingTideTotemSpell() {
+	numTargets := 5
+
+	resto.HealingTideTotem = resto.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 108280},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: shaman.SpellMaskHealingTideTotem,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 18,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: time.Second,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Healing Tide Totem" + resto.Label,
+			},
+			NumberOfTicks:    5,
+			TickLength:       time.Second * 2,
+			BonusCoefficient: 0.48399999738,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				for _, unit := range resto.SmartHealTargets(sim, nil, numTargets) {
+					dot.Spell.CalcAndDealPeriodicHealing(sim, unit, resto.CalcScalingSpellDmg(4.73000001907), dot.Spell.OutcomeHealingCrit)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			resto.TotemExpirations[shaman.WaterTotem] = sim.CurrentTime + time.Second*10
+			spell.SelfHot().Apply(sim)
+		},
+	})
+
+	resto.AddMajorCooldown(core.MajorCooldown{
+		Spell: resto.HealingTideTotem,
+		Type:  core.CooldownTypeSurvival,
+	})
+}
@@ -0,0 +1,33 @@
+package restoration
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/shaman"
+)
+
+// Increases the potency of your healing spells by up to ((8 + <Mastery Rating> / 600) * 3)%,
+// based on the current health level of your target (lower health targets are healed for more).
+func (resto *RestorationShaman) registerMastery() {
+	healingMask := shaman.SpellMaskDirectHeal | shaman.SpellMaskHealingRain | shaman.SpellMaskHealingTideTotem
+
+	deepHealing := core.MakePermanent(resto.RegisterAura(core.Aura{
+		Label:    "Mastery: Deep Healing" + resto.Label,
+		ActionID: core.ActionID{SpellID: 77226},
+	}))
+
+	for _, unit := range resto.Env.AllUnits {
+		if unit.IsOpponent(&resto.Unit) {
+			continue
+		}
+		unit.AddDynamicHealingTakenModifier(func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell.Unit != &resto.Unit || !spell.Matches(healingMask) || !deepHealing.IsActive() || !result.Target.HasHealthBar() {
+				return
+			}
+			result.Damage *= 1 + resto.getMasteryPercent()*(1-result.Target.CurrentHealthPercent())
+		})
+	}
+}
+
+func (resto *RestorationShaman) getMasteryPercent() float64 {
+	return ((8.0 + resto.GetMasteryPoints()) * 3) / 100.0
+}
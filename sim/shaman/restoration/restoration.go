@@ -35,15 +35,20 @@ func NewRestorationShaman(character *core.Character, options *proto.Player) *Res
 		Shaman: shaman.NewShaman(character, options.TalentsString, selfBuffs, false, restoOptions.ClassOptions.FeleAutocast),
 	}
 
-	// if resto.HasMHWeapon() {
-	// 	resto.ApplyEarthlivingImbueToItem(resto.GetMHWeapon())
-	// }
+	if resto.HasMHWeapon() {
+		resto.SelfBuffs.ImbueMH = proto.ShamanImbue_EarthlivingWeapon
+	}
 
 	return resto
 }
 
 type RestorationShaman struct {
 	*shaman.Shaman
+
+	HealingRain      *core.Spell
+	HealingTideTotem *core.Spell
+
+	TidalWavesAura *core.Aura
 }
 
 func (resto *RestorationShaman) GetShaman() *shaman.Shaman {
@@ -53,8 +58,8 @@ func (resto *RestorationShaman) GetShaman() *shaman.Shaman {
 func (resto *RestorationShaman) Reset(sim *core.Simulation) {
 	resto.Shaman.Reset(sim)
 }
+
 func (resto *RestorationShaman) GetMainTarget() *core.Unit {
-	// TODO: make this just grab first player that isn't self.
 	target := resto.Env.Raid.GetFirstTargetDummy()
 	if target == nil {
 		return &resto.Unit
@@ -64,20 +69,23 @@ func (resto *RestorationShaman) GetMainTarget() *core.Unit {
 }
 
 func (resto *RestorationShaman) Initialize() {
-	// resto.CurrentTarget = resto.GetMainTarget()
-
-	// // Has to be here because earthliving can cast hots and needs Env to be set to create the hots.
-	// procMask := core.ProcMaskUnknown
-	// if resto.HasMHWeapon() {
-	// 	procMask |= core.ProcMaskMeleeMH
-	// }
-	// if resto.HasOHWeapon() {
-	// 	procMask |= core.ProcMaskMeleeOH
-	// }
-	// resto.RegisterEarthlivingImbue(procMask)
+	resto.CurrentTarget = resto.GetMainTarget()
+
+	// Has to be here because earthliving can cast hots and needs Env to be set to create the hots.
+	procMask := core.ProcMaskUnknown
+	if resto.HasMHWeapon() {
+		procMask |= core.ProcMaskMeleeMH
+	}
+	resto.RegisterEarthlivingImbue(procMask)
 
 	resto.Shaman.Initialize()
 	resto.Shaman.RegisterHealingSpells()
+
+	resto.registerHealingRainSpell()
+	resto.registerHealingTideTotemSpell()
+	resto.registerMastery()
+	resto.registerRestorativeMists()
+	resto.registerTidalWaves()
 }
 
 func (resto *RestorationShaman) ApplyTalents() {
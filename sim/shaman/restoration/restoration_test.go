@@ -1,104 +1,78 @@
 package restoration
 
 import (
-	_ "github.com/wowsims/mop/sim/common"
+	"testing"
+
+	"github.com/wowsims/mop/sim/common" // imported to get item effects included.
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
 )
 
 func init() {
 	RegisterRestorationShaman()
+	common.RegisterAllEffects()
 }
 
-// func TestRestoration(t *testing.T) {
-// 	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
-// 		Class: proto.Class_ClassShaman,
-// 		Race:  proto.Race_RaceTroll,
-
-// 		GearSet:     core.GetGearSet("../../../ui/restoration_shaman/gear_sets", "p1"),
-// 		Talents:     StandardTalents,
-// 		Glyphs:      StandardGlyphs,
-// 		Consumes:    FullConsumes,
-// 		SpecOptions: core.SpecOptionsCombo{Label: "Standard", SpecOptions: PlayerOptionsStandard},
-// 		Rotation:    core.RotationCombo{Label: "Default", Rotation: DefaultRotation},
+func TestRestoration(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassShaman,
+			Race:       proto.Race_RaceTroll,
+			OtherRaces: []proto.Race{proto.Race_RaceDraenei},
+			IsHealer:   true,
 
-// 		ItemFilter: core.ItemFilter{
-// 			WeaponTypes: []proto.WeaponType{
-// 				proto.WeaponType_WeaponTypeAxe,
-// 				proto.WeaponType_WeaponTypeDagger,
-// 				proto.WeaponType_WeaponTypeFist,
-// 				proto.WeaponType_WeaponTypeMace,
-// 				proto.WeaponType_WeaponTypeOffHand,
-// 				proto.WeaponType_WeaponTypeShield,
-// 				proto.WeaponType_WeaponTypeStaff,
-// 			},
-// 			ArmorType: proto.ArmorType_ArmorTypeMail,
-// 			RangedWeaponTypes: []proto.RangedWeaponType{},
-// 		},
-// 	}))
-// }
+			GearSet: core.GetGearSet("../../../ui/shaman/elemental/gear_sets", "preraid"),
+			Talents: StandardTalents,
+			OtherTalentSets: []core.TalentsCombo{
+				{Label: "GlyphOfRiptide", Talents: StandardTalents, Glyphs: RiptideGlyphs},
+			},
+			Glyphs:      StandardGlyphs,
+			Consumables: FullConsumesSpec,
+			SpecOptions: core.SpecOptionsCombo{Label: "Standard", SpecOptions: PlayerOptionsStandard},
+			Rotation:    core.GetAplRotation("../../../ui/shaman/restoration/apls", "default"),
 
-// func BenchmarkSimulate(b *testing.B) {
-// 	rsr := &proto.RaidSimRequest{
-// 		Raid: core.SinglePlayerRaidProto(
-// 			&proto.Player{
-// 				Race:          proto.Race_RaceOrc,
-// 				Class:         proto.Class_ClassShaman,
-// 				Equipment:     core.GetGearSet("../../../ui/restoration_shaman/gear_sets", "p1").GearSet,
-// 				Consumes:      FullConsumes,
-// 				Spec:          PlayerOptionsStandard,
-// 				Buffs:         core.FullIndividualBuffs,
-// 				TalentsString: StandardTalents,
-// 				Glyphs:        StandardGlyphs,
-// 			},
-// 			core.FullPartyBuffs,
-// 			core.FullRaidBuffs,
-// 			core.FullDebuffs),
-// 		Encounter: &proto.Encounter{
-// 			Duration: 300,
-// 			Targets: []*proto.Target{
-// 				core.NewDefaultTarget(),
-// 			},
-// 		},
-// 		SimOptions: core.AverageDefaultSimTestOptions,
-// 	}
-
-// 	core.RaidBenchmark(b, rsr)
-// }
+			ItemFilter: core.ItemFilter{
+				WeaponTypes: []proto.WeaponType{
+					proto.WeaponType_WeaponTypeAxe,
+					proto.WeaponType_WeaponTypeDagger,
+					proto.WeaponType_WeaponTypeFist,
+					proto.WeaponType_WeaponTypeMace,
+					proto.WeaponType_WeaponTypeOffHand,
+					proto.WeaponType_WeaponTypeShield,
+					proto.WeaponType_WeaponTypeStaff,
+				},
+				ArmorType:         proto.ArmorType_ArmorTypeMail,
+				RangedWeaponTypes: []proto.RangedWeaponType{},
+			},
+		},
+	}))
+}
 
-// var StandardTalents = "-3020503-50005331335310501122331251"
-// var StandardGlyphs = &proto.Glyphs{
-// 	Major1: int32(proto.ShamanMajorGlyph_GlyphOfChainHeal),
-// 	Major2: int32(proto.ShamanMajorGlyph_GlyphOfEarthShield),
-// 	Major3: int32(proto.ShamanMajorGlyph_GlyphOfEarthlivingWeapon),
-// }
+var StandardTalents = "313231"
 
-// var BasicTotems = &proto.ShamanTotems{
-// 	Earth: proto.EarthTotem_TremorTotem,
-// 	Air:   proto.AirTotem_WrathOfAirTotem,
-// 	Water: proto.WaterTotem_ManaSpringTotem,
-// 	Fire:  proto.FireTotem_FlametongueTotem,
-// }
+var StandardGlyphs = &proto.Glyphs{
+	Major1: int32(proto.ShamanMajorGlyph_GlyphOfWaterShield),
+	Major2: int32(proto.ShamanMajorGlyph_GlyphOfHealingStreamTotem),
+}
 
-// var restoShamOptions = &proto.RestorationShaman_Options{
-// 	Shield: proto.ShamanShield_WaterShield,
-// 	Totems: BasicTotems,
-// }
-// var PlayerOptionsStandard = &proto.Player_RestorationShaman{
-// 	RestorationShaman: &proto.RestorationShaman{
-// 		Options: restoShamOptions,
-// 	},
-// }
+var RiptideGlyphs = &proto.Glyphs{
+	Major1: int32(proto.ShamanMajorGlyph_GlyphOfWaterShield),
+	Major2: int32(proto.ShamanMajorGlyph_GlyphOfRiptide),
+}
 
-// var FullConsumes = &proto.Consumes{
-// 	Flask:           proto.Flask_FlaskOfBlindingLight,
-// 	Food:            proto.Food_FoodBlackenedBasilisk,
-// 	DefaultPotion:   proto.Potions_SuperManaPotion,
-// 	PrepopPotion:    proto.Potions_DestructionPotion,
-// 	DefaultConjured: proto.Conjured_ConjuredDarkRune,
-// }
+var FullConsumesSpec = &proto.ConsumesSpec{
+	FlaskId:  76085, // Flask of the Warm Sun
+	FoodId:   74650, // Mogu Fish Stew
+	PotId:    76093, // Potion of the Jade Serpent
+	PrepotId: 76093, // Potion of the Jade Serpent
+}
 
-// var DefaultRotation = core.APLRotationFromJsonString(`{
-// 	"type": "TypeAPL",
-// 	"priorityList": [
-// 		{"action":{"autocastOtherCooldowns":{}}}
-// 	]
-// }`)
+var PlayerOptionsStandard = &proto.Player_RestorationShaman{
+	RestorationShaman: &proto.RestorationShaman{
+		Options: &proto.RestorationShaman_Options{
+			ClassOptions: &proto.ShamanOptions{
+				Shield: proto.ShamanShield_WaterShield,
+			},
+		},
+	},
+}
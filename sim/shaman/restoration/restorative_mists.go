@@ -0,0 +1,47 @@
+package restoration
+
+import (
+	"github.com/wowsims/mop/sim/core"
+)
+
+// While Ascendance is active, all healing done is duplicated and distributed
+// evenly among all party and raid members within 20 yards.
+func (resto *RestorationShaman) registerRestorativeMists() {
+	var duplicatedHealing float64
+	restorativeMists := resto.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 114083},
+		SpellSchool: core.SpellSchoolNature,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagIgnoreModifiers | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			allies := sim.Raid.GetSmartHealTargets(&resto.Unit, len(sim.Raid.AllUnits))
+			amount := duplicatedHealing / float64(len(allies))
+			for _, unit := range allies {
+				spell.CalcAndDealHealing(sim, unit, amount, spell.OutcomeHealing)
+			}
+		},
+	})
+
+	duplicate := func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+		if result.Damage <= 0 || spell == restorativeMists {
+			return
+		}
+		duplicatedHealing = result.Damage
+		restorativeMists.Cast(sim, &resto.Unit)
+	}
+
+	resto.AscendanceAura.AttachDependentAura(resto.RegisterAura(core.Aura{
+		Label:    "Restorative Mists" + resto.Label,
+		Duration: core.NeverExpires,
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			duplicate(sim, spell, result)
+		},
+		OnPeriodicHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			duplicate(sim, spell, result)
+		},
+	}))
+}
@@ -0,0 +1,52 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/shaman"
+)
+
+/*
+When you cast Chain Heal or Riptide, you gain the Tidal Waves effect,
+which reduces the cast time of your Healing Wave and Greater Healing Wave spells by 30%
+and increases the critical effect chance of your Healing Surge spell by 30%.
+Lasts for 2 casts.
+*/
+func (resto *RestorationShaman) registerTidalWaves() {
+	consumerMask := shaman.SpellMaskHealingWave | shaman.SpellMaskGreaterHealingWave | shaman.SpellMaskHealingSurge
+
+	resto.TidalWavesAura = resto.RegisterAura(core.Aura{
+		Label:     "Tidal Waves" + resto.Label,
+		ActionID:  core.ActionID{SpellID: 53390},
+		Duration:  time.Second * 15,
+		MaxStacks: 2,
+	}).AttachSpellMod(core.SpellModConfig{
+		Kind:       core.SpellMod_CastTime_Pct,
+		ClassMask:  shaman.SpellMaskHealingWave | shaman.SpellMaskGreaterHealingWave,
+		FloatValue: -0.3,
+	}).AttachSpellMod(core.SpellModConfig{
+		Kind:       core.SpellMod_BonusCrit_Percent,
+		ClassMask:  shaman.SpellMaskHealingSurge,
+		FloatValue: 30,
+	}).AttachProcTrigger(core.ProcTrigger{
+		Callback:       core.CallbackOnCastComplete,
+		ClassSpellMask: consumerMask,
+
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			resto.TidalWavesAura.RemoveStack(sim)
+		},
+	})
+
+	core.MakeProcTriggerAura(&resto.Unit, core.ProcTrigger{
+		Name:           "Tidal Waves Trigger" + resto.Label,
+		ActionID:       core.ActionID{SpellID: 51564},
+		Callback:       core.CallbackOnCastComplete,
+		ClassSpellMask: shaman.SpellMaskChainHeal | shaman.SpellMaskRiptide,
+
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			resto.TidalWavesAura.Activate(sim)
+			resto.TidalWavesAura.SetStacks(sim, 2)
+		},
+	})
+}
@@ -45,7 +45,6 @@ func NewShaman(character *core.Character, talents string, selfBuffs SelfBuffs, t
 		ThunderstormInRange: thunderstormRange,
 		ClassSpellScaling:   core.GetClassSpellScalingCoefficient(proto.Class_ClassShaman),
 	}
-	// shaman.waterShieldManaMetrics = shaman.NewManaMetrics(core.ActionID{SpellID: 57960})
 
 	core.FillTalentsProto(shaman.Talents.ProtoReflect(), talents)
 
@@ -167,7 +166,6 @@ type Shaman struct {
 	SearingFlamesMultiplier float64
 
 	// Healing Spells
-	tidalWaveProc          *core.Aura
 	ancestralHealingAmount float64
 	AncestralAwakening     *core.Spell
 	HealingSurge           *core.Spell
@@ -178,8 +176,6 @@ type Shaman struct {
 	Riptide            *core.Spell
 	EarthShield        *core.Spell
 
-	waterShieldManaMetrics *core.ResourceMetrics
-
 	// Item sets
 	T14Ele4pc *core.Aura
 	T14Enh4pc *core.Aura
@@ -228,33 +224,14 @@ func (shaman *Shaman) Initialize() {
 	shaman.registerStormlashCD()
 }
 
+// Registers the healing spells used by Restoration.
 func (shaman *Shaman) RegisterHealingSpells() {
-	// shaman.registerAncestralHealingSpell()
-	// shaman.registerHealingSurgeSpell()
-	// shaman.registerHealingWaveSpell()
-	// shaman.registerRiptideSpell()
-	// shaman.registerEarthShieldSpell()
-	// shaman.registerChainHealSpell()
-
-	// if shaman.Talents.TidalWaves > 0 {
-	// 	shaman.tidalWaveProc = shaman.GetOrRegisterAura(core.Aura{
-	// 		Label:    "Tidal Wave Proc",
-	// 		ActionID: core.ActionID{SpellID: 53390},
-	// 		Duration: core.NeverExpires,
-	// 		OnReset: func(aura *core.Aura, sim *core.Simulation) {
-	// 			aura.Deactivate(sim)
-	// 		},
-	// 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-	// 			shaman.HealingWave.CastTimeMultiplier *= 0.7
-	// 			shaman.HealingSurge.BonusCritRating += core.CritRatingPerCritChance * 25
-	// 		},
-	// 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-	// 			shaman.HealingWave.CastTimeMultiplier /= 0.7
-	// 			shaman.HealingSurge.BonusCritRating -= core.CritRatingPerCritChance * 25
-	// 		},
-	// 		MaxStacks: 2,
-	// 	})
-	// }
+	shaman.registerAncestralAwakening()
+	shaman.registerHealingSurgeSpell()
+	shaman.registerHealingWaveSpell()
+	shaman.registerGreaterHealingWaveSpell()
+	shaman.registerRiptideSpell()
+	shaman.registerChainHealSpell()
 }
 
 func (shaman *Shaman) Reset(sim *core.Simulation) {
@@ -331,6 +308,14 @@ const (
 	SpellMaskElementalBlastOverload
 	SpellMaskStormlashTotem
 	SpellMaskBloodlust
+	SpellMaskAncestralAwakening
+	SpellMaskHealingSurge
+	SpellMaskHealingWave
+	SpellMaskGreaterHealingWave
+	SpellMaskChainHeal
+	SpellMaskRiptide
+	SpellMaskHealingRain
+	SpellMaskHealingTideTotem
 
 	SpellMaskStormstrike  = SpellMaskStormstrikeCast | SpellMaskStormstrikeDamage
 	SpellMaskFlameShock   = SpellMaskFlameShockDirect | SpellMaskFlameShockDot
@@ -341,4 +326,7 @@ const (
 	SpellMaskShock        = SpellMaskFlameShock | SpellMaskEarthShock | SpellMaskFrostShock
 	SpellMaskTotem        = SpellMaskMagmaTotem | SpellMaskSearingTotem | SpellMaskFireElementalTotem | SpellMaskEarthElementalTotem | SpellMaskStormlashTotem
 	SpellMaskInstantSpell = SpellMaskAscendance | SpellMaskFeralSpirit | SpellMaskUnleashElements | SpellMaskBloodlust
+
+	SpellMaskSingleTargetHeal = SpellMaskHealingSurge | SpellMaskHealingWave | SpellMaskGreaterHealingWave | SpellMaskRiptide
+	SpellMaskDirectHeal       = SpellMaskSingleTargetHeal | SpellMaskChainHeal
 )
@@ -356,7 +356,7 @@ func (shaman *Shaman) ApplyEarthlivingImbueToItem(item *core.Item) {
 }
 
 func (shaman *Shaman) RegisterEarthlivingImbue(procMask core.ProcMask) {
-	if procMask == core.ProcMaskEmpty && !shaman.ItemSwap.IsEnabled() {
+	if procMask == core.ProcMaskUnknown && !shaman.ItemSwap.IsEnabled() {
 		return
 	}
 
@@ -376,7 +376,7 @@ func (shaman *Shaman) RegisterEarthlivingImbue(procMask core.ProcMask) {
 			aura.Activate(sim)
 		},
 		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if spell != shaman.ChainHeal && spell != shaman.HealingSurge && spell != shaman.HealingWave && spell != shaman.Riptide {
+			if !spell.Matches(SpellMaskDirectHeal) {
 				return
 			}
 
@@ -0,0 +1,243 @@
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func init() {
+	sim.RegisterAll()
+}
+
+func testRaidSimRequest(iterations int32) *proto.RaidSimRequest {
+	items := make([]*proto.ItemSpec, 16)
+	for i := range items {
+		items[i] = &proto.ItemSpec{}
+	}
+
+	return &proto.RaidSimRequest{
+		Raid: core.SinglePlayerRaidProto(
+			&proto.Player{
+				Name:      "Warrior",
+				Race:      proto.Race_RaceHuman,
+				Class:     proto.Class_ClassWarrior,
+				Equipment: &proto.EquipmentSpec{Items: items},
+				Spec:      &proto.Player_ArmsWarrior{ArmsWarrior: &proto.ArmsWarrior{Options: &proto.ArmsWarrior_Options{ClassOptions: &proto.WarriorOptions{}}}},
+				Rotation:  &proto.APLRotation{Type: proto.APLRotation_TypeAPL},
+			},
+			&proto.PartyBuffs{},
+			&proto.RaidBuffs{},
+			&proto.Debuffs{},
+		),
+		Encounter: &proto.Encounter{
+			Duration: 60,
+			Targets:  []*proto.Target{{}},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations: iterations,
+			RandomSeed: 101,
+		},
+	}
+}
+
+type testCluster struct {
+	coordinator *Coordinator
+	server      *httptest.Server
+}
+
+func newTestCluster(t *testing.T, lease time.Duration) *testCluster {
+	return newTestClusterWithToken(t, lease, "")
+}
+
+func newTestClusterWithToken(t *testing.T, lease time.Duration, token string) *testCluster {
+	coordinator := NewCoordinator(lease, "", token)
+	mux := http.NewServeMux()
+	coordinator.RegisterHandlers(mux)
+	server := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		server.Close()
+		coordinator.Close()
+	})
+	return &testCluster{coordinator: coordinator, server: server}
+}
+
+// Starts a worker, returning a function which stops it like a crash.
+func (tc *testCluster) startWorker(t *testing.T, name string, concurrency int) context.CancelFunc {
+	return tc.startWorkerWithToken(t, name, concurrency, "")
+}
+
+func (tc *testCluster) startWorkerWithToken(t *testing.T, name string, concurrency int, token string) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	worker := &Worker{Coordinator: tc.server.URL, Name: name, Concurrency: concurrency, Token: token}
+	go worker.Run(ctx)
+	return cancel
+}
+
+func (tc *testCluster) waitForCapacity(t *testing.T, capacity int) {
+	deadline := time.Now().Add(10 * time.Second)
+	for tc.coordinator.Capacity() != capacity {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for capacity %d, got %d", capacity, tc.coordinator.Capacity())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDistributedRaidSimMatchesLocal(t *testing.T) {
+	tc := newTestCluster(t, time.Second)
+	for _, name := range []string{"a", "b", "c"} {
+		tc.startWorker(t, name, 1)
+	}
+	tc.waitForCapacity(t, 3)
+
+	request := testRaidSimRequest(90)
+	progress := make(chan *proto.ProgressMetrics, 1000)
+	result := tc.coordinator.RunRaidSim(request, progress, simsignals.CreateSignals())
+	if result.Error != nil {
+		t.Fatalf("Distributed sim failed: %s", result.Error.Message)
+	}
+
+	var final *proto.ProgressMetrics
+	for metrics := range progress {
+		final = metrics
+	}
+	if final == nil || final.FinalRaidResult != result {
+		t.Fatalf("Expected the final progress to contain the result")
+	}
+
+	// The same shards simmed locally.
+	splitRes := core.SplitSimRequestForConcurrency(request, 3)
+	var localResults []*proto.RaidSimResult
+	for _, shard := range splitRes.Requests {
+		localResults = append(localResults, core.RunRaidSim(shard))
+	}
+	expected := core.CombineConcurrentSimResults(localResults, false)
+
+	if result.IterationsDone != 90 {
+		t.Fatalf("Expected 90 iterations, got %d", result.IterationsDone)
+	}
+	if result.RaidMetrics.Dps.Avg != expected.RaidMetrics.Dps.Avg || result.RaidMetrics.Dps.Stdev != expected.RaidMetrics.Dps.Stdev {
+		t.Fatalf("Distributed DPS %0.3f ± %0.3f does not match local DPS %0.3f ± %0.3f", result.RaidMetrics.Dps.Avg, result.RaidMetrics.Dps.Stdev, expected.RaidMetrics.Dps.Avg, expected.RaidMetrics.Dps.Stdev)
+	}
+}
+
+func TestCoordinatorRequiresWorkerToken(t *testing.T) {
+	tc := newTestClusterWithToken(t, time.Second, "secret")
+
+	for _, path := range []string{"/worker/register", "/worker/poll", "/worker/heartbeat", "/worker/result"} {
+		response, err := http.Post(tc.server.URL+path, "application/x-protobuf", nil)
+		if err != nil {
+			t.Fatalf("POST %s failed: %s", path, err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusUnauthorized {
+			t.Errorf("POST %s without a token returned %s, want %d", path, response.Status, http.StatusUnauthorized)
+		}
+	}
+
+	tc.startWorkerWithToken(t, "wrong", 1, "guess")
+	tc.startWorkerWithToken(t, "right", 2, "secret")
+	tc.waitForCapacity(t, 2)
+
+	// Give the worker with the wrong token a few chances to register.
+	time.Sleep(3 * workerRetryDelay)
+	if status := tc.coordinator.WorkerStatus(); len(status) != 1 {
+		t.Fatalf("Expected only the worker with the right token to register, got %v", status)
+	}
+
+	result := tc.coordinator.RunTask(testRaidSimRequest(10), nil, simsignals.CreateSignals())
+	if result.Error != nil {
+		t.Fatalf("Task failed: %s", result.Error.Message)
+	}
+}
+
+func TestDistributedTaskSurvivesWorkerLoss(t *testing.T) {
+	tc := newTestCluster(t, 300*time.Millisecond)
+	stopA := tc.startWorker(t, "a", 1)
+	tc.waitForCapacity(t, 1)
+
+	request := testRaidSimRequest(200)
+	results := make(chan *proto.RaidSimResult, 1)
+	go func() {
+		results <- tc.coordinator.RunTask(request, nil, simsignals.CreateSignals())
+	}()
+
+	// Crash the worker as soon as it picked up the task.
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		tc.coordinator.mu.Lock()
+		assigned := len(tc.coordinator.tasks) == 1 && len(tc.coordinator.queue) == 0
+		tc.coordinator.mu.Unlock()
+		if assigned {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the task to be assigned")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	stopA()
+	tc.startWorker(t, "b", 1)
+
+	select {
+	case result := <-results:
+		if result.Error != nil {
+			t.Fatalf("Task failed: %s", result.Error.Message)
+		}
+		expected := core.RunRaidSim(request)
+		if result.IterationsDone != 200 || result.RaidMetrics.Dps.Avg != expected.RaidMetrics.Dps.Avg {
+			t.Fatalf("Expected %d iterations at %0.3f DPS, got %d at %0.3f", 200, expected.RaidMetrics.Dps.Avg, result.IterationsDone, result.RaidMetrics.Dps.Avg)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatalf("Timed out waiting for the task to be retried on another worker")
+	}
+
+	// The lost worker is dropped once its lease runs out.
+	tc.waitForCapacity(t, 1)
+}
+
+func TestDistributedBulkSim(t *testing.T) {
+	tc := newTestCluster(t, time.Second)
+	tc.startWorker(t, "a", 2)
+	tc.startWorker(t, "b", 2)
+	tc.waitForCapacity(t, 4)
+
+	baseSettings := testRaidSimRequest(50)
+	baseSettings.Raid.Parties[0].Players[0].TalentsString = "113332"
+	request := &proto.BulkSimRequest{
+		BaseSettings: baseSettings,
+		BulkSettings: &proto.BulkSettings{
+			IterationsPerCombo: 50,
+			SimTalents:         true,
+			TalentsToSim: []*proto.TalentLoadout{
+				{TalentsString: "113331", Name: "Avatar"},
+				{TalentsString: "113333", Name: "Storm Bolt"},
+			},
+		},
+	}
+
+	progress := make(chan *proto.ProgressMetrics, 1000)
+	tc.coordinator.BulkSimAsync(request, progress, "test-distributed-bulk-sim")
+
+	var result *proto.BulkSimResult
+	for metrics := range progress {
+		if metrics.FinalBulkResult != nil {
+			result = metrics.FinalBulkResult
+		}
+	}
+	if result == nil || result.Error != nil {
+		t.Fatalf("Bulk sim failed: %v", result.GetError().GetMessage())
+	}
+	if len(result.Results) != 3 || result.EquippedGearResult == nil {
+		t.Fatalf("Expected 3 ranked results and the equipped gear result, got %d", len(result.Results))
+	}
+}
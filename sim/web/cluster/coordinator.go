@@ -0,0 +1,521 @@
+// Package cluster distributes sims over several machines: workers register with a coordinator
+// over HTTP and long-poll it for raid sim tasks, e.g. the shards of an async raid sim or the
+// combos of a bulk sim, and post back the results.
+package cluster
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultLease = 15 * time.Second
+
+	// Number of times a task is handed out before it fails, e.g. because it crashes every worker.
+	maxTaskAttempts = 3
+
+	// How often running tasks report progress and check for aborts.
+	taskProgressInterval = 250 * time.Millisecond
+)
+
+// Coordinator hands raid sim tasks out to registered workers and collects their results.
+type Coordinator struct {
+	// Workers which are not heard from within the lease are dropped, and tasks which are not
+	// reported within the lease are handed to other workers.
+	lease time.Duration
+	// Workers with a different version are rejected, unless empty.
+	version string
+	// Shared secret every worker request must carry, unless empty.
+	token string
+
+	mu      sync.Mutex
+	workers map[string]*workerState
+	tasks   map[string]*task
+	queue   []*task
+	// Closed and replaced whenever a task is queued, waking up polling workers.
+	queued chan struct{}
+	closed chan struct{}
+}
+
+type workerState struct {
+	id          string
+	name        string
+	concurrency int32
+	lastSeen    time.Time
+	tasks       map[string]*task
+}
+
+type task struct {
+	id       string
+	request  *proto.RaidSimRequest
+	attempts int
+
+	// Worker currently running the task, nil while queued.
+	worker     *workerState
+	lastReport time.Time
+
+	completedIterations int32
+	finished            bool
+	done                chan *proto.RaidSimResult
+}
+
+// Creates a coordinator and starts watching worker leases. A lease of 0 uses DefaultLease. Worker
+// requests are only accepted with the given token, unless it is empty.
+func NewCoordinator(lease time.Duration, version string, token string) *Coordinator {
+	if lease <= 0 {
+		lease = DefaultLease
+	}
+
+	c := &Coordinator{
+		lease:   lease,
+		version: version,
+		token:   token,
+		workers: map[string]*workerState{},
+		tasks:   map[string]*task{},
+		queued:  make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+	go c.watchLeases()
+	return c
+}
+
+// Stops watching worker leases.
+func (c *Coordinator) Close() {
+	close(c.closed)
+}
+
+// Returns the number of tasks all registered workers run at once.
+func (c *Coordinator) Capacity() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	capacity := 0
+	for _, worker := range c.workers {
+		capacity += int(worker.concurrency)
+	}
+	return capacity
+}
+
+// Returns a short description of each registered worker.
+func (c *Coordinator) WorkerStatus() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var status []string
+	for _, worker := range c.workers {
+		status = append(status, fmt.Sprintf("%s (%s): %d/%d tasks, last seen %s ago", worker.name, worker.id, len(worker.tasks), worker.concurrency, time.Since(worker.lastSeen).Round(time.Millisecond)))
+	}
+	slices.Sort(status)
+	return status
+}
+
+// Adds the worker endpoints to the mux.
+func (c *Coordinator) RegisterHandlers(mux *http.ServeMux) {
+	mux.Handle("/worker/register", c.authorize(protoHandler(func() googleProto.Message { return &proto.WorkerRegisterRequest{} }, func(ctx context.Context, msg googleProto.Message) (googleProto.Message, error) {
+		return c.register(msg.(*proto.WorkerRegisterRequest))
+	})))
+	mux.Handle("/worker/poll", c.authorize(protoHandler(func() googleProto.Message { return &proto.WorkerPollRequest{} }, func(ctx context.Context, msg googleProto.Message) (googleProto.Message, error) {
+		return c.poll(ctx, msg.(*proto.WorkerPollRequest)), nil
+	})))
+	mux.Handle("/worker/heartbeat", c.authorize(protoHandler(func() googleProto.Message { return &proto.WorkerHeartbeatRequest{} }, func(ctx context.Context, msg googleProto.Message) (googleProto.Message, error) {
+		return c.heartbeat(msg.(*proto.WorkerHeartbeatRequest)), nil
+	})))
+	mux.Handle("/worker/result", c.authorize(protoHandler(func() googleProto.Message { return &proto.WorkerTaskResult{} }, func(ctx context.Context, msg googleProto.Message) (googleProto.Message, error) {
+		return c.result(msg.(*proto.WorkerTaskResult)), nil
+	})))
+}
+
+// Rejects requests which do not carry the coordinator's token.
+func (c *Coordinator) authorize(handler http.Handler) http.Handler {
+	if c.token == "" {
+		return handler
+	}
+	expected := []byte("Bearer " + c.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			http.Error(w, "Invalid worker token", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func protoHandler(newMsg func() googleProto.Message, handle func(context.Context, googleProto.Message) (googleProto.Message, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return
+		}
+		msg := newMsg()
+		if err := googleProto.Unmarshal(body, msg); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to parse request: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		result, err := handle(r.Context(), msg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		outbytes, err := googleProto.Marshal(result)
+		if err != nil {
+			log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Add("Content-Type", "application/x-protobuf")
+		w.Write(outbytes)
+	})
+}
+
+func (c *Coordinator) register(req *proto.WorkerRegisterRequest) (*proto.WorkerRegisterResponse, error) {
+	if c.version != "" && req.Version != c.version {
+		return nil, fmt.Errorf("worker version %q does not match coordinator version %q", req.Version, c.version)
+	}
+	if req.Concurrency <= 0 {
+		return nil, fmt.Errorf("worker concurrency must be positive, got %d", req.Concurrency)
+	}
+
+	worker := &workerState{
+		id:          uuid.NewString(),
+		name:        req.Name,
+		concurrency: req.Concurrency,
+		lastSeen:    time.Now(),
+		tasks:       map[string]*task{},
+	}
+
+	c.mu.Lock()
+	c.workers[worker.id] = worker
+	c.mu.Unlock()
+
+	log.Printf("Worker %s (%s) registered with %d concurrent tasks.", worker.name, worker.id, worker.concurrency)
+	return &proto.WorkerRegisterResponse{
+		WorkerId: worker.id,
+		LeaseMs:  int32(c.lease.Milliseconds()),
+	}, nil
+}
+
+// Hands the next queued task to the worker, waiting for up to half a lease for one.
+func (c *Coordinator) poll(ctx context.Context, req *proto.WorkerPollRequest) *proto.WorkerPollResponse {
+	timeout := time.NewTimer(c.lease / 2)
+	defer timeout.Stop()
+
+	for {
+		c.mu.Lock()
+		worker, ok := c.workers[req.WorkerId]
+		if !ok {
+			c.mu.Unlock()
+			return &proto.WorkerPollResponse{UnknownWorker: true}
+		}
+		worker.lastSeen = time.Now()
+
+		if len(c.queue) > 0 {
+			t := c.queue[0]
+			c.queue = c.queue[1:]
+			t.worker = worker
+			t.attempts++
+			t.lastReport = time.Now()
+			worker.tasks[t.id] = t
+			c.mu.Unlock()
+			return &proto.WorkerPollResponse{Task: &proto.WorkerTask{TaskId: t.id, Request: t.request}}
+		}
+		queued := c.queued
+		c.mu.Unlock()
+
+		select {
+		case <-queued:
+		case <-timeout.C:
+			return &proto.WorkerPollResponse{}
+		case <-ctx.Done():
+			return &proto.WorkerPollResponse{}
+		}
+	}
+}
+
+func (c *Coordinator) heartbeat(req *proto.WorkerHeartbeatRequest) *proto.WorkerHeartbeatResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	worker, ok := c.workers[req.WorkerId]
+	if !ok {
+		return &proto.WorkerHeartbeatResponse{UnknownWorker: true}
+	}
+	worker.lastSeen = time.Now()
+
+	response := &proto.WorkerHeartbeatResponse{}
+	for _, progress := range req.Progress {
+		t, ok := worker.tasks[progress.TaskId]
+		if !ok {
+			// Aborted, finished or handed to another worker in the meantime.
+			response.AbortedTaskIds = append(response.AbortedTaskIds, progress.TaskId)
+			continue
+		}
+		t.lastReport = worker.lastSeen
+		t.completedIterations = progress.CompletedIterations
+	}
+	return response
+}
+
+func (c *Coordinator) result(req *proto.WorkerTaskResult) *proto.WorkerTaskResultResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	worker, ok := c.workers[req.WorkerId]
+	if ok {
+		worker.lastSeen = time.Now()
+	}
+
+	// Results are accepted from any worker, even if the task was handed to another one in the
+	// meantime, since all of them run the same request.
+	if t, ok := c.tasks[req.TaskId]; ok && req.Result != nil {
+		c.finish(t, req.Result)
+	}
+	return &proto.WorkerTaskResultResponse{UnknownWorker: !ok}
+}
+
+// Drops workers and requeues tasks whose lease ran out.
+func (c *Coordinator) watchLeases() {
+	ticker := time.NewTicker(c.lease / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.closed:
+			return
+		case <-ticker.C:
+		}
+
+		c.mu.Lock()
+		now := time.Now()
+		for id, worker := range c.workers {
+			if now.Sub(worker.lastSeen) > c.lease {
+				log.Printf("Lost worker %s (%s), requeueing its %d tasks.", worker.name, worker.id, len(worker.tasks))
+				delete(c.workers, id)
+				for _, t := range worker.tasks {
+					c.requeue(t, fmt.Sprintf("lost worker %s", worker.name))
+				}
+			}
+		}
+		for _, worker := range c.workers {
+			for _, t := range worker.tasks {
+				if now.Sub(t.lastReport) > c.lease {
+					c.requeue(t, fmt.Sprintf("worker %s stopped reporting", worker.name))
+				}
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+// The following task functions must be called with the lock held.
+
+func (c *Coordinator) enqueue(t *task) {
+	c.queue = append(c.queue, t)
+	close(c.queued)
+	c.queued = make(chan struct{})
+}
+
+func (c *Coordinator) unassign(t *task) {
+	if t.worker != nil {
+		delete(t.worker.tasks, t.id)
+		t.worker = nil
+	}
+}
+
+func (c *Coordinator) requeue(t *task, reason string) {
+	c.unassign(t)
+	t.completedIterations = 0
+	if t.attempts >= maxTaskAttempts {
+		c.finish(t, &proto.RaidSimResult{Error: &proto.ErrorOutcome{
+			Message: fmt.Sprintf("Sim task failed after %d attempts, last one with %s.", t.attempts, reason),
+		}})
+		return
+	}
+	c.enqueue(t)
+}
+
+func (c *Coordinator) finish(t *task, result *proto.RaidSimResult) {
+	if t.finished {
+		return
+	}
+	t.finished = true
+	c.unassign(t)
+	c.queue = slices.DeleteFunc(c.queue, func(queued *task) bool { return queued == t })
+	delete(c.tasks, t.id)
+	t.done <- result
+}
+
+// Runs the request as a single task on one of the workers, calling onProgress whenever the
+// number of completed iterations changes.
+func (c *Coordinator) runTask(request *proto.RaidSimRequest, onProgress func(completedIterations int32), aborted func() bool) *proto.RaidSimResult {
+	t := &task{
+		id:      uuid.NewString(),
+		request: request,
+		done:    make(chan *proto.RaidSimResult, 1),
+	}
+
+	c.mu.Lock()
+	c.tasks[t.id] = t
+	c.enqueue(t)
+	c.mu.Unlock()
+
+	ticker := time.NewTicker(taskProgressInterval)
+	defer ticker.Stop()
+
+	var lastCompleted int32
+	for {
+		select {
+		case result := <-t.done:
+			return result
+		case <-ticker.C:
+		}
+
+		c.mu.Lock()
+		if aborted() {
+			c.finish(t, &proto.RaidSimResult{Error: &proto.ErrorOutcome{Type: proto.ErrorOutcomeType_ErrorOutcomeAborted}})
+		}
+		completed := t.completedIterations
+		c.mu.Unlock()
+
+		if completed != lastCompleted {
+			lastCompleted = completed
+			onProgress(completed)
+		}
+	}
+}
+
+// Runs the request as a single task on one of the workers, see core.RaidSimRunner.
+func (c *Coordinator) RunTask(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.RaidSimResult {
+	totalIterations := request.SimOptions.Iterations
+	result := c.runTask(request, func(completedIterations int32) {
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{
+				TotalIterations:     totalIterations,
+				CompletedIterations: completedIterations,
+			}
+		}
+	}, signals.Abort.IsTriggered)
+
+	if progress != nil {
+		progress <- &proto.ProgressMetrics{
+			TotalIterations:     totalIterations,
+			CompletedIterations: totalIterations,
+			FinalRaidResult:     result,
+		}
+		close(progress)
+	}
+	return result
+}
+
+// Splits the request into one shard per worker slot and runs them on the workers, combining the
+// results like a concurrent local sim. See core.RaidSimRunner.
+func (c *Coordinator) RunRaidSim(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) (result *proto.RaidSimResult) {
+	defer func() {
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{
+				TotalIterations:     request.GetSimOptions().GetIterations(),
+				CompletedIterations: result.IterationsDone,
+				FinalRaidResult:     result,
+			}
+			close(progress)
+		}
+	}()
+
+	splitRes := core.SplitSimRequestForConcurrency(request, int32(max(1, c.Capacity())))
+	if splitRes.ErrorResult != "" {
+		return &proto.RaidSimResult{Error: &proto.ErrorOutcome{Message: splitRes.ErrorResult}}
+	}
+
+	var progressMu sync.Mutex
+	completed := make([]int32, len(splitRes.Requests))
+	results := make([]*proto.RaidSimResult, len(splitRes.Requests))
+
+	// A failed shard aborts the others.
+	var failed atomic.Bool
+	aborted := func() bool {
+		return failed.Load() || signals.Abort.IsTriggered()
+	}
+
+	var wg sync.WaitGroup
+	for i, shard := range splitRes.Requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.runTask(shard, func(completedIterations int32) {
+				progressMu.Lock()
+				defer progressMu.Unlock()
+				completed[i] = completedIterations
+				if progress != nil {
+					total := int32(0)
+					for _, done := range completed {
+						total += done
+					}
+					progress <- &proto.ProgressMetrics{
+						TotalIterations:     request.SimOptions.Iterations,
+						CompletedIterations: total,
+					}
+				}
+			}, aborted)
+			if results[i].Error != nil {
+				failed.Store(true)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Report the error which caused the other shards to abort.
+	for _, res := range results {
+		if res.Error != nil && res.Error.Type != proto.ErrorOutcomeType_ErrorOutcomeAborted {
+			return res
+		}
+	}
+	for _, res := range results {
+		if res.Error != nil {
+			return res
+		}
+	}
+	return core.CombineConcurrentSimResults(results, request.SimOptions.Debug)
+}
+
+// Async APIs matching the ones of the core, running their sims on the workers.
+
+func (c *Coordinator) RunRaidSimAsync(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalRaidResult: &proto.RaidSimResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		c.RunRaidSim(request, progress, signals)
+	}()
+}
+
+func (c *Coordinator) StatWeightsAsync(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	core.StatWeightsAsyncWithRunner(request, progress, requestId, c.RunRaidSim)
+}
+
+func (c *Coordinator) BulkSimAsync(request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	core.RunBulkSimAsyncWithRunner(request, progress, requestId, c.RunTask, max(1, c.Capacity()))
+}
@@ -0,0 +1,249 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Delay before retrying requests to an unreachable coordinator.
+const workerRetryDelay = time.Second
+
+// Worker runs raid sim tasks for a coordinator.
+type Worker struct {
+	// Base URL of the coordinator, e.g. http://localhost:3333.
+	Coordinator string
+	// Name shown by the coordinator, defaults to the host name.
+	Name string
+	// Number of tasks run at once, defaults to the number of CPUs.
+	Concurrency int
+	Version     string
+	// Shared secret of the coordinator, if it requires one.
+	Token string
+
+	client  http.Client
+	mu      sync.Mutex
+	running map[string]*runningTask
+}
+
+type runningTask struct {
+	signals             simsignals.Signals
+	completedIterations atomic.Int32
+}
+
+// Registers with the coordinator and runs its tasks until the context is done, registering again
+// whenever the coordinator loses track of the worker, e.g. after a restart.
+func (w *Worker) Run(ctx context.Context) error {
+	if w.Name == "" {
+		w.Name, _ = os.Hostname()
+	}
+	if w.Concurrency <= 0 {
+		w.Concurrency = runtime.NumCPU()
+	}
+	w.running = map[string]*runningTask{}
+
+	for {
+		registration := &proto.WorkerRegisterResponse{}
+		err := w.post(ctx, "/worker/register", &proto.WorkerRegisterRequest{
+			Name:        w.Name,
+			Concurrency: int32(w.Concurrency),
+			Version:     w.Version,
+		}, registration)
+		if err == nil {
+			log.Printf("Registered with coordinator %s as %s.", w.Coordinator, registration.WorkerId)
+			w.serve(ctx, registration)
+		} else if ctx.Err() == nil {
+			log.Printf("Failed to register with coordinator %s: %s", w.Coordinator, err)
+			sleepCtx(ctx, workerRetryDelay)
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// Polls for tasks and sends heartbeats until the context is done or the coordinator does not know
+// the worker anymore.
+func (w *Worker) serve(ctx context.Context, registration *proto.WorkerRegisterResponse) {
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Running tasks are aborted when the worker stops.
+	go func() {
+		<-sessionCtx.Done()
+		if ctx.Err() != nil {
+			w.mu.Lock()
+			for _, t := range w.running {
+				t.signals.Abort.Trigger()
+			}
+			w.mu.Unlock()
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.sendHeartbeats(sessionCtx, cancel, registration)
+	}()
+
+	for i := 0; i < w.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for sessionCtx.Err() == nil {
+				poll := &proto.WorkerPollResponse{}
+				if err := w.post(sessionCtx, "/worker/poll", &proto.WorkerPollRequest{WorkerId: registration.WorkerId}, poll); err != nil {
+					if sessionCtx.Err() == nil {
+						log.Printf("Failed to poll coordinator %s: %s", w.Coordinator, err)
+						sleepCtx(sessionCtx, workerRetryDelay)
+					}
+					continue
+				}
+				if poll.UnknownWorker {
+					cancel()
+					return
+				}
+				if poll.Task != nil {
+					w.runTask(ctx, registration.WorkerId, poll.Task)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (w *Worker) sendHeartbeats(ctx context.Context, cancel context.CancelFunc, registration *proto.WorkerRegisterResponse) {
+	ticker := time.NewTicker(time.Duration(registration.LeaseMs) * time.Millisecond / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		request := &proto.WorkerHeartbeatRequest{WorkerId: registration.WorkerId}
+		w.mu.Lock()
+		for id, t := range w.running {
+			request.Progress = append(request.Progress, &proto.WorkerTaskProgress{
+				TaskId:              id,
+				CompletedIterations: t.completedIterations.Load(),
+			})
+		}
+		w.mu.Unlock()
+
+		response := &proto.WorkerHeartbeatResponse{}
+		if err := w.post(ctx, "/worker/heartbeat", request, response); err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Failed to send heartbeat to coordinator %s: %s", w.Coordinator, err)
+			}
+			continue
+		}
+		if response.UnknownWorker {
+			cancel()
+			return
+		}
+
+		w.mu.Lock()
+		for _, id := range response.AbortedTaskIds {
+			if t, ok := w.running[id]; ok {
+				t.signals.Abort.Trigger()
+			}
+		}
+		w.mu.Unlock()
+	}
+}
+
+func (w *Worker) runTask(ctx context.Context, workerId string, workerTask *proto.WorkerTask) {
+	t := &runningTask{signals: simsignals.CreateSignals()}
+	w.mu.Lock()
+	w.running[workerTask.TaskId] = t
+	w.mu.Unlock()
+
+	progress := make(chan *proto.ProgressMetrics, 100)
+	go core.RunSim(workerTask.Request, progress, t.signals)
+
+	var result *proto.RaidSimResult
+	for metrics := range progress {
+		t.completedIterations.Store(metrics.CompletedIterations)
+		if metrics.FinalRaidResult != nil {
+			result = metrics.FinalRaidResult
+		}
+	}
+
+	w.mu.Lock()
+	delete(w.running, workerTask.TaskId)
+	w.mu.Unlock()
+
+	// Nobody waits for aborted tasks, and the coordinator hands tasks of stopped workers to others.
+	if result == nil || t.signals.Abort.IsTriggered() || ctx.Err() != nil {
+		return
+	}
+
+	for attempt := 0; attempt < maxTaskAttempts; attempt++ {
+		err := w.post(ctx, "/worker/result", &proto.WorkerTaskResult{
+			WorkerId: workerId,
+			TaskId:   workerTask.TaskId,
+			Result:   result,
+		}, &proto.WorkerTaskResultResponse{})
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		log.Printf("Failed to send result to coordinator %s: %s", w.Coordinator, err)
+		sleepCtx(ctx, workerRetryDelay)
+	}
+}
+
+func (w *Worker) post(ctx context.Context, path string, request googleProto.Message, response googleProto.Message) error {
+	body, err := googleProto.Marshal(request)
+	if err != nil {
+		return err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(w.Coordinator, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/x-protobuf")
+	if w.Token != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+w.Token)
+	}
+
+	httpResponse, err := w.client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	data, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", httpResponse.Status, strings.TrimSpace(string(data)))
+	}
+	return googleProto.Unmarshal(data, response)
+}
+
+func sleepCtx(ctx context.Context, duration time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(duration):
+	}
+}
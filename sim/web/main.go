@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"runtime"
 	"runtime/pprof"
 	"strings"
 	"sync"
@@ -25,6 +27,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	proto "github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/web/cluster"
 
 	googleProto "google.golang.org/protobuf/proto"
 )
@@ -48,10 +51,19 @@ func main() {
 	var host = flag.String("host", "localhost:3333", "URL to host the interface on.")
 	var launch = flag.Bool("launch", true, "auto launch browser")
 	var skipVersionCheck = flag.Bool("nvc", false, "set true to skip version check")
+	var distributed = flag.Bool("distributed", false, "Accept sim workers and fan async sims out to them, see --worker.")
+	var worker = flag.Bool("worker", false, "Run sims for a coordinator started with --distributed instead of hosting the interface.")
+	var coordinator = flag.String("coordinator", "http://localhost:3333", "URL of the coordinator to run sims for. Only used with --worker.")
+	var workerConcurrency = flag.Int("worker-concurrency", runtime.NumCPU(), "Number of sims a worker runs at once.")
+	var clusterToken = flag.String("cluster-token", os.Getenv("WOWSIM_CLUSTER_TOKEN"), "Shared secret workers must send to the coordinator. Defaults to $WOWSIM_CLUSTER_TOKEN.")
 
 	flag.Parse()
 
 	fmt.Printf("Version: %s\n", Version)
+	if *worker {
+		runWorker(*coordinator, *workerConcurrency, *clusterToken)
+		return
+	}
 	if !*skipVersionCheck && Version != "development" {
 		go func() {
 			resp, err := http.Get("https://api.github.com/repos/wowsims/mop/releases/latest")
@@ -86,6 +98,9 @@ func main() {
 		progMut:         sync.RWMutex{},
 		asyncProgresses: map[string]*asyncProgress{},
 	}
+	if *distributed {
+		s.useCoordinator(*host, *workerConcurrency, *clusterToken)
+	}
 	s.runServer(*useFS, *host, *launch, *simName, *wasm, bufio.NewReader(os.Stdin))
 }
 
@@ -114,6 +129,12 @@ var handlers = map[string]apiHandler{
 	"/bulkSimCombos": {msg: func() googleProto.Message { return &proto.BulkSimCombosRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.RunBulkCombos(msg.(*proto.BulkSimCombosRequest))
 	}},
+	"/optimizeReforges": {msg: func() googleProto.Message { return &proto.ReforgeOptimizerRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.OptimizeReforges(msg.(*proto.ReforgeOptimizerRequest))
+	}},
+	"/statScaling": {msg: func() googleProto.Message { return &proto.StatScalingRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.StatScaling(msg.(*proto.StatScalingRequest))
+	}},
 }
 
 var asyncAPIHandlers = map[string]asyncAPIHandler{
@@ -131,6 +152,53 @@ var asyncAPIHandlers = map[string]asyncAPIHandler{
 type server struct {
 	progMut         sync.RWMutex
 	asyncProgresses map[string]*asyncProgress
+	coordinator     *cluster.Coordinator
+}
+
+// Fans async sims out to registered workers. This machine runs sims as a worker of its own as well.
+func (s *server) useCoordinator(host string, localConcurrency int, token string) {
+	if token == "" {
+		log.Printf("Accepting sim workers without a token, anyone who can reach %s can run as a worker. Set --cluster-token to require one.", host)
+	}
+	s.coordinator = cluster.NewCoordinator(cluster.DefaultLease, Version, token)
+	s.coordinator.RegisterHandlers(http.DefaultServeMux)
+
+	asyncAPIHandlers["/raidSimAsync"] = asyncAPIHandler{msg: func() googleProto.Message { return &proto.RaidSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		s.coordinator.RunRaidSimAsync(msg.(*proto.RaidSimRequest), reporter, requestId)
+	}}
+	asyncAPIHandlers["/statWeightsAsync"] = asyncAPIHandler{msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		s.coordinator.StatWeightsAsync(msg.(*proto.StatWeightsRequest), reporter, requestId)
+	}}
+	asyncAPIHandlers["/bulkSimAsync"] = asyncAPIHandler{msg: func() googleProto.Message { return &proto.BulkSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		s.coordinator.BulkSimAsync(msg.(*proto.BulkSimRequest), reporter, requestId)
+	}}
+
+	if strings.HasPrefix(host, ":") {
+		host = "localhost" + host
+	}
+	localWorker := &cluster.Worker{
+		Coordinator: "http://" + host,
+		Name:        "local",
+		Concurrency: localConcurrency,
+		Version:     Version,
+		Token:       token,
+	}
+	go localWorker.Run(context.Background())
+}
+
+func runWorker(coordinator string, concurrency int, token string) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	worker := &cluster.Worker{
+		Coordinator: coordinator,
+		Concurrency: concurrency,
+		Version:     Version,
+		Token:       token,
+	}
+	log.Printf("Running sims for coordinator %s with %d concurrent sims.", coordinator, concurrency)
+	worker.Run(ctx)
+	log.Printf("Shutting down")
 }
 
 type apiHandler struct {
@@ -412,10 +480,20 @@ func (s *server) runServer(useFS bool, host string, launchBrowser bool, simName
 				fmt.Printf("Process: %s (%d sims)\n\t  Progress: %d/%d\n", v.id, latest.TotalSims, latest.CompletedIterations, latest.TotalIterations)
 			}
 			s.progMut.RUnlock()
+		case "workers":
+			if s.coordinator == nil {
+				fmt.Printf("Not running as a coordinator, start with --distributed to accept workers.\n")
+				break
+			}
+			status := s.coordinator.WorkerStatus()
+			fmt.Printf("Total Workers: %d (%d concurrent sims)\n", len(status), s.coordinator.Capacity())
+			for _, worker := range status {
+				fmt.Printf("\t%s\n", worker)
+			}
 		case "quit":
 			os.Exit(1)
 		case "?":
-			fmt.Printf("Commands:\n\tsims - Lists all active async sims running currently.\n\tprofile - start a CPU profile for debugging performance\n\theap_profile - capture a memory snapshot for debugging performance\n\tquit - exits\n\n")
+			fmt.Printf("Commands:\n\tsims - Lists all active async sims running currently.\n\tworkers - Lists all registered sim workers when running with --distributed.\n\tprofile - start a CPU profile for debugging performance\n\theap_profile - capture a memory snapshot for debugging performance\n\tquit - exits\n\n")
 		case "":
 			// nothing.
 		default:
@@ -29,6 +29,7 @@ func (gem *Gem) ToProto() *proto.UIGem {
 		Unique:                  gem.Flags0.Has(UNIQUE_EQUIPPABLE),
 		Stats:                   gem.GetItemEnchantmentStats().ToProtoArray(),
 		DisabledInChallengeMode: gem.IsDisabledInChallengeMode(),
+		HasEffect:               gem.HasGemEffect(),
 	}
 	if gem.IsJc {
 		uiGem.RequiredProfession = proto.Profession_Jewelcrafting
@@ -50,6 +51,24 @@ func (gem *Gem) IsDisabledInChallengeMode() bool {
 
 	return false
 }
+// Returns true if the gem does anything beyond the stats in GetItemEnchantmentStats.
+func (gem *Gem) HasGemEffect() bool {
+	for idx, effect := range gem.Effects {
+		switch effect {
+		case ITEM_ENCHANTMENT_COMBAT_SPELL, ITEM_ENCHANTMENT_USE_SPELL:
+			return true
+		case ITEM_ENCHANTMENT_EQUIP_SPELL:
+			for _, spellEffect := range dbcInstance.SpellEffects[gem.EffectArgs[idx]] {
+				if spellEffect.EffectType != E_APPLY_AURA || spellEffect.EffectAura != A_MOD_STAT {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
 func (gem *Gem) GetItemEnchantmentStats() stats.Stats {
 	stats := stats.Stats{}
 	processEnchantmentEffects(gem.Effects, gem.EffectArgs, gem.EffectPoints, &stats, false)
@@ -6,6 +6,7 @@ import {
 	APLActionActivateAura,
 	APLActionActivateAuraWithStacks,
 	APLActionAutocastOtherCooldowns,
+	APLActionCallList,
 	APLActionCancelAura,
 	APLActionCastAllStatBuffCooldowns,
 	APLActionCastFriendlySpell,
@@ -23,6 +24,7 @@ import {
 	APLActionMultidot,
 	APLActionMultishield,
 	APLActionResetSequence,
+	APLActionRunList,
 	APLActionSchedule,
 	APLActionSequence,
 	APLActionStrictMultidot,
@@ -594,6 +596,28 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 		newValue: APLActionStrictSequence.create,
 		fields: [actionListFieldConfig('actions')],
 	}),
+	['callList']: inputBuilder({
+		label: 'Call Action List',
+		submenu: ['Action Lists'],
+		shortDescription: 'Performs the first ready action of a named action list, or continues with the next action if none is ready.',
+		fullDescription: `
+			<p>Use the <b>name</b> field to refer to one of the action lists of the rotation.</p>
+		`,
+		includeIf: (_, isPrepull: boolean) => !isPrepull,
+		newValue: APLActionCallList.create,
+		fields: [AplHelpers.stringFieldConfig('name')],
+	}),
+	['runList']: inputBuilder({
+		label: 'Run Action List',
+		submenu: ['Action Lists'],
+		shortDescription: 'Performs the first ready action of a named action list, without continuing with the next action if none is ready.',
+		fullDescription: `
+			<p>Actions after this one are never evaluated while its condition is met.</p>
+		`,
+		includeIf: (_, isPrepull: boolean) => !isPrepull,
+		newValue: APLActionRunList.create,
+		fields: [AplHelpers.stringFieldConfig('name')],
+	}),
 	['changeTarget']: inputBuilder({
 		label: 'Change Target',
 		submenu: ['Misc'],
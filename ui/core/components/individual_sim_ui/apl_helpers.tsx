@@ -460,6 +460,12 @@ export class APLUnitPicker extends UnitPicker<Player<any>> {
 				iconUrl: 'fa-bullseye',
 				text: 'Current Target',
 			};
+		} else if (ref.type == UnitType.Owner) {
+			return {
+				value: ref,
+				iconUrl: 'fa-user',
+				text: 'Owner',
+			};
 		} else if (ref.type == UnitType.Player) {
 			const player = thisPlayer.sim.raid.getPlayer(ref.index);
 			if (player) {
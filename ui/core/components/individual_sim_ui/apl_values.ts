@@ -102,6 +102,7 @@ import {
 	APLValueTrinketProcsMinRemainingTime,
 	APLValueUnitDistance,
 	APLValueUnitIsMoving,
+	APLValueVariableRef,
 	APLValueWarlockHandOfGuldanInFlight,
 	APLValueWarlockHauntInFlight,
 } from '../../proto/apl.js';
@@ -1379,6 +1380,14 @@ const valueKindFactories: { [f in ValidAPLValueKind]: ValueKindConfig<APLValueIm
 		fields: [AplHelpers.stringFieldConfig('sequenceName')],
 	}),
 
+	variableRef: inputBuilder({
+		label: 'Variable',
+		submenu: ['Variables'],
+		shortDescription: 'Returns the value of a named variable of the rotation.',
+		newValue: APLValueVariableRef.create,
+		fields: [AplHelpers.stringFieldConfig('name')],
+	}),
+
 	// Class/spec specific values
 	totemRemainingTime: inputBuilder({
 		label: 'Totem Remaining Time',
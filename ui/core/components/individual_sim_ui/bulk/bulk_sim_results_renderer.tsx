@@ -32,6 +32,12 @@ export default class BulkSimResultRenderer extends Component {
 				<div className="results-sim">
 					<div className="results-sim-dps damage-metrics">
 						<span className="topline-result-avg">{this.formatDps(result.unitMetrics!.dps!.avg)}</span>
+						{result.iterations > 0 && (
+							<span className="topline-result-stdev" title={`95% confidence interval after ${result.iterations} iterations`}>
+								(<i className="fas fa-plus-minus fa-xs"></i>
+								{this.formatDps((result.dpsCiUpper - result.dpsCiLower) / 2)})
+							</span>
+						)}
 						<div className="results-reference">
 							<span ref={dpsDeltaRef} className={clsx('results-reference-diff', dpsDelta >= 0 ? 'positive' : 'negative')} />
 						</div>
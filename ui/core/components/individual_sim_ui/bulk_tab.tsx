@@ -651,7 +651,8 @@ export class BulkTab extends SimTab {
 			new BooleanPicker<BulkTab>(this.booleanSettingsContainer, this, {
 				id: 'bulk-fast-mode',
 				label: 'Fast Mode',
-				labelTooltip: 'Fast mode reduces accuracy but will run faster.',
+				labelTooltip:
+					'Fast mode races combos against each other and only keeps simming combos that could still be the best one. Results that were dropped early are less accurate.',
 				changedEvent: _modObj => this.settingsChangedEmitter,
 				getValue: _modObj => this.fastMode,
 				setValue: (_, _modObj, newValue: boolean) => {
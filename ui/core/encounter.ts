@@ -1,7 +1,7 @@
 import * as Mechanics from './constants/mechanics';
 import { CURRENT_API_VERSION } from './constants/other';
 import { UnitMetadataList } from './player';
-import { Encounter as EncounterProto, MobType, PresetEncounter, PresetTarget, SpellSchool, Stat, Target as TargetProto, TargetInput } from './proto/common';
+import { Encounter as EncounterProto, EncounterScript, MobType, PresetEncounter, PresetTarget, SpellSchool, Stat, Target as TargetProto, TargetInput } from './proto/common';
 import { Stats } from './proto_utils/stats';
 import { Sim } from './sim';
 import { EventID, TypedEvent } from './typed_event';
@@ -18,12 +18,14 @@ export class Encounter {
 	private executeProportion45 = 0.45;
 	private executeProportion90 = 0.9;
 	private useHealth = false;
+	private script: EncounterScript | undefined = undefined;
 	targets: Array<TargetProto>;
 	targetsMetadata: UnitMetadataList;
 
 	readonly targetsChangeEmitter = new TypedEvent<void>();
 	readonly durationChangeEmitter = new TypedEvent<void>();
 	readonly executeProportionChangeEmitter = new TypedEvent<void>();
+	readonly scriptChangeEmitter = new TypedEvent<void>();
 
 	// Emits when any of the above emitters emit.
 	readonly changeEmitter = new TypedEvent<void>();
@@ -33,7 +35,7 @@ export class Encounter {
 		this.targets = [Encounter.defaultTargetProto()];
 		this.targetsMetadata = new UnitMetadataList();
 
-		[this.targetsChangeEmitter, this.durationChangeEmitter, this.executeProportionChangeEmitter].forEach(emitter =>
+		[this.targetsChangeEmitter, this.durationChangeEmitter, this.executeProportionChangeEmitter, this.scriptChangeEmitter].forEach(emitter =>
 			emitter.on(eventID => this.changeEmitter.emit(eventID)),
 		);
 	}
@@ -118,6 +120,16 @@ export class Encounter {
 		this.executeProportionChangeEmitter.emit(eventID);
 	}
 
+	getScript(): EncounterScript | undefined {
+		return this.script;
+	}
+	setScript(eventID: EventID, newScript: EncounterScript | undefined) {
+		if (EncounterScript.equals(newScript, this.script)) return;
+
+		this.script = newScript;
+		this.scriptChangeEmitter.emit(eventID);
+	}
+
 	matchesPreset(preset: PresetEncounter): boolean {
 		return preset.targets.length == this.targets.length && this.targets.every((t, i) => TargetProto.equals(t, preset.targets[i].target));
 	}
@@ -143,6 +155,7 @@ export class Encounter {
 			executeProportion90: this.executeProportion90,
 			useHealth: this.useHealth,
 			targets: this.targets,
+			script: this.script,
 			apiVersion: CURRENT_API_VERSION,
 		});
 	}
@@ -160,6 +173,7 @@ export class Encounter {
 			this.setExecuteProportion45(eventID, proto.executeProportion45);
 			this.setExecuteProportion90(eventID, proto.executeProportion90);
 			this.setUseHealth(eventID, proto.useHealth);
+			this.setScript(eventID, proto.script);
 			this.targets = proto.targets;
 			this.targetsChangeEmitter.emit(eventID);
 		});
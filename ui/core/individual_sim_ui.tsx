@@ -37,7 +37,7 @@ import { getSpecLaunchStatus, LaunchStatus, simLaunchStatuses } from './launched
 import { Player, PlayerConfig, registerSpecConfig as registerPlayerConfig } from './player';
 import { PlayerSpecs } from './player_specs';
 import { PresetBuild, PresetEpWeights, PresetGear, PresetItemSwap, PresetRotation, PresetSettings } from './preset_utils';
-import { StatWeightsResult } from './proto/api';
+import { StatWeightsResult, UnsimulatedEffectType } from './proto/api';
 import { APLRotation, APLRotation_Type as APLRotationType } from './proto/apl';
 import {
 	ConsumesSpec,
@@ -74,6 +74,13 @@ const SAVED_ROTATION_STORAGE_KEY = '__savedRotation__';
 const SAVED_SETTINGS_STORAGE_KEY = '__savedSettings__';
 const SAVED_TALENTS_STORAGE_KEY = '__savedTalents__';
 
+const unsimulatedEffectTypeNames: Map<UnsimulatedEffectType, string> = new Map([
+	[UnsimulatedEffectType.UnsimulatedEffectItem, 'Item'],
+	[UnsimulatedEffectType.UnsimulatedEffectEnchant, 'Enchant'],
+	[UnsimulatedEffectType.UnsimulatedEffectGem, 'Gem'],
+	[UnsimulatedEffectType.UnsimulatedEffectTinker, 'Tinker'],
+]);
+
 export type InputConfig<ModObject> =
 	| InputHelpers.TypedBooleanPickerConfig<ModObject>
 	| InputHelpers.TypedNumberPickerConfig<ModObject>
@@ -267,6 +274,15 @@ export abstract class IndividualSimUI<SpecType extends Spec> extends SimUI {
 				return `Meta gem disabled (${metaGem.name}): ${getMetaGemConditionDescription(metaGem)}`;
 			},
 		});
+		this.addWarning({
+			updateOn: this.player.currentStatsEmitter,
+			getContent: () => {
+				const unsimulatedEffects = this.player.getCurrentStats().unsimulatedEffects;
+				return unsimulatedEffects.map(
+					effect => `${effect.name || effect.id} (${unsimulatedEffectTypeNames.get(effect.type)}) has a special effect which is not simulated.`,
+				);
+			},
+		});
 		this.addWarning({
 			updateOn: TypedEvent.onAny([this.player.gearChangeEmitter, this.player.professionChangeEmitter]),
 			getContent: () => {
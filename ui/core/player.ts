@@ -250,6 +250,8 @@ export class Player<SpecType extends Spec> {
 	private profession1: Profession = 0;
 	private profession2: Profession = 0;
 	aplRotation: APLRotation = APLRotation.create();
+	// Optional pet rotations keyed by pet name, there is no editor for these yet so they are only kept from imports.
+	private petRotations: { [petName: string]: APLRotation } = {};
 	private talentsString = '';
 	private glyphs: Glyphs = Glyphs.create();
 	private specOptions: SpecOptions<SpecType>;
@@ -847,6 +849,22 @@ export class Player<SpecType extends Spec> {
 		this.rotationChangeEmitter.emit(eventID);
 	}
 
+	getPetRotations(): { [petName: string]: APLRotation } {
+		return { ...this.petRotations };
+	}
+
+	setPetRotations(eventID: EventID, newPetRotations: { [petName: string]: APLRotation }) {
+		const names = Object.keys(newPetRotations);
+		if (
+			names.length == Object.keys(this.petRotations).length &&
+			names.every(name => this.petRotations[name] && APLRotation.equals(newPetRotations[name], this.petRotations[name]))
+		)
+			return;
+
+		this.petRotations = Object.fromEntries(names.map(name => [name, APLRotation.clone(newPetRotations[name])]));
+		this.rotationChangeEmitter.emit(eventID);
+	}
+
 	getSimpleRotation(): SpecRotation<SpecType> {
 		const jsonStr = this.aplRotation.simple?.specRotationJson || '';
 		if (!jsonStr) {
@@ -1508,6 +1526,7 @@ export class Player<SpecType extends Spec> {
 					hpPercentForDefensives: this.getSimpleCooldowns().hpPercentForDefensives,
 				}),
 				rotation: aplRotation,
+				petRotations: omitDeep(this.petRotations, ['uuid']),
 			});
 		}
 		if (exportCategory(SimSettingCategories.Consumes)) {
@@ -1567,6 +1586,7 @@ export class Player<SpecType extends Spec> {
 					proto.rotation.type = APLRotationType.TypeAuto;
 				}
 				this.setAplRotation(eventID, proto.rotation || APLRotation.create());
+				this.setPetRotations(eventID, proto.petRotations || {});
 			}
 			if (loadCategory(SimSettingCategories.Consumes)) {
 				this.setConsumes(eventID, proto.consumables || ConsumesSpec.create());
@@ -146,6 +146,14 @@ export class ActionId {
 				baseName = 'Encounter Start';
 				iconUrl = 'https://wow.zamimg.com/images/wow/icons/medium/achievement_faction_elders.jpg';
 				break;
+			case OtherAction.OtherActionRaidDamage:
+				baseName = 'Raid Damage';
+				iconUrl = 'https://wow.zamimg.com/images/wow/icons/medium/spell_fire_selfdestruct.jpg';
+				break;
+			case OtherAction.OtherActionEncounterScript:
+				baseName = 'Encounter Script';
+				iconUrl = 'https://wow.zamimg.com/images/wow/icons/medium/inv_misc_note_01.jpg';
+				break;
 		}
 		this.baseName = baseName ?? '';
 		this.name = (name || baseName) ?? '';
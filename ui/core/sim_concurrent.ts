@@ -8,6 +8,7 @@ import {
 	RaidSimResult,
 	RaidSimResultCombinationRequest,
 	StatWeightsCalcRequest,
+	StatWeightsRegressionSampleResult,
 	StatWeightsRequest,
 	StatWeightsResult,
 	StatWeightsStatResultData,
@@ -245,6 +246,12 @@ export const runConcurrentStatWeights = async (
 		simsTotal += 2;
 	}
 
+	for (const sample of manualResponse.regressionSamples) {
+		sample.request!.requestId = id;
+		iterationsTotal += sample.request!.simOptions!.iterations;
+		simsTotal += 1;
+	}
+
 	console.log(`Need to run a total of ${simsTotal} sims and ${iterationsTotal} iterations.`);
 
 	let lastIterations = 0;
@@ -271,6 +278,8 @@ export const runConcurrentStatWeights = async (
 		baseResult: baseLine,
 		epReferenceStat: manualResponse.epReferenceStat,
 		statSimResults: [],
+		regressionResults: [],
+		regressionOptions: manualResponse.regressionOptions,
 	});
 
 	for (const statReqData of manualResponse.statSimRequests) {
@@ -293,6 +302,21 @@ export const runConcurrentStatWeights = async (
 		);
 	}
 
+	for (const sample of manualResponse.regressionSamples) {
+		if (signals.abort.isTriggered()) return makeAndSendWeightsError(ErrorOutcome.create({ type: ErrorOutcomeType.ErrorOutcomeAborted }), onProgress);
+
+		lastIterations = 0;
+		const sampleRes = await runConcurrentSim(sample.request!, workerPool, progressHandler, signals);
+		if (sampleRes.error) return makeAndSendWeightsError(sampleRes.error, onProgress);
+
+		calcRequest.regressionResults.push(
+			StatWeightsRegressionSampleResult.create({
+				statMods: sample.statMods,
+				result: sampleRes,
+			}),
+		);
+	}
+
 	console.log(`All ${simsTotal} sims finished successfully. Computing weights.`);
 
 	const weightResult = await workerPool.statWeightCompute(calcRequest);
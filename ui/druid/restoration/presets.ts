@@ -2,6 +2,7 @@ import * as PresetUtils from '../../core/preset_utils';
 import { ConsumesSpec, Debuffs, IndividualBuffs, PartyBuffs, RaidBuffs, Stat, UnitReference } from '../../core/proto/common';
 import { RestorationDruid_Options as RestorationDruidOptions } from '../../core/proto/druid';
 import { SavedTalents } from '../../core/proto/ui';
+import DefaultApl from './apls/default.apl.json';
 // Preset options for this spec.
 // Eventually we will import these values for the raid sim too, so its good to
 // keep them in a separate file.
@@ -18,6 +19,8 @@ import { defaultRaidBuffMajorDamageCooldowns } from '../../core/proto_utils/util
 import P4Gear from './gear_sets/p4.gear.json';
 export const P4_PRESET = PresetUtils.makePresetGear('P4 Preset', P4Gear);
 
+export const ROTATION_PRESET_DEFAULT = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
+
 export const P1_EP_PRESET = PresetUtils.makePresetEpWeights(
 	'P1',
 	Stats.fromMap({
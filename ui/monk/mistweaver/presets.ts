@@ -3,6 +3,7 @@ import { ConsumesSpec, Glyphs, Profession, PseudoStat, Stat } from '../../core/p
 import { MistweaverMonk_Options as MistweaverMonkOptions, MonkMajorGlyph, MonkMinorGlyph, MonkStance } from '../../core/proto/monk';
 import { SavedTalents } from '../../core/proto/ui';
 import { Stats, UnitStat, UnitStatPresets } from '../../core/proto_utils/stats';
+import DefaultApl from './apls/default.apl.json';
 import DefaultGear from './gear_sets/default.gear.json';
 
 // Preset options for this spec.
@@ -11,6 +12,8 @@ import DefaultGear from './gear_sets/default.gear.json';
 
 export const PREBIS_GEAR_PRESET = PresetUtils.makePresetGear('Default', DefaultGear);
 
+export const ROTATION_PRESET = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
+
 // Preset options for EP weights
 export const DEFAULT_EP_PRESET = PresetUtils.makePresetEpWeights(
 	'Default',
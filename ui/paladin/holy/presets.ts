@@ -8,6 +8,7 @@ import {
 import { SavedTalents } from '../../core/proto/ui.js';
 import { Stats } from '../../core/proto_utils/stats';
 import { defaultRaidBuffMajorDamageCooldowns } from '../../core/proto_utils/utils';
+import DefaultApl from './apls/default.apl.json';
 import P1Gear from './gear_sets/p1.gear.json';
 
 // Preset options for this spec.
@@ -16,6 +17,8 @@ import P1Gear from './gear_sets/p1.gear.json';
 
 export const P1_GEAR_PRESET = PresetUtils.makePresetGear('P1 Preset', P1Gear);
 
+export const ROTATION_PRESET_DEFAULT = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
+
 // Preset options for EP weights
 export const P1_EP_PRESET = PresetUtils.makePresetEpWeights(
 	'P1',
@@ -35,10 +38,11 @@ export const P1_EP_PRESET = PresetUtils.makePresetEpWeights(
 export const StandardTalents = {
 	name: 'Standard',
 	data: SavedTalents.create({
-		talentsString: '',
+		talentsString: '312231',
 		glyphs: Glyphs.create({
-			major1: MajorGlyph.GlyphOfDivinePlea,
-			major2: MajorGlyph.GlyphOfDivinity,
+			major1: MajorGlyph.GlyphOfDivinity,
+			major2: MajorGlyph.GlyphOfBeaconOfLight,
+			major3: MajorGlyph.GlyphOfIllumination,
 		}),
 	}),
 };
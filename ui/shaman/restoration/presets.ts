@@ -1,8 +1,9 @@
 import * as PresetUtils from '../../core/preset_utils.js';
-import { ConsumesSpec, Stat } from '../../core/proto/common.js';
-import { RestorationShaman_Options as RestorationShamanOptions, ShamanMajorGlyph, ShamanMinorGlyph, ShamanShield } from '../../core/proto/shaman.js';
+import { ConsumesSpec, Glyphs, Stat } from '../../core/proto/common.js';
+import { RestorationShaman_Options as RestorationShamanOptions, ShamanMajorGlyph, ShamanShield } from '../../core/proto/shaman.js';
 import { SavedTalents } from '../../core/proto/ui.js';
 import { Stats } from '../../core/proto_utils/stats';
+import DefaultApl from './apls/default.apl.json';
 import P1Gear from './gear_sets/p1.gear.json';
 import P2Gear from './gear_sets/p2.gear.json';
 import P3Gear from './gear_sets/p3.gear.json';
@@ -19,6 +20,8 @@ export const P2_PRESET = PresetUtils.makePresetGear('P2 Preset', P2Gear);
 export const P3_PRESET = PresetUtils.makePresetGear('P3 Preset', P3Gear);
 export const P4_PRESET = PresetUtils.makePresetGear('P4 Preset', P4Gear);
 
+export const ROTATION_PRESET_DEFAULT = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
+
 // Preset options for EP weights
 export const P1_EP_PRESET = PresetUtils.makePresetEpWeights(
 	'P1',
@@ -37,29 +40,21 @@ export const P1_EP_PRESET = PresetUtils.makePresetEpWeights(
 export const TankHealingTalents = {
 	name: 'Tank Healing',
 	data: SavedTalents.create({
-		// talentsString: '-30205033-05005331335010501122331251',
-		// glyphs: Glyphs.create({
-		// 	major1: ShamanMajorGlyph.GlyphOfEarthlivingWeapon,
-		// 	major2: ShamanMajorGlyph.GlyphOfEarthShield,
-		// 	major3: ShamanMajorGlyph.GlyphOfLesserHealingWave,
-		// 	minor2: ShamanMinorGlyph.GlyphOfWaterShield,
-		// 	minor1: ShamanMinorGlyph.GlyphOfRenewedLife,
-		// 	minor3: ShamanMinorGlyph.GlyphOfGhostWolf,
-		// }),
+		talentsString: '313231',
+		glyphs: Glyphs.create({
+			major1: ShamanMajorGlyph.GlyphOfWaterShield,
+			major2: ShamanMajorGlyph.GlyphOfHealingWave,
+		}),
 	}),
 };
 export const RaidHealingTalents = {
 	name: 'Raid Healing',
 	data: SavedTalents.create({
-		// talentsString: '-3020503-50005331335310501122331251',
-		// glyphs: Glyphs.create({
-		// 	major1: ShamanMajorGlyph.GlyphOfChainHeal,
-		// 	major2: ShamanMajorGlyph.GlyphOfEarthShield,
-		// 	major3: ShamanMajorGlyph.GlyphOfEarthlivingWeapon,
-		// 	minor2: ShamanMinorGlyph.GlyphOfWaterShield,
-		// 	minor1: ShamanMinorGlyph.GlyphOfRenewedLife,
-		// 	minor3: ShamanMinorGlyph.GlyphOfGhostWolf,
-		// }),
+		talentsString: '313231',
+		glyphs: Glyphs.create({
+			major1: ShamanMajorGlyph.GlyphOfWaterShield,
+			major2: ShamanMajorGlyph.GlyphOfRiptide,
+		}),
 	}),
 };
 